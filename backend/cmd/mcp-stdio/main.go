@@ -0,0 +1,152 @@
+// Command mcp-stdio serves the memory tools over stdio for desktop MCP
+// clients that spawn their servers as child processes.
+//
+// With a remote URL it proxies to the Streamable HTTP endpoint of a running
+// backend, authenticating with an API key. Otherwise it opens the database
+// itself and acts on the tenant of the API key or of the configured tenant ID.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mark3labs/mcp-go/server"
+
+	"evolutionary-mcp/backend/internal/auth"
+	"evolutionary-mcp/backend/internal/config"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/logging"
+	"evolutionary-mcp/backend/internal/mcp"
+	"evolutionary-mcp/backend/internal/quota"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/internal/services"
+	"evolutionary-mcp/backend/pkg/models"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// stdout carries the protocol; everything else goes to stderr.
+	logger := logging.NewLoggerTo(os.Stderr)
+
+	envFile := flag.String("env", "", "Path to .env file")
+	apiKey := flag.String("api-key", "", "API key of the tenant (overrides mcp.stdio.api_key / MCP_API_KEY)")
+	tenantID := flag.String("tenant", "", "Tenant ID for direct database access without an API key (overrides mcp.stdio.tenant_id / MCP_TENANT_ID)")
+	remoteURL := flag.String("remote", "", "Base URL of a backend to proxy to, e.g. https://memory.example.com (overrides mcp.stdio.remote_url / MCP_REMOTE_URL)")
+	flag.Parse()
+
+	// A remote proxy needs nothing but the URL and key, so a missing config
+	// file is only fatal in direct mode.
+	cfg, cfgErr := config.LoadConfig(*envFile)
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	stdio := cfg.MCP.Stdio
+	if *apiKey != "" {
+		stdio.APIKey = *apiKey
+	}
+	if *tenantID != "" {
+		stdio.TenantID = *tenantID
+	}
+	if *remoteURL != "" {
+		stdio.RemoteURL = *remoteURL
+	}
+
+	if stdio.RemoteURL != "" {
+		if stdio.APIKey == "" {
+			log.Fatal("an API key is required to proxy to a remote backend")
+		}
+		endpoint := strings.TrimRight(stdio.RemoteURL, "/")
+		if !strings.HasSuffix(endpoint, "/mcp") {
+			endpoint += "/mcp"
+		}
+		proxy := mcp.NewStdioProxy(endpoint, stdio.APIKey, logger)
+		if err := proxy.Run(ctx, os.Stdin, os.Stdout); err != nil {
+			log.Fatalf("MCP proxy failed: %v", err)
+		}
+		return
+	}
+
+	if cfgErr != nil {
+		log.Fatalf("Configuration loading failed: %v", cfgErr)
+	}
+	if err := serveDirect(ctx, cfg, stdio.APIKey, stdio.TenantID, logger); err != nil {
+		log.Fatalf("MCP stdio server failed: %v", err)
+	}
+}
+
+// serveDirect runs the MCP server in-process against the database.
+func serveDirect(ctx context.Context, cfg *config.Config, apiKey, tenantID string, logger *logging.Logger) error {
+	connStr := fmt.Sprintf(
+		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		cfg.DB.Host, cfg.DB.Port, cfg.DB.User, cfg.DB.Password, cfg.DB.Name, cfg.DB.SSLMode,
+	)
+	pool, err := pgxpool.New(ctx, connStr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to DB: %w", err)
+	}
+	defer pool.Close()
+
+	store := repository.NewPostgresMemoryStore(pool, logger)
+
+	identity, err := resolveIdentity(ctx, store, apiKey, tenantID)
+	if err != nil {
+		return err
+	}
+
+	quotas := quota.NewEnforcer(quota.Limits{
+		MaxMemories:           cfg.Quotas.MaxMemories,
+		EmbeddingsPerMinute:   cfg.Quotas.EmbeddingsPerMinute,
+		RESTRequestsPerSecond: cfg.Quotas.RESTRequestsPerSecond,
+		MCPRequestsPerSecond:  cfg.Quotas.MCPRequestsPerSecond,
+	}, store, logger)
+	mlClient := services.NewHTTPMLClient(cfg.MLSidecar.URL)
+	memoryService := services.NewMemoryService(store, mlClient).WithQuotas(quotas)
+	workflowService := services.NewWorkflowService(store).WithEmbeddings(mlClient, quotas)
+	mcpServer := mcp.NewServer(memoryService, workflowService, quotas)
+	bus := events.NewBus()
+	bus.Subscribe(mcpServer.Notify)
+	memoryService.WithEvents(bus)
+	workflowService.WithEvents(bus)
+
+	logger.Info("MCP stdio server ready")
+	return mcpServer.ServeStdio(ctx, identity, logger.Logger, os.Stdin, os.Stdout)
+}
+
+// resolveIdentity authenticates the process to a tenant, preferring an API
+// key over a bare tenant ID, and returns a function that stamps the identity
+// onto the context of every MCP request.
+func resolveIdentity(ctx context.Context, store repository.Repository, apiKey, tenantID string) (server.StdioContextFunc, error) {
+	if apiKey != "" {
+		key, _, err := auth.AuthenticateAPIKey(ctx, store, apiKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to authenticate API key: %w", err)
+		}
+		return func(ctx context.Context) context.Context { return auth.WithAPIKey(ctx, key) }, nil
+	}
+
+	if tenantID == "" {
+		return nil, fmt.Errorf("an API key or tenant ID is required")
+	}
+	tenant, err := store.GetTenantByID(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tenant %s: %w", tenantID, err)
+	}
+	if tenant.Status == models.TenantStatusSuspended {
+		return nil, fmt.Errorf("tenant %s is suspended", tenantID)
+	}
+	return func(ctx context.Context) context.Context {
+		ctx = contextutil.WithTenant(ctx, tenant.ID)
+		ctx = contextutil.WithUser(ctx, "stdio")
+		return contextutil.WithScopes(ctx, []string{auth.ScopeEvolveRead, auth.ScopeEvolveWrite})
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"evolutionary-mcp/backend/internal/config"
+	"evolutionary-mcp/backend/internal/contextutil"
 	"evolutionary-mcp/backend/internal/logging"
 	"evolutionary-mcp/backend/internal/repository"
 	"evolutionary-mcp/backend/pkg/models"
@@ -54,7 +55,7 @@ func main() {
 	}
 
 	// Inject tenant_id into context for subsequent operations
-	ctx = context.WithValue(ctx, "tenant_id", tenant.ID)
+	ctx = contextutil.WithTenant(ctx, tenant.ID)
 
 	// 2. Check for existing workflows to prevent duplicates
 	existingWorkflows, err := store.ListWorkflows(ctx)
@@ -73,8 +74,8 @@ func main() {
 		Description string
 		Status      string
 	}{
-		{"Summarizer", "Summarizes long conversations into concise notes.", "active"},
-		{"Fact Checker", "Verifies claims against stored long-term memories.", "active"},
+		{"Summarizer", "Summarizes long conversations into concise notes.", "published"},
+		{"Fact Checker", "Verifies claims against stored long-term memories.", "published"},
 		{"Code Reviewer", "Analyzes code snippets for style and bugs.", "draft"},
 	}
 
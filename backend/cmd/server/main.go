@@ -20,8 +20,11 @@ import (
 	"evolutionary-mcp/backend/internal/api"
 	"evolutionary-mcp/backend/internal/auth"
 	"evolutionary-mcp/backend/internal/config"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
 	"evolutionary-mcp/backend/internal/logging"
 	"evolutionary-mcp/backend/internal/mcp"
+	"evolutionary-mcp/backend/internal/quota"
 	"evolutionary-mcp/backend/internal/repository"
 	"evolutionary-mcp/backend/internal/services"
 )
@@ -73,7 +76,16 @@ func main() {
 
 	// Initialize service layer
 	mlClient := services.NewHTTPMLClient(cfg.MLSidecar.URL)
-	memoryService := services.NewMemoryService(memoryStore, mlClient)
+	quotas := quota.NewEnforcer(quota.Limits{
+		MaxMemories:           cfg.Quotas.MaxMemories,
+		EmbeddingsPerMinute:   cfg.Quotas.EmbeddingsPerMinute,
+		RESTRequestsPerSecond: cfg.Quotas.RESTRequestsPerSecond,
+		MCPRequestsPerSecond:  cfg.Quotas.MCPRequestsPerSecond,
+	}, memoryStore, logger)
+	memoryService := services.NewMemoryService(memoryStore, mlClient).WithQuotas(quotas)
+	tenantService := services.NewTenantService(memoryStore)
+	statsService := services.NewStatsService(memoryStore)
+	workflowService := services.NewWorkflowService(memoryStore).WithEmbeddings(mlClient, quotas)
 
 	logger.Info("Service layer initialized")
 
@@ -107,25 +119,52 @@ func main() {
 	// the wrapped RequireAuth middleware.
 	apiGroup.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			if tid := c.Request().Context().Value("tenant_id"); tid != nil {
+			if tid := contextutil.GetTenant(c.Request().Context()); tid != "" {
 				c.Set("tenant_id", tid)
 			}
 			return next(c)
 		}
 	})
 
-	apiServer := api.NewServer(memoryStore)
+	apiGroup.Use(api.RateLimit(quotas))
+
+	apiServer := api.NewServer(memoryStore, tenantService, memoryService, workflowService, statsService)
 	api.RegisterHandlers(apiGroup, apiServer)
 
 	logger.Info("REST API handlers mounted")
 
 	// Mount MCP protocol handlers
-	mcpServer := mcp.NewServer(memoryService)
+	mcpServer := mcp.NewServer(memoryService, workflowService, quotas)
+	// Changes made through either API are announced to the tenant's MCP clients.
+	bus := events.NewBus()
+	bus.Subscribe(mcpServer.Notify)
+	memoryService.WithEvents(bus)
+	workflowService.WithEvents(bus)
 	mcpHandlers := http.NewServeMux()
-	mcp.MountHTTPHandlers(mcpHandlers, mcpServer.GetMCPServer())
-	e.Any("/mcp/*", echo.WrapHandler(mcpHandlers))
+	mcpOpts := mcp.HTTPOptions{
+		HeartbeatInterval:  cfg.MCP.HeartbeatInterval,
+		SessionIdleTimeout: cfg.MCP.SessionIdleTimeout,
+		ReplayBufferSize:   cfg.MCP.ReplayBufferSize,
+		Subscriptions:      mcpServer.Subscriptions(),
+	}
+	for _, t := range cfg.MCP.Transports {
+		switch t {
+		case config.MCPTransportSSE:
+			mcpOpts.SSE = true
+		case config.MCPTransportStreamableHTTP:
+			mcpOpts.StreamableHTTP = true
+		}
+	}
+	if err := mcp.MountHTTPHandlers(mcpHandlers, mcpServer.GetMCPServer(), mcpOpts); err != nil {
+		log.Fatalf("failed to mount MCP handlers: %v", err)
+	}
+	// MCP clients authenticate like REST clients; non-interactive clients
+	// such as cmd/mcp-stdio in proxy mode use an API key as bearer token.
+	mcpHandler := echo.WrapHandler(authz.RequireAuth(mcpHandlers))
+	e.Any("/mcp", mcpHandler)
+	e.Any("/mcp/*", mcpHandler)
 
-	logger.Info("MCP protocol handlers mounted")
+	logger.Info("MCP protocol handlers mounted", "transports", cfg.MCP.Transports)
 
 	// expose OpenAPI spec (with runtime substitution) and Swagger UI
 	e.GET("/openapi.yaml", echo.WrapHandler(http.HandlerFunc(api.SpecHandler(cfg.Auth.OktaDomain))))
@@ -62,7 +62,7 @@ func createEnvFile() {
 			Vars: []EnvVar{
 				{"DB_HOST", "localhost", "Database host"},
 				{"DB_PORT", "5432", "Database port"},
-				{"DB_USER", "user", "Database user"},
+				{"DB_USER", "evolve_app", "Database user (the application role, not the schema owner)"},
 				{"DB_PASSWORD", "password", "Database password"},
 				{"DB_NAME", "db", "Database name"},
 				{"DB_SSLMODE", "disable", "SSL mode (disable, require, verify-full)"},
@@ -84,6 +84,22 @@ func createEnvFile() {
 				{"AUTH_REDIRECT_URL", "http://localhost:8080/auth/callback", "Redirect URL for the backend callback"},
 			},
 		},
+		{
+			Name: "Tenancy",
+			Vars: []EnvVar{
+				{"TENANCY_AUTO_PROVISION", "on", "Create tenants for unknown email domains on first login (on, allowlist, off)"},
+				{"TENANCY_ALLOWED_DOMAINS", "", "Domains that may be auto-provisioned in allowlist mode (comma separated)"},
+			},
+		},
+		{
+			Name: "Per-Tenant Quotas (0 = unlimited)",
+			Vars: []EnvVar{
+				{"QUOTAS_MAX_MEMORIES", "0", "Maximum number of memories a tenant may store"},
+				{"QUOTAS_EMBEDDINGS_PER_MINUTE", "0", "Embeddings a tenant may generate per minute (remember/recall)"},
+				{"QUOTAS_REST_RPS", "0", "REST API requests per second per tenant"},
+				{"QUOTAS_MCP_RPS", "0", "MCP tool calls per second per tenant"},
+			},
+		},
 		{
 			Name: "TLS Configuration",
 			Vars: []EnvVar{
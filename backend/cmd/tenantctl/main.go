@@ -0,0 +1,471 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"evolutionary-mcp/backend/internal/config"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/logging"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/internal/services"
+	"evolutionary-mcp/backend/pkg/models"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tenants   *services.TenantService
+	workflows *services.WorkflowService
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "tenantctl",
+	Short: "Administer Evolutionary MCP tenants",
+	Long:  `A utility to onboard, update, suspend and remove tenants, and to move their workflows between environments, directly against the database.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadConfig("")
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		connStr := fmt.Sprintf(
+			"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+			cfg.DB.Host, cfg.DB.Port, cfg.DB.User, cfg.DB.Password, cfg.DB.Name, cfg.DB.SSLMode,
+		)
+		pool, err := pgxpool.New(cmd.Context(), connStr)
+		if err != nil {
+			return fmt.Errorf("failed to connect to DB: %w", err)
+		}
+
+		store := repository.NewPostgresMemoryStore(pool, logging.NewLogger())
+		tenants = services.NewTenantService(store)
+		workflows = services.NewWorkflowService(store).WithEmbeddings(services.NewHTTPMLClient(cfg.MLSidecar.URL), nil)
+		return nil
+	},
+	SilenceUsage: true,
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all tenants",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, err := tenants.ListTenants(cmd.Context())
+		if err != nil {
+			return err
+		}
+		return printJSON(list)
+	},
+}
+
+var getCmd = &cobra.Command{
+	Use:   "get <tenant-id>",
+	Short: "Show a single tenant",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tenant, err := tenants.GetTenant(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		return printJSON(tenant)
+	},
+}
+
+var createCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Onboard a new tenant",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		domains, _ := cmd.Flags().GetStringSlice("domain")
+		brandTitle, _ := cmd.Flags().GetString("brand-title")
+		logoSVG, err := readLogo(cmd)
+		if err != nil {
+			return err
+		}
+
+		tenant, err := tenants.CreateTenant(cmd.Context(), name, brandTitle, logoSVG, domains)
+		if err != nil {
+			return err
+		}
+		return printJSON(tenant)
+	},
+}
+
+var updateCmd = &cobra.Command{
+	Use:   "update <tenant-id>",
+	Short: "Update a tenant's name or branding",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var update services.TenantUpdate
+		if cmd.Flags().Changed("name") {
+			name, _ := cmd.Flags().GetString("name")
+			update.Name = &name
+		}
+		if cmd.Flags().Changed("brand-title") {
+			brandTitle, _ := cmd.Flags().GetString("brand-title")
+			update.BrandTitle = &brandTitle
+		}
+		if cmd.Flags().Changed("logo-svg") {
+			logoSVG, err := readLogo(cmd)
+			if err != nil {
+				return err
+			}
+			update.LogoSVG = &logoSVG
+		}
+
+		if quotas, changed := readQuotas(cmd, args[0]); changed {
+			update.Quotas = quotas
+		}
+
+		tenant, err := tenants.UpdateTenant(cmd.Context(), args[0], update)
+		if err != nil {
+			return err
+		}
+		return printJSON(tenant)
+	},
+}
+
+var suspendCmd = &cobra.Command{
+	Use:   "suspend <tenant-id>",
+	Short: "Block every user of a tenant from authenticating",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tenant, err := tenants.SuspendTenant(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		return printJSON(tenant)
+	},
+}
+
+var activateCmd = &cobra.Command{
+	Use:   "activate <tenant-id>",
+	Short: "Lift a tenant suspension",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tenant, err := tenants.ActivateTenant(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		return printJSON(tenant)
+	},
+}
+
+var deleteCmd = &cobra.Command{
+	Use:   "delete <tenant-id>",
+	Short: "Permanently delete a tenant and all of its data",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+			return fmt.Errorf("refusing to delete tenant %s without --yes", args[0])
+		}
+		if err := tenants.DeleteTenant(cmd.Context(), args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted tenant %s\n", args[0])
+		return nil
+	},
+}
+
+var domainsCmd = &cobra.Command{
+	Use:   "domains",
+	Short: "Manage the email domains mapped to a tenant",
+}
+
+var domainsAddCmd = &cobra.Command{
+	Use:   "add <tenant-id> <domain>",
+	Short: "Map an email domain to a tenant",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tenant, err := tenants.AddDomain(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return printJSON(tenant)
+	},
+}
+
+var domainsRemoveCmd = &cobra.Command{
+	Use:   "remove <tenant-id> <domain>",
+	Short: "Unmap an email domain from a tenant",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tenant, err := tenants.RemoveDomain(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return printJSON(tenant)
+	},
+}
+
+var membersCmd = &cobra.Command{
+	Use:   "members",
+	Short: "Manage users with explicit access to a tenant",
+}
+
+var membersListCmd = &cobra.Command{
+	Use:   "list <tenant-id>",
+	Short: "List the explicit members of a tenant",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		members, err := tenants.ListMembers(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		return printJSON(members)
+	},
+}
+
+var membersAddCmd = &cobra.Command{
+	Use:   "add <tenant-id> <email>",
+	Short: "Grant a user access to a tenant regardless of their email domain",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		member, err := tenants.AddMember(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return printJSON(member)
+	},
+}
+
+var membersRemoveCmd = &cobra.Command{
+	Use:   "remove <tenant-id> <email>",
+	Short: "Revoke a user's explicit access to a tenant",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := tenants.RemoveMember(cmd.Context(), args[0], args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Removed %s from tenant %s\n", args[1], args[0])
+		return nil
+	},
+}
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage the API keys that authenticate non-interactive clients to a tenant",
+}
+
+var keysListCmd = &cobra.Command{
+	Use:   "list <tenant-id>",
+	Short: "List a tenant's API keys",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keys, err := tenants.ListAPIKeys(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		return printJSON(keys)
+	},
+}
+
+var keysCreateCmd = &cobra.Command{
+	Use:   "create <tenant-id>",
+	Short: "Issue an API key; the secret is printed once",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		scopes, _ := cmd.Flags().GetStringSlice("scope")
+		key, secret, err := tenants.CreateAPIKey(cmd.Context(), args[0], name, scopes)
+		if err != nil {
+			return err
+		}
+		return printJSON(struct {
+			*models.APIKey
+			Secret string `json:"secret"`
+		}{key, secret})
+	},
+}
+
+var keysRevokeCmd = &cobra.Command{
+	Use:   "revoke <tenant-id> <key-id>",
+	Short: "Permanently disable an API key",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := tenants.RevokeAPIKey(cmd.Context(), args[0], args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Revoked API key %s of tenant %s\n", args[1], args[0])
+		return nil
+	},
+}
+
+var workflowsCmd = &cobra.Command{
+	Use:   "workflows",
+	Short: "Export and import a tenant's workflows",
+}
+
+var workflowsExportCmd = &cobra.Command{
+	Use:   "export <tenant-id> <workflow-id>",
+	Short: "Export a workflow as a portable bundle",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		allVersions, _ := cmd.Flags().GetBool("all-versions")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		ctx, err := workflowCtx(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		bundle, err := workflows.ExportWorkflow(ctx, args[1], allVersions)
+		if err != nil {
+			return err
+		}
+		data, err := services.EncodeWorkflowBundle(bundle, format)
+		if err != nil {
+			return err
+		}
+		if output == "" || output == "-" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+		return os.WriteFile(output, data, 0o644)
+	},
+}
+
+var workflowsImportCmd = &cobra.Command{
+	Use:   "import <tenant-id> <bundle-file>",
+	Short: "Import a workflow bundle under new IDs",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to read bundle: %w", err)
+		}
+		bundle, err := services.DecodeWorkflowBundle(data)
+		if err != nil {
+			return err
+		}
+
+		ctx, err := workflowCtx(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		imported, err := workflows.ImportWorkflow(ctx, bundle)
+		if err != nil {
+			return err
+		}
+		return printJSON(imported)
+	},
+}
+
+func init() {
+	createCmd.Flags().String("name", "", "Display name of the tenant")
+	createCmd.Flags().StringSlice("domain", nil, "Email domain (repeatable; the first is the primary domain)")
+	createCmd.Flags().String("brand-title", "", "Title shown in the frontend")
+	createCmd.Flags().String("logo-svg", "", "Path to an SVG logo file")
+	_ = createCmd.MarkFlagRequired("name")
+	_ = createCmd.MarkFlagRequired("domain")
+
+	updateCmd.Flags().String("name", "", "Display name of the tenant")
+	updateCmd.Flags().String("brand-title", "", "Title shown in the frontend")
+	updateCmd.Flags().String("logo-svg", "", "Path to an SVG logo file")
+	updateCmd.Flags().Int("max-memories", 0, "Maximum stored memories (0 = unlimited, -1 = server default)")
+	updateCmd.Flags().Int("embeddings-per-minute", 0, "Embeddings per minute (0 = unlimited, -1 = server default)")
+	updateCmd.Flags().Float64("rest-rps", 0, "REST requests per second (0 = unlimited, -1 = server default)")
+	updateCmd.Flags().Float64("mcp-rps", 0, "MCP tool calls per second (0 = unlimited, -1 = server default)")
+
+	deleteCmd.Flags().Bool("yes", false, "Confirm deletion")
+
+	keysCreateCmd.Flags().String("name", "", "Name identifying where the key is used")
+	keysCreateCmd.Flags().StringSlice("scope", nil, "Scope granted to the key (repeatable; default evolve:read and evolve:write)")
+	_ = keysCreateCmd.MarkFlagRequired("name")
+
+	workflowsExportCmd.Flags().Bool("all-versions", false, "Export every version instead of only the latest")
+	workflowsExportCmd.Flags().String("format", services.BundleEncodingYAML, "Bundle encoding: json or yaml")
+	workflowsExportCmd.Flags().StringP("output", "o", "", "File to write the bundle to (default stdout)")
+
+	domainsCmd.AddCommand(domainsAddCmd, domainsRemoveCmd)
+	membersCmd.AddCommand(membersListCmd, membersAddCmd, membersRemoveCmd)
+	keysCmd.AddCommand(keysListCmd, keysCreateCmd, keysRevokeCmd)
+	workflowsCmd.AddCommand(workflowsExportCmd, workflowsImportCmd)
+	rootCmd.AddCommand(listCmd, getCmd, createCmd, updateCmd, suspendCmd, activateCmd, deleteCmd, domainsCmd, membersCmd, keysCmd, workflowsCmd)
+}
+
+func main() {
+	if err := rootCmd.ExecuteContext(context.Background()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// readLogo returns the contents of the file named by --logo-svg, if any.
+func readLogo(cmd *cobra.Command) (string, error) {
+	path, _ := cmd.Flags().GetString("logo-svg")
+	if path == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read logo: %w", err)
+	}
+	return string(data), nil
+}
+
+// readQuotas merges the quota flags given on the command line onto the
+// tenant's current overrides. A value of -1 resets a quota to the server
+// default.
+func readQuotas(cmd *cobra.Command, id string) (*models.TenantQuotas, bool) {
+	flags := cmd.Flags()
+	if !flags.Changed("max-memories") && !flags.Changed("embeddings-per-minute") &&
+		!flags.Changed("rest-rps") && !flags.Changed("mcp-rps") {
+		return nil, false
+	}
+
+	var quotas models.TenantQuotas
+	if current, err := tenants.GetTenant(cmd.Context(), id); err == nil {
+		quotas = current.Quotas
+	}
+	if flags.Changed("max-memories") {
+		v, _ := flags.GetInt("max-memories")
+		quotas.MaxMemories = intOverride(v)
+	}
+	if flags.Changed("embeddings-per-minute") {
+		v, _ := flags.GetInt("embeddings-per-minute")
+		quotas.EmbeddingsPerMinute = intOverride(v)
+	}
+	if flags.Changed("rest-rps") {
+		v, _ := flags.GetFloat64("rest-rps")
+		quotas.RESTRequestsPerSecond = floatOverride(v)
+	}
+	if flags.Changed("mcp-rps") {
+		v, _ := flags.GetFloat64("mcp-rps")
+		quotas.MCPRequestsPerSecond = floatOverride(v)
+	}
+	return &quotas, true
+}
+
+func intOverride(v int) *int {
+	if v == -1 {
+		return nil
+	}
+	return &v
+}
+
+func floatOverride(v float64) *float64 {
+	if v == -1 {
+		return nil
+	}
+	return &v
+}
+
+// workflowCtx scopes ctx to an existing tenant, acting as tenantctl.
+func workflowCtx(ctx context.Context, tenantID string) (context.Context, error) {
+	if _, err := tenants.GetTenant(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	return contextutil.WithUser(contextutil.WithTenant(ctx, tenantID), "tenantctl"), nil
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
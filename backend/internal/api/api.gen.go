@@ -17,6 +17,114 @@ const (
 	OpenIdConnectScopes = "openIdConnect.Scopes"
 )
 
+// Defines values for BundledGroundingRuleStatus.
+const (
+	BundledGroundingRuleStatusActive   BundledGroundingRuleStatus = "active"
+	BundledGroundingRuleStatusPending  BundledGroundingRuleStatus = "pending"
+	BundledGroundingRuleStatusRejected BundledGroundingRuleStatus = "rejected"
+)
+
+// Defines values for BundledWorkflowElementType.
+const (
+	BundledWorkflowElementTypeDetail   BundledWorkflowElementType = "detail"
+	BundledWorkflowElementTypeElement  BundledWorkflowElementType = "element"
+	BundledWorkflowElementTypeWorkflow BundledWorkflowElementType = "workflow"
+)
+
+// Defines values for BundledWorkflowStatus.
+const (
+	BundledWorkflowStatusArchived   BundledWorkflowStatus = "archived"
+	BundledWorkflowStatusDeprecated BundledWorkflowStatus = "deprecated"
+	BundledWorkflowStatusDraft      BundledWorkflowStatus = "draft"
+	BundledWorkflowStatusPublished  BundledWorkflowStatus = "published"
+	BundledWorkflowStatusReview     BundledWorkflowStatus = "review"
+)
+
+// Defines values for CompatibilityReportCompatibility.
+const (
+	CompatibilityReportCompatibilityBackward CompatibilityReportCompatibility = "backward"
+	CompatibilityReportCompatibilityForward  CompatibilityReportCompatibility = "forward"
+	CompatibilityReportCompatibilityFull     CompatibilityReportCompatibility = "full"
+	CompatibilityReportCompatibilityNone     CompatibilityReportCompatibility = "none"
+)
+
+// Defines values for ExportWorkflowParamsFormat.
+const (
+	Json ExportWorkflowParamsFormat = "json"
+	Yaml ExportWorkflowParamsFormat = "yaml"
+)
+
+// Defines values for ExportWorkflowParamsVersions.
+const (
+	All    ExportWorkflowParamsVersions = "all"
+	Latest ExportWorkflowParamsVersions = "latest"
+)
+
+// Defines values for FeedbackSignal.
+const (
+	Helpful    FeedbackSignal = "helpful"
+	Incorrect  FeedbackSignal = "incorrect"
+	NotHelpful FeedbackSignal = "not_helpful"
+	Outdated   FeedbackSignal = "outdated"
+)
+
+// Defines values for GroundingRuleStatus.
+const (
+	GroundingRuleStatusActive   GroundingRuleStatus = "active"
+	GroundingRuleStatusArchived GroundingRuleStatus = "archived"
+	GroundingRuleStatusPending  GroundingRuleStatus = "pending"
+	GroundingRuleStatusRejected GroundingRuleStatus = "rejected"
+)
+
+// Defines values for GroundingRuleVersionStatus.
+const (
+	GroundingRuleVersionStatusApproved GroundingRuleVersionStatus = "approved"
+	GroundingRuleVersionStatusPending  GroundingRuleVersionStatus = "pending"
+	GroundingRuleVersionStatusRejected GroundingRuleVersionStatus = "rejected"
+)
+
+// Defines values for MetricComparisonMetric.
+const (
+	AverageConfidence    MetricComparisonMetric = "average_confidence"
+	PositiveFeedbackRate MetricComparisonMetric = "positive_feedback_rate"
+	RunSuccessRate       MetricComparisonMetric = "run_success_rate"
+)
+
+// Defines values for SchemaChangeCompatibility.
+const (
+	SchemaChangeCompatibilityBackward SchemaChangeCompatibility = "backward"
+	SchemaChangeCompatibilityForward  SchemaChangeCompatibility = "forward"
+	SchemaChangeCompatibilityFull     SchemaChangeCompatibility = "full"
+	SchemaChangeCompatibilityNone     SchemaChangeCompatibility = "none"
+)
+
+// Defines values for SchemaValidationSchema.
+const (
+	SchemaValidationSchemaInput  SchemaValidationSchema = "input"
+	SchemaValidationSchemaOutput SchemaValidationSchema = "output"
+)
+
+// Defines values for VersionComparisonVerdict.
+const (
+	Better           VersionComparisonVerdict = "better"
+	InsufficientData VersionComparisonVerdict = "insufficient_data"
+	Mixed            VersionComparisonVerdict = "mixed"
+	NoDifference     VersionComparisonVerdict = "no_difference"
+	Worse            VersionComparisonVerdict = "worse"
+)
+
+// Defines values for WorkflowBundleFormat.
+const (
+	EvolutionaryMcpWorkflowBundleV1 WorkflowBundleFormat = "evolutionary-mcp/workflow-bundle/v1"
+)
+
+// Defines values for WorkflowChangeKind.
+const (
+	Added   WorkflowChangeKind = "added"
+	Changed WorkflowChangeKind = "changed"
+	Removed WorkflowChangeKind = "removed"
+)
+
 // Defines values for WorkflowElementType.
 const (
 	WorkflowElementTypeDetail   WorkflowElementType = "detail"
@@ -24,25 +132,212 @@ const (
 	WorkflowElementTypeWorkflow WorkflowElementType = "workflow"
 )
 
+// Defines values for WorkflowNodeElementType.
+const (
+	WorkflowNodeElementTypeDetail   WorkflowNodeElementType = "detail"
+	WorkflowNodeElementTypeElement  WorkflowNodeElementType = "element"
+	WorkflowNodeElementTypeWorkflow WorkflowNodeElementType = "workflow"
+)
+
+// Defines values for WorkflowNodeStatus.
+const (
+	WorkflowNodeStatusArchived   WorkflowNodeStatus = "archived"
+	WorkflowNodeStatusDeprecated WorkflowNodeStatus = "deprecated"
+	WorkflowNodeStatusDraft      WorkflowNodeStatus = "draft"
+	WorkflowNodeStatusPublished  WorkflowNodeStatus = "published"
+	WorkflowNodeStatusReview     WorkflowNodeStatus = "review"
+)
+
+// Defines values for WorkflowPayloadValidationRequestSchema.
+const (
+	WorkflowPayloadValidationRequestSchemaInput  WorkflowPayloadValidationRequestSchema = "input"
+	WorkflowPayloadValidationRequestSchemaOutput WorkflowPayloadValidationRequestSchema = "output"
+)
+
+// Defines values for WorkflowRunCompletionRequestStatus.
+const (
+	WorkflowRunCompletionRequestStatusCancelled WorkflowRunCompletionRequestStatus = "cancelled"
+	WorkflowRunCompletionRequestStatusFailed    WorkflowRunCompletionRequestStatus = "failed"
+	WorkflowRunCompletionRequestStatusSucceeded WorkflowRunCompletionRequestStatus = "succeeded"
+)
+
+// Defines values for WorkflowRunStatus.
+const (
+	WorkflowRunStatusCancelled WorkflowRunStatus = "cancelled"
+	WorkflowRunStatusFailed    WorkflowRunStatus = "failed"
+	WorkflowRunStatusRunning   WorkflowRunStatus = "running"
+	WorkflowRunStatusSucceeded WorkflowRunStatus = "succeeded"
+)
+
 // Defines values for WorkflowStatus.
 const (
-	Active   WorkflowStatus = "active"
-	Archived WorkflowStatus = "archived"
-	Draft    WorkflowStatus = "draft"
+	WorkflowStatusArchived   WorkflowStatus = "archived"
+	WorkflowStatusDeprecated WorkflowStatus = "deprecated"
+	WorkflowStatusDraft      WorkflowStatus = "draft"
+	WorkflowStatusPublished  WorkflowStatus = "published"
+	WorkflowStatusReview     WorkflowStatus = "review"
 )
 
+// Defines values for WorkflowTransitionRequestStatus.
+const (
+	WorkflowTransitionRequestStatusArchived   WorkflowTransitionRequestStatus = "archived"
+	WorkflowTransitionRequestStatusDeprecated WorkflowTransitionRequestStatus = "deprecated"
+	WorkflowTransitionRequestStatusDraft      WorkflowTransitionRequestStatus = "draft"
+	WorkflowTransitionRequestStatusPublished  WorkflowTransitionRequestStatus = "published"
+	WorkflowTransitionRequestStatusReview     WorkflowTransitionRequestStatus = "review"
+)
+
+// BundledGroundingRule defines model for BundledGroundingRule.
+type BundledGroundingRule struct {
+	Content string                      `json:"content"`
+	Id      *string                     `json:"id,omitempty"`
+	Name    string                      `json:"name"`
+	Status  *BundledGroundingRuleStatus `json:"status,omitempty"`
+	// WorkflowId ID of the version in the bundle the rule is linked to
+	WorkflowId string `json:"workflow_id"`
+}
+
+// BundledGroundingRuleStatus defines model for BundledGroundingRule.Status.
+type BundledGroundingRuleStatus string
+
+// BundledWorkflow defines model for BundledWorkflow.
+type BundledWorkflow struct {
+	Description  *string                     `json:"description,omitempty"`
+	ElementType  *BundledWorkflowElementType `json:"element_type,omitempty"`
+	Id           string                      `json:"id"`
+	InputSchema  *map[string]interface{}     `json:"input_schema,omitempty"`
+	Name         string                      `json:"name"`
+	OutputSchema *map[string]interface{}     `json:"output_schema,omitempty"`
+	// ParentId ID of the parent version, when it is in the bundle
+	ParentId   *string                `json:"parent_id,omitempty"`
+	Position   *int                   `json:"position,omitempty"`
+	Status     *BundledWorkflowStatus `json:"status,omitempty"`
+	Version    int                    `json:"version"`
+	WorkflowId string                 `json:"workflow_id"`
+}
+
+// BundledWorkflowElementType defines model for BundledWorkflow.ElementType.
+type BundledWorkflowElementType string
+
+// BundledWorkflowStatus defines model for BundledWorkflow.Status.
+type BundledWorkflowStatus string
+
+// CompatibilityReport defines model for CompatibilityReport.
+type CompatibilityReport struct {
+	Breaking bool           `json:"breaking"`
+	Changes  []SchemaChange `json:"changes"`
+	// Compatibility The compatibility of all changes together
+	Compatibility CompatibilityReportCompatibility `json:"compatibility"`
+	FromVersion   int                              `json:"from_version"`
+	ToVersion     int                              `json:"to_version"`
+	WorkflowId    openapi_types.UUID               `json:"workflow_id"`
+}
+
+// CompatibilityReportCompatibility defines model for CompatibilityReport.Compatibility.
+type CompatibilityReportCompatibility string
+
+// ConfidenceBand Number of memories whose confidence lies in [min, max)
+type ConfidenceBand struct {
+	Count *int     `json:"count,omitempty"`
+	Max   *float32 `json:"max,omitempty"`
+	Min   *float32 `json:"min,omitempty"`
+}
+
+// DailyCount defines model for DailyCount.
+type DailyCount struct {
+	Count *int       `json:"count,omitempty"`
+	Day   *time.Time `json:"day,omitempty"`
+}
+
+// ExportWorkflowParamsFormat defines model for ExportWorkflowParams.Format.
+type ExportWorkflowParamsFormat string
+
+// ExportWorkflowParamsVersions defines model for ExportWorkflowParams.Versions.
+type ExportWorkflowParamsVersions string
+
+// FeedbackEvent defines model for FeedbackEvent.
+type FeedbackEvent struct {
+	// Confidence The confidence the memory was left with
+	Confidence *float32            `json:"confidence,omitempty"`
+	CreatedAt  *time.Time          `json:"created_at,omitempty"`
+	MemoryId   *openapi_types.UUID `json:"memory_id,omitempty"`
+	Query      *string             `json:"query,omitempty"`
+	Reason     *string             `json:"reason,omitempty"`
+	Signal     *FeedbackSignal     `json:"signal,omitempty"`
+	TenantId   *string             `json:"tenant_id,omitempty"`
+}
+
+// FeedbackSignal defines model for FeedbackSignal.
+type FeedbackSignal string
+
 // GroundingRule defines model for GroundingRule.
 type GroundingRule struct {
-	Content    *string             `json:"content,omitempty"`
-	CreatedAt  *time.Time          `json:"created_at,omitempty"`
-	Id         *openapi_types.UUID `json:"id,omitempty"`
-	IsGlobal   *bool               `json:"is_global,omitempty"`
-	Name       *string             `json:"name,omitempty"`
-	TenantId   *openapi_types.UUID `json:"tenant_id,omitempty"`
-	UpdatedAt  *time.Time          `json:"updated_at,omitempty"`
+	Content   *string             `json:"content,omitempty"`
+	CreatedAt *time.Time          `json:"created_at,omitempty"`
+	Id        *openapi_types.UUID `json:"id,omitempty"`
+	IsGlobal  *bool               `json:"is_global,omitempty"`
+	Name      *string             `json:"name,omitempty"`
+	// PendingVersions The number of proposed changes awaiting review.
+	PendingVersions *int `json:"pending_versions,omitempty"`
+	// ProposedBy The agent identity that proposed the rule, if any.
+	ProposedBy *string `json:"proposed_by,omitempty"`
+	// Status Rules proposed by agents are pending until their first version is approved or rejected, and deleted rules are archived. Only active rules are used at recall time.
+	Status    *GroundingRuleStatus `json:"status,omitempty"`
+	TenantId  *openapi_types.UUID  `json:"tenant_id,omitempty"`
+	UpdatedAt *time.Time           `json:"updated_at,omitempty"`
+	// Version The version in effect; sent as the rule's ETag.
+	Version    *int                `json:"version,omitempty"`
 	WorkflowId *openapi_types.UUID `json:"workflow_id"`
 }
 
+// GroundingRuleHits defines model for GroundingRuleHits.
+type GroundingRuleHits struct {
+	HitRate *float32            `json:"hit_rate,omitempty"`
+	Hits    *int                `json:"hits,omitempty"`
+	Name    *string             `json:"name,omitempty"`
+	RuleId  *openapi_types.UUID `json:"rule_id,omitempty"`
+}
+
+// GroundingRuleReview defines model for GroundingRuleReview.
+type GroundingRuleReview struct {
+	// Comment Why the version was approved or rejected.
+	Comment *string `json:"comment,omitempty"`
+}
+
+// GroundingRuleStatus defines model for GroundingRule.Status.
+type GroundingRuleStatus string
+
+// GroundingRuleVersion defines model for GroundingRuleVersion.
+type GroundingRuleVersion struct {
+	// BaseVersion The version in effect when the change was proposed; absent for the first version.
+	BaseVersion   *int                        `json:"base_version,omitempty"`
+	Content       *string                     `json:"content,omitempty"`
+	CreatedAt     *time.Time                  `json:"created_at,omitempty"`
+	Id            *int64                      `json:"id,omitempty"`
+	IsGlobal      *bool                       `json:"is_global,omitempty"`
+	Name          *string                     `json:"name,omitempty"`
+	ProposedBy    *string                     `json:"proposed_by,omitempty"`
+	ReviewComment *string                     `json:"review_comment,omitempty"`
+	ReviewedAt    *time.Time                  `json:"reviewed_at,omitempty"`
+	ReviewedBy    *string                     `json:"reviewed_by,omitempty"`
+	RuleId        *openapi_types.UUID         `json:"rule_id,omitempty"`
+	Status        *GroundingRuleVersionStatus `json:"status,omitempty"`
+	TenantId      *openapi_types.UUID         `json:"tenant_id,omitempty"`
+	Version       *int                        `json:"version,omitempty"`
+	WorkflowId    *openapi_types.UUID         `json:"workflow_id"`
+}
+
+// GroundingRuleVersionStatus defines model for GroundingRuleVersion.Status.
+type GroundingRuleVersionStatus string
+
+// GroundingStats How often recall queries matched grounding rules
+type GroundingStats struct {
+	HitRate          *float32             `json:"hit_rate,omitempty"`
+	Recalls          *int                 `json:"recalls,omitempty"`
+	RecallsWithRules *int                 `json:"recalls_with_rules,omitempty"`
+	Rules            *[]GroundingRuleHits `json:"rules,omitempty"`
+}
+
 // HealthStatus defines model for HealthStatus.
 type HealthStatus struct {
 	Service   *string    `json:"service,omitempty"`
@@ -51,6 +346,15 @@ type HealthStatus struct {
 	Version   *string    `json:"version,omitempty"`
 }
 
+// LatencyPercentiles Recall latency in milliseconds
+type LatencyPercentiles struct {
+	Count *int     `json:"count,omitempty"`
+	MaxMs *float32 `json:"max_ms,omitempty"`
+	P50Ms *float32 `json:"p50_ms,omitempty"`
+	P90Ms *float32 `json:"p90_ms,omitempty"`
+	P99Ms *float32 `json:"p99_ms,omitempty"`
+}
+
 // Memory defines model for Memory.
 type Memory struct {
 	Confidence *float32                `json:"confidence,omitempty"`
@@ -62,56 +366,564 @@ type Memory struct {
 	WorkflowId *openapi_types.UUID     `json:"workflow_id"`
 }
 
+// MemoryBatchCreate defines model for MemoryBatchCreate.
+type MemoryBatchCreate struct {
+	Contents []string `json:"contents"`
+}
+
+// MemoryBatchItem The outcome of one batch item. Exactly one of memory and error is set.
+type MemoryBatchItem struct {
+	Error  *ProblemDetails `json:"error,omitempty"`
+	Index  int             `json:"index"`
+	Memory *Memory         `json:"memory,omitempty"`
+}
+
+// MemoryBatchResult defines model for MemoryBatchResult.
+type MemoryBatchResult struct {
+	Failed    int               `json:"failed"`
+	Results   []MemoryBatchItem `json:"results"`
+	Succeeded int               `json:"succeeded"`
+}
+
 // MemoryFeedback defines model for MemoryFeedback.
 type MemoryFeedback struct {
+	Confidence *float32 `json:"confidence,omitempty"`
+	// Query The query that surfaced the memory
+	Query *string `json:"query,omitempty"`
+	// Reason Why the memory was (not) useful
+	Reason *string         `json:"reason,omitempty"`
+	Signal *FeedbackSignal `json:"signal,omitempty"`
+}
+
+// MemoryFeedbackBatch defines model for MemoryFeedbackBatch.
+type MemoryFeedbackBatch struct {
+	Items []MemoryFeedbackItem `json:"items"`
+}
+
+// MemoryFeedbackItem defines model for MemoryFeedbackItem.
+type MemoryFeedbackItem struct {
 	Confidence float32 `json:"confidence"`
+	Id         string  `json:"id"`
+}
+
+// MemoryRecall defines model for MemoryRecall.
+type MemoryRecall struct {
+	GroundingRules *[]RecalledGroundingRule `json:"grounding_rules,omitempty"`
+	LatencyMs      *float32                 `json:"latency_ms,omitempty"`
+	RecalledAt     *time.Time               `json:"recalled_at,omitempty"`
+}
+
+// MetricComparison defines model for MetricComparison.
+type MetricComparison struct {
+	Baseline        float32 `json:"baseline"`
+	BaselineSamples int     `json:"baseline_samples"`
+	Current         float32 `json:"current"`
+	CurrentSamples  int     `json:"current_samples"`
+	Delta           float32 `json:"delta"`
+	// Metric Higher is better for every metric
+	Metric      MetricComparisonMetric `json:"metric"`
+	Significant bool                   `json:"significant"`
+}
+
+// MetricComparisonMetric defines model for MetricComparison.Metric.
+type MetricComparisonMetric string
+
+// ProblemDetails RFC 7807 problem details
+type ProblemDetails struct {
+	Detail   *string `json:"detail,omitempty"`
+	Instance *string `json:"instance,omitempty"`
+	Status   *int    `json:"status,omitempty"`
+	Title    *string `json:"title,omitempty"`
+	TraceId  *string `json:"trace_id,omitempty"`
+	Type     *string `json:"type,omitempty"`
 }
 
+// RecalledGroundingRule defines model for RecalledGroundingRule.
+type RecalledGroundingRule struct {
+	Content *string             `json:"content,omitempty"`
+	Name    *string             `json:"name,omitempty"`
+	RuleId  *openapi_types.UUID `json:"rule_id,omitempty"`
+	// Version The version in effect at the recall; absent for recalls recorded before rules were versioned.
+	Version *int `json:"version,omitempty"`
+}
+
+// RecalledMemory defines model for RecalledMemory.
+type RecalledMemory struct {
+	Confidence *float32            `json:"confidence,omitempty"`
+	Content    *string             `json:"content,omitempty"`
+	MemoryId   *openapi_types.UUID `json:"memory_id,omitempty"`
+	Recalls    *int                `json:"recalls,omitempty"`
+}
+
+// SchemaChange defines model for SchemaChange.
+type SchemaChange struct {
+	Breaking      bool                      `json:"breaking"`
+	Compatibility SchemaChangeCompatibility `json:"compatibility"`
+	Description   string                    `json:"description"`
+	// Path JSON Pointer into the workflow, e.g. /input_schema/properties/email
+	Path string `json:"path"`
+}
+
+// SchemaChangeCompatibility defines model for SchemaChange.Compatibility.
+type SchemaChangeCompatibility string
+
+// SchemaError defines model for SchemaError.
+type SchemaError struct {
+	Message string `json:"message"`
+	// Path JSON Pointer to the offending value in the payload
+	Path string `json:"path"`
+	// SchemaPath JSON Pointer to the schema keyword that failed
+	SchemaPath string `json:"schema_path"`
+}
+
+// SchemaValidation defines model for SchemaValidation.
+type SchemaValidation struct {
+	Errors     []SchemaError          `json:"errors"`
+	Schema     SchemaValidationSchema `json:"schema"`
+	Valid      bool                   `json:"valid"`
+	Version    int                    `json:"version"`
+	WorkflowId openapi_types.UUID     `json:"workflow_id"`
+}
+
+// SchemaValidationSchema defines model for SchemaValidation.Schema.
+type SchemaValidationSchema string
+
 // Tenant defines model for Tenant.
 type Tenant struct {
-	BrandTitle *string             `json:"brand_title,omitempty"`
-	CreatedAt  *time.Time          `json:"created_at,omitempty"`
-	Domain     *string             `json:"domain,omitempty"`
-	Id         *openapi_types.UUID `json:"id,omitempty"`
-	LogoSvg    *string             `json:"logo_svg,omitempty"`
-	Name       *string             `json:"name,omitempty"`
-	UpdatedAt  *time.Time          `json:"updated_at,omitempty"`
+	BrandTitle *string    `json:"brand_title,omitempty"`
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	// Domain Primary email domain
+	Domain *string `json:"domain,omitempty"`
+	// Domains All email domains that resolve to this tenant
+	Domains *[]string           `json:"domains,omitempty"`
+	Id      *openapi_types.UUID `json:"id,omitempty"`
+	LogoSvg *string             `json:"logo_svg,omitempty"`
+	Name    *string             `json:"name,omitempty"`
+	Quotas  *TenantQuotas       `json:"quotas,omitempty"`
+	// Status Either active or suspended
+	Status    *string    `json:"status,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// TenantCreate defines model for TenantCreate.
+type TenantCreate struct {
+	BrandTitle *string  `json:"brand_title,omitempty"`
+	Domains    []string `json:"domains"`
+	LogoSvg    *string  `json:"logo_svg,omitempty"`
+	Name       string   `json:"name"`
+}
+
+// TenantDomain defines model for TenantDomain.
+type TenantDomain struct {
+	Domain string `json:"domain"`
+}
+
+// TenantMember defines model for TenantMember.
+type TenantMember struct {
+	CreatedAt *time.Time          `json:"created_at,omitempty"`
+	Email     *string             `json:"email,omitempty"`
+	TenantId  *openapi_types.UUID `json:"tenant_id,omitempty"`
+}
+
+// TenantMemberCreate defines model for TenantMemberCreate.
+type TenantMemberCreate struct {
+	Email string `json:"email"`
+}
+
+// TenantQuotas Per-tenant overrides of the server-wide quota defaults. Omitted fields use the default; 0 means unlimited.
+type TenantQuotas struct {
+	EmbeddingsPerMinute   *int     `json:"embeddings_per_minute,omitempty"`
+	MaxMemories           *int     `json:"max_memories,omitempty"`
+	McpRequestsPerSecond  *float32 `json:"mcp_requests_per_second,omitempty"`
+	RestRequestsPerSecond *float32 `json:"rest_requests_per_second,omitempty"`
+}
+
+// TenantStats defines model for TenantStats.
+type TenantStats struct {
+	ConfidenceBands     *[]ConfidenceBand   `json:"confidence_bands,omitempty"`
+	FeedbackOverTime    *[]DailyCount       `json:"feedback_over_time,omitempty"`
+	GeneratedAt         *time.Time          `json:"generated_at,omitempty"`
+	Grounding           *GroundingStats     `json:"grounding,omitempty"`
+	RecallLatency       *LatencyPercentiles `json:"recall_latency,omitempty"`
+	TenantId            *string             `json:"tenant_id,omitempty"`
+	TopRecalledMemories *[]RecalledMemory   `json:"top_recalled_memories,omitempty"`
+	TotalMemories       *int                `json:"total_memories,omitempty"`
+	WindowDays          *int                `json:"window_days,omitempty"`
+	WorkflowUsage       *[]WorkflowUsage    `json:"workflow_usage,omitempty"`
+}
+
+// TenantUpdate defines model for TenantUpdate.
+type TenantUpdate struct {
+	BrandTitle *string       `json:"brand_title,omitempty"`
+	LogoSvg    *string       `json:"logo_svg,omitempty"`
+	Name       *string       `json:"name,omitempty"`
+	Quotas     *TenantQuotas `json:"quotas,omitempty"`
+}
+
+// VersionComparison How a version compares with the version before it
+type VersionComparison struct {
+	BaselineVersion int                      `json:"baseline_version"`
+	Metrics         []MetricComparison       `json:"metrics"`
+	Verdict         VersionComparisonVerdict `json:"verdict"`
+}
+
+// VersionComparisonVerdict defines model for VersionComparison.Verdict.
+type VersionComparisonVerdict string
+
+// VersionPerformance defines model for VersionPerformance.
+type VersionPerformance struct {
+	AverageConfidence float32            `json:"average_confidence"`
+	CancelledRuns     int                `json:"cancelled_runs"`
+	Comparison        *VersionComparison `json:"comparison,omitempty"`
+	ConfidenceStddev  float32            `json:"confidence_stddev"`
+	FailedRuns        int                `json:"failed_runs"`
+	// Feedback Feedback on the version's memories in the window
+	Feedback int `json:"feedback"`
+	// MedianRunDurationMs Of succeeded runs
+	MedianRunDurationMs float32 `json:"median_run_duration_ms"`
+	// Memories Memories linked to the version
+	Memories int `json:"memories"`
+	// NegativeFeedback Not helpful, incorrect and outdated signals
+	NegativeFeedback int `json:"negative_feedback"`
+	// PositiveFeedback Helpful signals
+	PositiveFeedback int `json:"positive_feedback"`
+	// Runs Runs started in the window
+	Runs          int                `json:"runs"`
+	Status        string             `json:"status"`
+	SucceededRuns int                `json:"succeeded_runs"`
+	Version       int                `json:"version"`
+	VersionId     openapi_types.UUID `json:"version_id"`
 }
 
 // Workflow defines model for Workflow.
 type Workflow struct {
-	CreatedAt        *time.Time           `json:"created_at,omitempty"`
-	Description      *string              `json:"description,omitempty"`
-	ElementType      *WorkflowElementType `json:"element_type,omitempty"`
-	Id               *openapi_types.UUID  `json:"id,omitempty"`
-	IsLatest         *bool                `json:"is_latest,omitempty"`
-	Name             *string              `json:"name,omitempty"`
-	ParentId         *openapi_types.UUID  `json:"parent_id"`
-	SaveAsNewVersion *bool                `json:"save_as_new_version,omitempty"`
-	Status           *WorkflowStatus      `json:"status,omitempty"`
-	TenantId         *string              `json:"tenant_id,omitempty"`
-	UpdatedAt        *time.Time           `json:"updated_at,omitempty"`
-	Version          *int                 `json:"version,omitempty"`
-	WorkflowId       *openapi_types.UUID  `json:"workflow_id,omitempty"`
+	CreatedAt   *time.Time           `json:"created_at,omitempty"`
+	Description *string              `json:"description,omitempty"`
+	ElementType *WorkflowElementType `json:"element_type,omitempty"`
+	Id          *openapi_types.UUID  `json:"id,omitempty"`
+	IsLatest    *bool                `json:"is_latest,omitempty"`
+	Name        *string              `json:"name,omitempty"`
+	ParentId    *openapi_types.UUID  `json:"parent_id"`
+	// Position Order among the siblings under parent_id. Change it with the moves endpoint.
+	Position *int `json:"position,omitempty"`
+	// Revision Incremented by every change of this version; sent as its ETag.
+	Revision         *int  `json:"revision,omitempty"`
+	SaveAsNewVersion *bool `json:"save_as_new_version,omitempty"`
+	// Status Lifecycle status. New versions start as draft; only drafts can be edited. Change it with the transitions endpoint.
+	Status     *WorkflowStatus     `json:"status,omitempty"`
+	TenantId   *string             `json:"tenant_id,omitempty"`
+	UpdatedAt  *time.Time          `json:"updated_at,omitempty"`
+	Version    *int                `json:"version,omitempty"`
+	WorkflowId *openapi_types.UUID `json:"workflow_id,omitempty"`
+}
+
+// WorkflowBundle A portable definition of a workflow. IDs are those of the exporting environment and only link the entries of the bundle to each other.
+type WorkflowBundle struct {
+	ExportedAt     *time.Time              `json:"exported_at,omitempty"`
+	Format         WorkflowBundleFormat    `json:"format"`
+	GroundingRules *[]BundledGroundingRule `json:"grounding_rules,omitempty"`
+	// WorkflowId The exported workflow
+	WorkflowId string            `json:"workflow_id"`
+	Workflows  []BundledWorkflow `json:"workflows"`
+}
+
+// WorkflowBundleFormat defines model for WorkflowBundle.Format.
+type WorkflowBundleFormat string
+
+// WorkflowChange defines model for WorkflowChange.
+type WorkflowChange struct {
+	// From The value before, unless added
+	From *interface{}       `json:"from,omitempty"`
+	Kind WorkflowChangeKind `json:"kind"`
+	// Path JSON Pointer to the changed value, e.g. /input_schema/properties/name/type
+	Path string `json:"path"`
+	// To The value after, unless removed
+	To *interface{} `json:"to,omitempty"`
+}
+
+// WorkflowChangeKind defines model for WorkflowChange.Kind.
+type WorkflowChangeKind string
+
+// WorkflowDiff defines model for WorkflowDiff.
+type WorkflowDiff struct {
+	Changes     []WorkflowChange   `json:"changes"`
+	FromVersion int                `json:"from_version"`
+	ToVersion   int                `json:"to_version"`
+	WorkflowId  openapi_types.UUID `json:"workflow_id"`
 }
 
 // WorkflowElementType defines model for Workflow.ElementType.
 type WorkflowElementType string
 
+// WorkflowImport defines model for WorkflowImport.
+type WorkflowImport struct {
+	GroundingRules []GroundingRule `json:"grounding_rules"`
+	// Ids Maps the workflow, version and rule IDs of the bundle to their new IDs
+	Ids map[string]interface{} `json:"ids"`
+	// WorkflowId New ID of the bundle's workflow
+	WorkflowId openapi_types.UUID `json:"workflow_id"`
+	Workflows  []Workflow         `json:"workflows"`
+}
+
+// WorkflowMove defines model for WorkflowMove.
+type WorkflowMove struct {
+	// Id ID of the version to move
+	Id openapi_types.UUID `json:"id"`
+	// ParentId The new parent version; null or absent for a top-level workflow
+	ParentId *openapi_types.UUID `json:"parent_id"`
+	// Position Index among the new siblings; larger values append
+	Position int `json:"position"`
+}
+
+// WorkflowMoveBatch defines model for WorkflowMoveBatch.
+type WorkflowMoveBatch struct {
+	Moves []WorkflowMove `json:"moves"`
+}
+
+// WorkflowNode defines model for WorkflowNode.
+type WorkflowNode struct {
+	Children    []WorkflowNode           `json:"children"`
+	CreatedAt   *time.Time               `json:"created_at,omitempty"`
+	Description *string                  `json:"description,omitempty"`
+	ElementType *WorkflowNodeElementType `json:"element_type,omitempty"`
+	Id          *openapi_types.UUID      `json:"id,omitempty"`
+	IsLatest    *bool                    `json:"is_latest,omitempty"`
+	Name        *string                  `json:"name,omitempty"`
+	ParentId    *openapi_types.UUID      `json:"parent_id"`
+	// Position Order among the siblings under parent_id. Change it with the moves endpoint.
+	Position *int `json:"position,omitempty"`
+	// Revision Incremented by every change of this version; sent as its ETag.
+	Revision         *int  `json:"revision,omitempty"`
+	SaveAsNewVersion *bool `json:"save_as_new_version,omitempty"`
+	// Status Lifecycle status. New versions start as draft; only drafts can be edited. Change it with the transitions endpoint.
+	Status     *WorkflowNodeStatus `json:"status,omitempty"`
+	TenantId   *string             `json:"tenant_id,omitempty"`
+	UpdatedAt  *time.Time          `json:"updated_at,omitempty"`
+	Version    *int                `json:"version,omitempty"`
+	WorkflowId *openapi_types.UUID `json:"workflow_id,omitempty"`
+}
+
+// WorkflowNodeElementType defines model for WorkflowNode.ElementType.
+type WorkflowNodeElementType string
+
+// WorkflowNodeStatus defines model for WorkflowNode.Status.
+type WorkflowNodeStatus string
+
+// WorkflowPayloadValidationRequest defines model for WorkflowPayloadValidationRequest.
+type WorkflowPayloadValidationRequest struct {
+	// Payload The value to validate
+	Payload interface{} `json:"payload"`
+	// Schema Which schema of the workflow to validate against
+	Schema WorkflowPayloadValidationRequestSchema `json:"schema"`
+	// Version Defaults to the latest version
+	Version *int `json:"version,omitempty"`
+}
+
+// WorkflowPayloadValidationRequestSchema defines model for WorkflowPayloadValidationRequest.Schema.
+type WorkflowPayloadValidationRequestSchema string
+
+// WorkflowPerformance defines model for WorkflowPerformance.
+type WorkflowPerformance struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	// Versions Oldest version first
+	Versions   []VersionPerformance `json:"versions"`
+	WindowDays int                  `json:"window_days"`
+	WorkflowId openapi_types.UUID   `json:"workflow_id"`
+}
+
+// WorkflowRollback defines model for WorkflowRollback.
+type WorkflowRollback struct {
+	// Version The version to copy
+	Version int `json:"version"`
+}
+
+// WorkflowRun defines model for WorkflowRun.
+type WorkflowRun struct {
+	CompletedAt      *time.Time           `json:"completed_at,omitempty"`
+	CreatedMemoryIds []openapi_types.UUID `json:"created_memory_ids"`
+	// DurationMs Set once the run has completed
+	DurationMs *int64 `json:"duration_ms,omitempty"`
+	// Error Why the run failed or was cancelled
+	Error *string            `json:"error,omitempty"`
+	Id    openapi_types.UUID `json:"id"`
+	// Inputs The inputs the run was started with
+	Inputs *interface{} `json:"inputs,omitempty"`
+	// Outputs The outputs recorded so far
+	Outputs           *interface{}         `json:"outputs,omitempty"`
+	RecalledMemoryIds []openapi_types.UUID `json:"recalled_memory_ids"`
+	StartedAt         time.Time            `json:"started_at"`
+	StartedBy         string               `json:"started_by"`
+	Status            WorkflowRunStatus    `json:"status"`
+	TenantId          string               `json:"tenant_id"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+	Version           int                  `json:"version"`
+	VersionId         openapi_types.UUID   `json:"version_id"`
+	WorkflowId        openapi_types.UUID   `json:"workflow_id"`
+}
+
+// WorkflowRunCompletionRequest defines model for WorkflowRunCompletionRequest.
+type WorkflowRunCompletionRequest struct {
+	CreatedMemoryIds *[]openapi_types.UUID `json:"created_memory_ids,omitempty"`
+	// Error Why the run failed or was cancelled
+	Error *string `json:"error,omitempty"`
+	// Outputs Must match the output schema when the run succeeded
+	Outputs           *interface{}                       `json:"outputs,omitempty"`
+	RecalledMemoryIds *[]openapi_types.UUID              `json:"recalled_memory_ids,omitempty"`
+	Status            WorkflowRunCompletionRequestStatus `json:"status"`
+}
+
+// WorkflowRunCompletionRequestStatus defines model for WorkflowRunCompletionRequest.Status.
+type WorkflowRunCompletionRequestStatus string
+
+// WorkflowRunStartRequest defines model for WorkflowRunStartRequest.
+type WorkflowRunStartRequest struct {
+	// Inputs Must match the input schema of the version
+	Inputs *interface{} `json:"inputs,omitempty"`
+	// Version Defaults to the latest version
+	Version *int `json:"version,omitempty"`
+}
+
+// WorkflowRunStatus defines model for WorkflowRun.Status.
+type WorkflowRunStatus string
+
+// WorkflowRunUpdateRequest defines model for WorkflowRunUpdateRequest.
+type WorkflowRunUpdateRequest struct {
+	CreatedMemoryIds *[]openapi_types.UUID `json:"created_memory_ids,omitempty"`
+	// Outputs Replaces the outputs recorded so far
+	Outputs           *interface{}          `json:"outputs,omitempty"`
+	RecalledMemoryIds *[]openapi_types.UUID `json:"recalled_memory_ids,omitempty"`
+}
+
 // WorkflowStatus defines model for Workflow.Status.
 type WorkflowStatus string
 
+// WorkflowTransition defines model for WorkflowTransition.
+type WorkflowTransition struct {
+	Actor *string `json:"actor,omitempty"`
+	// AllowBreaking The version was published despite breaking schema changes
+	AllowBreaking *bool      `json:"allow_breaking,omitempty"`
+	Comment       *string    `json:"comment,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+	// FromStatus Absent for the creation of a version, e.g. the copy made by a rollback
+	FromStatus *string             `json:"from_status,omitempty"`
+	Id         *int64              `json:"id,omitempty"`
+	TenantId   *string             `json:"tenant_id,omitempty"`
+	ToStatus   *string             `json:"to_status,omitempty"`
+	Version    *int                `json:"version,omitempty"`
+	VersionId  *openapi_types.UUID `json:"version_id,omitempty"`
+	WorkflowId *openapi_types.UUID `json:"workflow_id,omitempty"`
+}
+
+// WorkflowTransitionRequest defines model for WorkflowTransitionRequest.
+type WorkflowTransitionRequest struct {
+	// AllowBreaking Publish even if the schemas break callers or consumers of the previously published version. Recorded with the transition.
+	AllowBreaking *bool                           `json:"allow_breaking,omitempty"`
+	Comment       *string                         `json:"comment,omitempty"`
+	Status        WorkflowTransitionRequestStatus `json:"status"`
+}
+
+// WorkflowTransitionRequestStatus defines model for WorkflowTransitionRequest.Status.
+type WorkflowTransitionRequestStatus string
+
+// WorkflowUsage defines model for WorkflowUsage.
+type WorkflowUsage struct {
+	Memories   *int                `json:"memories,omitempty"`
+	Name       *string             `json:"name,omitempty"`
+	Recalls    *int                `json:"recalls,omitempty"`
+	WorkflowId *openapi_types.UUID `json:"workflow_id,omitempty"`
+}
+
+// UpdateGroundingRuleParams defines parameters for UpdateGroundingRule.
+type UpdateGroundingRuleParams struct {
+	// IfMatch The ETag of the rule the change is based on.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
 // SearchMemoriesJSONBody defines parameters for SearchMemories.
 type SearchMemoriesJSONBody struct {
 	Query *string `json:"query,omitempty"`
 }
 
+// GiveMemoryFeedbackParams defines parameters for GiveMemoryFeedback.
+type GiveMemoryFeedbackParams struct {
+	// IfMatch The ETag of the memory the edit is based on.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// GetTenantStatsParams defines parameters for GetTenantStats.
+type GetTenantStatsParams struct {
+	// Days Size of the reporting window in days (default 30)
+	Days *int `form:"days,omitempty" json:"days,omitempty"`
+}
+
+// PutWorkflowParams defines parameters for PutWorkflow.
+type PutWorkflowParams struct {
+	// IfMatch The ETag of the workflow version the edit is based on.
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// CheckWorkflowCompatibilityParams defines parameters for CheckWorkflowCompatibility.
+type CheckWorkflowCompatibilityParams struct {
+	From int `form:"from" json:"from"`
+	// To Defaults to the latest version
+	To *int `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// DiffWorkflowVersionsParams defines parameters for DiffWorkflowVersions.
+type DiffWorkflowVersionsParams struct {
+	From int `form:"from" json:"from"`
+	// To Defaults to the latest version
+	To *int `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ExportWorkflowParams defines parameters for ExportWorkflow.
+type ExportWorkflowParams struct {
+	Versions *ExportWorkflowParamsVersions `form:"versions,omitempty" json:"versions,omitempty"`
+	Format   *ExportWorkflowParamsFormat   `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetWorkflowPerformanceParams defines parameters for GetWorkflowPerformance.
+type GetWorkflowPerformanceParams struct {
+	// Days Size of the reporting window in days (default 30)
+	Days *int `form:"days,omitempty" json:"days,omitempty"`
+}
+
+// GetWorkflowTreeParams defines parameters for GetWorkflowTree.
+type GetWorkflowTreeParams struct {
+	// Version Defaults to the latest version
+	Version *int `form:"version,omitempty" json:"version,omitempty"`
+}
+
+// CreateTenantJSONRequestBody defines body for CreateTenant for application/json ContentType.
+type CreateTenantJSONRequestBody = TenantCreate
+
+// UpdateTenantJSONRequestBody defines body for UpdateTenant for application/json ContentType.
+type UpdateTenantJSONRequestBody = TenantUpdate
+
+// AddTenantDomainJSONRequestBody defines body for AddTenantDomain for application/json ContentType.
+type AddTenantDomainJSONRequestBody = TenantDomain
+
+// AddTenantMemberJSONRequestBody defines body for AddTenantMember for application/json ContentType.
+type AddTenantMemberJSONRequestBody = TenantMemberCreate
+
 // CreateGroundingRuleJSONRequestBody defines body for CreateGroundingRule for application/json ContentType.
 type CreateGroundingRuleJSONRequestBody = GroundingRule
 
 // UpdateGroundingRuleJSONRequestBody defines body for UpdateGroundingRule for application/json ContentType.
 type UpdateGroundingRuleJSONRequestBody = GroundingRule
 
+// ApproveGroundingRuleVersionJSONRequestBody defines body for ApproveGroundingRuleVersion for application/json ContentType.
+type ApproveGroundingRuleVersionJSONRequestBody = GroundingRuleReview
+
+// RejectGroundingRuleVersionJSONRequestBody defines body for RejectGroundingRuleVersion for application/json ContentType.
+type RejectGroundingRuleVersionJSONRequestBody = GroundingRuleReview
+
+// RememberMemoryBatchJSONRequestBody defines body for RememberMemoryBatch for application/json ContentType.
+type RememberMemoryBatchJSONRequestBody = MemoryBatchCreate
+
+// GiveMemoryFeedbackBatchJSONRequestBody defines body for GiveMemoryFeedbackBatch for application/json ContentType.
+type GiveMemoryFeedbackBatchJSONRequestBody = MemoryFeedbackBatch
+
 // SearchMemoriesJSONRequestBody defines body for SearchMemories for application/json ContentType.
 type SearchMemoriesJSONRequestBody SearchMemoriesJSONBody
 
@@ -121,50 +933,191 @@ type GiveMemoryFeedbackJSONRequestBody = MemoryFeedback
 // PutWorkflowJSONRequestBody defines body for PutWorkflow for application/json ContentType.
 type PutWorkflowJSONRequestBody = Workflow
 
+// ImportWorkflowJSONRequestBody defines body for ImportWorkflow for application/json ContentType.
+type ImportWorkflowJSONRequestBody = WorkflowBundle
+
+// MoveWorkflowElementsJSONRequestBody defines body for MoveWorkflowElements for application/json ContentType.
+type MoveWorkflowElementsJSONRequestBody = WorkflowMoveBatch
+
+// UpdateWorkflowRunJSONRequestBody defines body for UpdateWorkflowRun for application/json ContentType.
+type UpdateWorkflowRunJSONRequestBody = WorkflowRunUpdateRequest
+
+// CompleteWorkflowRunJSONRequestBody defines body for CompleteWorkflowRun for application/json ContentType.
+type CompleteWorkflowRunJSONRequestBody = WorkflowRunCompletionRequest
+
+// RollbackWorkflowJSONRequestBody defines body for RollbackWorkflow for application/json ContentType.
+type RollbackWorkflowJSONRequestBody = WorkflowRollback
+
+// StartWorkflowRunJSONRequestBody defines body for StartWorkflowRun for application/json ContentType.
+type StartWorkflowRunJSONRequestBody = WorkflowRunStartRequest
+
+// ValidateWorkflowPayloadJSONRequestBody defines body for ValidateWorkflowPayload for application/json ContentType.
+type ValidateWorkflowPayloadJSONRequestBody = WorkflowPayloadValidationRequest
+
+// TransitionWorkflowVersionJSONRequestBody defines body for TransitionWorkflowVersion for application/json ContentType.
+type TransitionWorkflowVersionJSONRequestBody = WorkflowTransitionRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List tenants
+	// (GET /admin/tenants)
+	ListTenants(ctx echo.Context) error
+	// Onboard a tenant
+	// (POST /admin/tenants)
+	CreateTenant(ctx echo.Context) error
+	// Delete tenant
+	// (DELETE /admin/tenants/{id})
+	DeleteTenant(ctx echo.Context, id openapi_types.UUID) error
+	// Get tenant by ID
+	// (GET /admin/tenants/{id})
+	GetTenantByID(ctx echo.Context, id openapi_types.UUID) error
+	// Update tenant name and branding
+	// (PATCH /admin/tenants/{id})
+	UpdateTenant(ctx echo.Context, id openapi_types.UUID) error
+	// Reactivate a suspended tenant
+	// (POST /admin/tenants/{id}/activate)
+	ActivateTenant(ctx echo.Context, id openapi_types.UUID) error
+	// Map an email domain to a tenant
+	// (POST /admin/tenants/{id}/domains)
+	AddTenantDomain(ctx echo.Context, id openapi_types.UUID) error
+	// Unmap an email domain from a tenant
+	// (DELETE /admin/tenants/{id}/domains/{domain})
+	RemoveTenantDomain(ctx echo.Context, id openapi_types.UUID, domain string) error
+	// List explicit tenant members
+	// (GET /admin/tenants/{id}/members)
+	ListTenantMembers(ctx echo.Context, id openapi_types.UUID) error
+	// Add an explicit tenant member
+	// (POST /admin/tenants/{id}/members)
+	AddTenantMember(ctx echo.Context, id openapi_types.UUID) error
+	// Remove an explicit tenant member
+	// (DELETE /admin/tenants/{id}/members/{email})
+	RemoveTenantMember(ctx echo.Context, id openapi_types.UUID, email string) error
+	// Suspend a tenant
+	// (POST /admin/tenants/{id}/suspend)
+	SuspendTenant(ctx echo.Context, id openapi_types.UUID) error
 	// List grounding rules
 	// (GET /grounding)
 	ListGroundingRules(ctx echo.Context) error
-	// Create grounding rule
+	// Propose a grounding rule
 	// (POST /grounding)
 	CreateGroundingRule(ctx echo.Context) error
-	// Delete grounding rule
+	// Archive grounding rule
 	// (DELETE /grounding/{id})
 	DeleteGroundingRule(ctx echo.Context, id openapi_types.UUID) error
 	// Get grounding rule
 	// (GET /grounding/{id})
 	GetGroundingRule(ctx echo.Context, id openapi_types.UUID) error
-	// Update grounding rule
+	// Propose a change to a grounding rule
 	// (PUT /grounding/{id})
-	UpdateGroundingRule(ctx echo.Context, id openapi_types.UUID) error
+	UpdateGroundingRule(ctx echo.Context, id openapi_types.UUID, params UpdateGroundingRuleParams) error
+	// List the versions of a grounding rule
+	// (GET /grounding/{id}/versions)
+	ListGroundingRuleVersions(ctx echo.Context, id openapi_types.UUID) error
+	// Approve a proposed version of a grounding rule
+	// (POST /grounding/{id}/versions/{version}/approve)
+	ApproveGroundingRuleVersion(ctx echo.Context, id openapi_types.UUID, version int) error
+	// Reject a proposed version of a grounding rule
+	// (POST /grounding/{id}/versions/{version}/reject)
+	RejectGroundingRuleVersion(ctx echo.Context, id openapi_types.UUID, version int) error
 	// Health check
 	// (GET /health)
 	GetHealth(ctx echo.Context) error
 	// List all memories
 	// (GET /memories)
 	ListMemories(ctx echo.Context) error
+	// Create several memories
+	// (POST /memories/batch)
+	RememberMemoryBatch(ctx echo.Context) error
+	// Provide feedback on several memories
+	// (POST /memories/feedback/batch)
+	GiveMemoryFeedbackBatch(ctx echo.Context) error
 	// Semantic memory search
 	// (POST /memories/search)
 	SearchMemories(ctx echo.Context) error
+	// List the feedback given on a memory
+	// (GET /memories/{id}/feedback)
+	ListMemoryFeedback(ctx echo.Context, id openapi_types.UUID) error
 	// Provide feedback on a memory
 	// (POST /memories/{id}/feedback)
-	GiveMemoryFeedback(ctx echo.Context, id openapi_types.UUID) error
+	GiveMemoryFeedback(ctx echo.Context, id openapi_types.UUID, params GiveMemoryFeedbackParams) error
+	// List the recalls that returned a memory
+	// (GET /memories/{id}/recalls)
+	ListMemoryRecalls(ctx echo.Context, id openapi_types.UUID) error
 	// Status check
 	// (GET /status)
 	GetStatus(ctx echo.Context) error
 	// Get tenant branding
 	// (GET /tenant)
 	GetTenant(ctx echo.Context) error
+	// Get tenant usage statistics
+	// (GET /tenant/stats)
+	GetTenantStats(ctx echo.Context, params GetTenantStatsParams) error
+	// List the tenants available to the current user
+	// (GET /tenants)
+	ListUserTenants(ctx echo.Context) error
 	// List workflows
 	// (GET /workflows)
 	ListWorkflows(ctx echo.Context) error
 	// Create or update a workflow
 	// (PUT /workflows)
-	PutWorkflow(ctx echo.Context) error
+	PutWorkflow(ctx echo.Context, params PutWorkflowParams) error
+	// Import a workflow bundle
+	// (POST /workflows/import)
+	ImportWorkflow(ctx echo.Context) error
+	// Move and reorder workflow elements
+	// (POST /workflows/moves)
+	MoveWorkflowElements(ctx echo.Context) error
+	// Get a workflow run
+	// (GET /workflows/runs/{run_id})
+	GetWorkflowRun(ctx echo.Context, runId openapi_types.UUID) error
+	// Record the progress of a workflow run
+	// (PATCH /workflows/runs/{run_id})
+	UpdateWorkflowRun(ctx echo.Context, runId openapi_types.UUID) error
+	// Complete a workflow run
+	// (POST /workflows/runs/{run_id}/complete)
+	CompleteWorkflowRun(ctx echo.Context, runId openapi_types.UUID) error
 	// Get workflow by ID
 	// (GET /workflows/{id})
 	GetWorkflow(ctx echo.Context, id openapi_types.UUID) error
+	// Clone a workflow subtree into new draft versions
+	// (POST /workflows/{id}/clone)
+	CloneWorkflowSubtree(ctx echo.Context, id openapi_types.UUID) error
+	// Check schema compatibility between two versions of a workflow
+	// (GET /workflows/{workflow_id}/compatibility)
+	CheckWorkflowCompatibility(ctx echo.Context, workflowId openapi_types.UUID, params CheckWorkflowCompatibilityParams) error
+	// Compare two versions of a workflow
+	// (GET /workflows/{workflow_id}/diff)
+	DiffWorkflowVersions(ctx echo.Context, workflowId openapi_types.UUID, params DiffWorkflowVersionsParams) error
+	// Export a workflow as a portable bundle
+	// (GET /workflows/{workflow_id}/export)
+	ExportWorkflow(ctx echo.Context, workflowId openapi_types.UUID, params ExportWorkflowParams) error
+	// Compare the performance of workflow versions
+	// (GET /workflows/{workflow_id}/performance)
+	GetWorkflowPerformance(ctx echo.Context, workflowId openapi_types.UUID, params GetWorkflowPerformanceParams) error
+	// Roll a workflow back to an older version
+	// (POST /workflows/{workflow_id}/rollback)
+	RollbackWorkflow(ctx echo.Context, workflowId openapi_types.UUID) error
+	// List the runs of a workflow
+	// (GET /workflows/{workflow_id}/runs)
+	ListWorkflowRuns(ctx echo.Context, workflowId openapi_types.UUID) error
+	// Start a run of a workflow
+	// (POST /workflows/{workflow_id}/runs)
+	StartWorkflowRun(ctx echo.Context, workflowId openapi_types.UUID) error
+	// List the status changes of a workflow
+	// (GET /workflows/{workflow_id}/transitions)
+	ListWorkflowTransitions(ctx echo.Context, workflowId openapi_types.UUID) error
+	// Get a workflow with everything nested under it
+	// (GET /workflows/{workflow_id}/tree)
+	GetWorkflowTree(ctx echo.Context, workflowId openapi_types.UUID, params GetWorkflowTreeParams) error
+	// Validate a payload against a workflow schema
+	// (POST /workflows/{workflow_id}/validate)
+	ValidateWorkflowPayload(ctx echo.Context, workflowId openapi_types.UUID) error
+	// List the versions of a workflow
+	// (GET /workflows/{workflow_id}/versions)
+	ListWorkflowVersions(ctx echo.Context, workflowId openapi_types.UUID) error
+	// Move a workflow version through its lifecycle
+	// (POST /workflows/{workflow_id}/versions/{version}/transitions)
+	TransitionWorkflowVersion(ctx echo.Context, workflowId openapi_types.UUID, version int) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -172,30 +1125,30 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
-// ListGroundingRules converts echo context to params.
-func (w *ServerInterfaceWrapper) ListGroundingRules(ctx echo.Context) error {
+// ListTenants converts echo context to params.
+func (w *ServerInterfaceWrapper) ListTenants(ctx echo.Context) error {
 	var err error
 
-	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.ListGroundingRules(ctx)
+	err = w.Handler.ListTenants(ctx)
 	return err
 }
 
-// CreateGroundingRule converts echo context to params.
-func (w *ServerInterfaceWrapper) CreateGroundingRule(ctx echo.Context) error {
+// CreateTenant converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateTenant(ctx echo.Context) error {
 	var err error
 
-	ctx.Set(OpenIdConnectScopes, []string{"evolve:read", "evolve:write"})
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.CreateGroundingRule(ctx)
+	err = w.Handler.CreateTenant(ctx)
 	return err
 }
 
-// DeleteGroundingRule converts echo context to params.
-func (w *ServerInterfaceWrapper) DeleteGroundingRule(ctx echo.Context) error {
+// DeleteTenant converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteTenant(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
 	var id openapi_types.UUID
@@ -205,15 +1158,15 @@ func (w *ServerInterfaceWrapper) DeleteGroundingRule(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	ctx.Set(OpenIdConnectScopes, []string{"evolve:read", "evolve:write"})
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.DeleteGroundingRule(ctx, id)
+	err = w.Handler.DeleteTenant(ctx, id)
 	return err
 }
 
-// GetGroundingRule converts echo context to params.
-func (w *ServerInterfaceWrapper) GetGroundingRule(ctx echo.Context) error {
+// GetTenantByID converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTenantByID(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
 	var id openapi_types.UUID
@@ -223,15 +1176,15 @@ func (w *ServerInterfaceWrapper) GetGroundingRule(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetGroundingRule(ctx, id)
+	err = w.Handler.GetTenantByID(ctx, id)
 	return err
 }
 
-// UpdateGroundingRule converts echo context to params.
-func (w *ServerInterfaceWrapper) UpdateGroundingRule(ctx echo.Context) error {
+// UpdateTenant converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateTenant(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
 	var id openapi_types.UUID
@@ -241,46 +1194,215 @@ func (w *ServerInterfaceWrapper) UpdateGroundingRule(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	ctx.Set(OpenIdConnectScopes, []string{"evolve:read", "evolve:write"})
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.UpdateGroundingRule(ctx, id)
+	err = w.Handler.UpdateTenant(ctx, id)
 	return err
 }
 
-// GetHealth converts echo context to params.
-func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
+// ActivateTenant converts echo context to params.
+func (w *ServerInterfaceWrapper) ActivateTenant(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetHealth(ctx)
+	err = w.Handler.ActivateTenant(ctx, id)
 	return err
 }
 
-// ListMemories converts echo context to params.
-func (w *ServerInterfaceWrapper) ListMemories(ctx echo.Context) error {
+// AddTenantDomain converts echo context to params.
+func (w *ServerInterfaceWrapper) AddTenantDomain(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.AddTenantDomain(ctx, id)
+	return err
+}
+
+// RemoveTenantDomain converts echo context to params.
+func (w *ServerInterfaceWrapper) RemoveTenantDomain(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// ------------- Path parameter "domain" -------------
+	var domain string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "domain", runtime.ParamLocationPath, ctx.Param("domain"), &domain)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter domain: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RemoveTenantDomain(ctx, id, domain)
+	return err
+}
+
+// ListTenantMembers converts echo context to params.
+func (w *ServerInterfaceWrapper) ListTenantMembers(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListTenantMembers(ctx, id)
+	return err
+}
+
+// AddTenantMember converts echo context to params.
+func (w *ServerInterfaceWrapper) AddTenantMember(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.AddTenantMember(ctx, id)
+	return err
+}
+
+// RemoveTenantMember converts echo context to params.
+func (w *ServerInterfaceWrapper) RemoveTenantMember(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// ------------- Path parameter "email" -------------
+	var email string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "email", runtime.ParamLocationPath, ctx.Param("email"), &email)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter email: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RemoveTenantMember(ctx, id, email)
+	return err
+}
+
+// SuspendTenant converts echo context to params.
+func (w *ServerInterfaceWrapper) SuspendTenant(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SuspendTenant(ctx, id)
+	return err
+}
+
+// ListGroundingRules converts echo context to params.
+func (w *ServerInterfaceWrapper) ListGroundingRules(ctx echo.Context) error {
 	var err error
 
 	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.ListMemories(ctx)
+	err = w.Handler.ListGroundingRules(ctx)
 	return err
 }
 
-// SearchMemories converts echo context to params.
-func (w *ServerInterfaceWrapper) SearchMemories(ctx echo.Context) error {
+// CreateGroundingRule converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateGroundingRule(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read", "evolve:write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateGroundingRule(ctx)
+	return err
+}
+
+// DeleteGroundingRule converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteGroundingRule(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteGroundingRule(ctx, id)
+	return err
+}
+
+// GetGroundingRule converts echo context to params.
+func (w *ServerInterfaceWrapper) GetGroundingRule(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
 
 	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.SearchMemories(ctx)
+	err = w.Handler.GetGroundingRule(ctx, id)
 	return err
 }
 
-// GiveMemoryFeedback converts echo context to params.
-func (w *ServerInterfaceWrapper) GiveMemoryFeedback(ctx echo.Context) error {
+// UpdateGroundingRule converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateGroundingRule(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
 	var id openapi_types.UUID
@@ -290,59 +1412,157 @@ func (w *ServerInterfaceWrapper) GiveMemoryFeedback(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	ctx.Set(OpenIdConnectScopes, []string{"evolve:write"})
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read", "evolve:write"})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params UpdateGroundingRuleParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GiveMemoryFeedback(ctx, id)
+	err = w.Handler.UpdateGroundingRule(ctx, id, params)
 	return err
 }
 
-// GetStatus converts echo context to params.
-func (w *ServerInterfaceWrapper) GetStatus(ctx echo.Context) error {
+// ListGroundingRuleVersions converts echo context to params.
+func (w *ServerInterfaceWrapper) ListGroundingRuleVersions(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-	ctx.Set(OpenIdConnectScopes, []string{"openid", "profile", "email"})
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetStatus(ctx)
+	err = w.Handler.ListGroundingRuleVersions(ctx, id)
 	return err
 }
 
-// GetTenant converts echo context to params.
-func (w *ServerInterfaceWrapper) GetTenant(ctx echo.Context) error {
+// ApproveGroundingRuleVersion converts echo context to params.
+func (w *ServerInterfaceWrapper) ApproveGroundingRuleVersion(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-	ctx.Set(OpenIdConnectScopes, []string{"openid", "profile", "email"})
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "version", runtime.ParamLocationPath, ctx.Param("version"), &version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetTenant(ctx)
+	err = w.Handler.ApproveGroundingRuleVersion(ctx, id, version)
 	return err
 }
 
-// ListWorkflows converts echo context to params.
-func (w *ServerInterfaceWrapper) ListWorkflows(ctx echo.Context) error {
+// RejectGroundingRuleVersion converts echo context to params.
+func (w *ServerInterfaceWrapper) RejectGroundingRuleVersion(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "version", runtime.ParamLocationPath, ctx.Param("version"), &version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RejectGroundingRuleVersion(ctx, id, version)
+	return err
+}
+
+// GetHealth converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealth(ctx)
+	return err
+}
+
+// ListMemories converts echo context to params.
+func (w *ServerInterfaceWrapper) ListMemories(ctx echo.Context) error {
 	var err error
 
 	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.ListWorkflows(ctx)
+	err = w.Handler.ListMemories(ctx)
 	return err
 }
 
-// PutWorkflow converts echo context to params.
-func (w *ServerInterfaceWrapper) PutWorkflow(ctx echo.Context) error {
+// RememberMemoryBatch converts echo context to params.
+func (w *ServerInterfaceWrapper) RememberMemoryBatch(ctx echo.Context) error {
 	var err error
 
-	ctx.Set(OpenIdConnectScopes, []string{"evolve:read", "evolve:write"})
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:write"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PutWorkflow(ctx)
+	err = w.Handler.RememberMemoryBatch(ctx)
 	return err
 }
 
-// GetWorkflow converts echo context to params.
-func (w *ServerInterfaceWrapper) GetWorkflow(ctx echo.Context) error {
+// GiveMemoryFeedbackBatch converts echo context to params.
+func (w *ServerInterfaceWrapper) GiveMemoryFeedbackBatch(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GiveMemoryFeedbackBatch(ctx)
+	return err
+}
+
+// SearchMemories converts echo context to params.
+func (w *ServerInterfaceWrapper) SearchMemories(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SearchMemories(ctx)
+	return err
+}
+
+// ListMemoryFeedback converts echo context to params.
+func (w *ServerInterfaceWrapper) ListMemoryFeedback(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
 	var id openapi_types.UUID
@@ -355,7 +1575,558 @@ func (w *ServerInterfaceWrapper) GetWorkflow(ctx echo.Context) error {
 	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetWorkflow(ctx, id)
+	err = w.Handler.ListMemoryFeedback(ctx, id)
+	return err
+}
+
+// GiveMemoryFeedback converts echo context to params.
+func (w *ServerInterfaceWrapper) GiveMemoryFeedback(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GiveMemoryFeedbackParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GiveMemoryFeedback(ctx, id, params)
+	return err
+}
+
+// ListMemoryRecalls converts echo context to params.
+func (w *ServerInterfaceWrapper) ListMemoryRecalls(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListMemoryRecalls(ctx, id)
+	return err
+}
+
+// GetStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) GetStatus(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(OpenIdConnectScopes, []string{"openid", "profile", "email"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetStatus(ctx)
+	return err
+}
+
+// GetTenant converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTenant(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(OpenIdConnectScopes, []string{"openid", "profile", "email"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTenant(ctx)
+	return err
+}
+
+// GetTenantStats converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTenantStats(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTenantStatsParams
+	// ------------- Optional query parameter "days" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "days", ctx.QueryParams(), &params.Days)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter days: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTenantStats(ctx, params)
+	return err
+}
+
+// ListUserTenants converts echo context to params.
+func (w *ServerInterfaceWrapper) ListUserTenants(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(OpenIdConnectScopes, []string{"openid", "profile", "email"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListUserTenants(ctx)
+	return err
+}
+
+// ListWorkflows converts echo context to params.
+func (w *ServerInterfaceWrapper) ListWorkflows(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListWorkflows(ctx)
+	return err
+}
+
+// PutWorkflow converts echo context to params.
+func (w *ServerInterfaceWrapper) PutWorkflow(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read", "evolve:write"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutWorkflowParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, valueList[0], &IfMatch)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutWorkflow(ctx, params)
+	return err
+}
+
+// ImportWorkflow converts echo context to params.
+func (w *ServerInterfaceWrapper) ImportWorkflow(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ImportWorkflow(ctx)
+	return err
+}
+
+// MoveWorkflowElements converts echo context to params.
+func (w *ServerInterfaceWrapper) MoveWorkflowElements(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.MoveWorkflowElements(ctx)
+	return err
+}
+
+// GetWorkflowRun converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWorkflowRun(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "run_id", runtime.ParamLocationPath, ctx.Param("run_id"), &runId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter run_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWorkflowRun(ctx, runId)
+	return err
+}
+
+// UpdateWorkflowRun converts echo context to params.
+func (w *ServerInterfaceWrapper) UpdateWorkflowRun(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "run_id", runtime.ParamLocationPath, ctx.Param("run_id"), &runId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter run_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UpdateWorkflowRun(ctx, runId)
+	return err
+}
+
+// CompleteWorkflowRun converts echo context to params.
+func (w *ServerInterfaceWrapper) CompleteWorkflowRun(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "run_id" -------------
+	var runId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "run_id", runtime.ParamLocationPath, ctx.Param("run_id"), &runId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter run_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CompleteWorkflowRun(ctx, runId)
+	return err
+}
+
+// GetWorkflow converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWorkflow(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWorkflow(ctx, id)
+	return err
+}
+
+// CloneWorkflowSubtree converts echo context to params.
+func (w *ServerInterfaceWrapper) CloneWorkflowSubtree(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CloneWorkflowSubtree(ctx, id)
+	return err
+}
+
+// CheckWorkflowCompatibility converts echo context to params.
+func (w *ServerInterfaceWrapper) CheckWorkflowCompatibility(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "workflow_id" -------------
+	var workflowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "workflow_id", runtime.ParamLocationPath, ctx.Param("workflow_id"), &workflowId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter workflow_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CheckWorkflowCompatibilityParams
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CheckWorkflowCompatibility(ctx, workflowId, params)
+	return err
+}
+
+// DiffWorkflowVersions converts echo context to params.
+func (w *ServerInterfaceWrapper) DiffWorkflowVersions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "workflow_id" -------------
+	var workflowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "workflow_id", runtime.ParamLocationPath, ctx.Param("workflow_id"), &workflowId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter workflow_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DiffWorkflowVersionsParams
+	// ------------- Required query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DiffWorkflowVersions(ctx, workflowId, params)
+	return err
+}
+
+// ExportWorkflow converts echo context to params.
+func (w *ServerInterfaceWrapper) ExportWorkflow(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "workflow_id" -------------
+	var workflowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "workflow_id", runtime.ParamLocationPath, ctx.Param("workflow_id"), &workflowId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter workflow_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportWorkflowParams
+	// ------------- Optional query parameter "versions" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "versions", ctx.QueryParams(), &params.Versions)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter versions: %s", err))
+	}
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ExportWorkflow(ctx, workflowId, params)
+	return err
+}
+
+// GetWorkflowPerformance converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWorkflowPerformance(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "workflow_id" -------------
+	var workflowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "workflow_id", runtime.ParamLocationPath, ctx.Param("workflow_id"), &workflowId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter workflow_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWorkflowPerformanceParams
+	// ------------- Optional query parameter "days" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "days", ctx.QueryParams(), &params.Days)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter days: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWorkflowPerformance(ctx, workflowId, params)
+	return err
+}
+
+// RollbackWorkflow converts echo context to params.
+func (w *ServerInterfaceWrapper) RollbackWorkflow(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "workflow_id" -------------
+	var workflowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "workflow_id", runtime.ParamLocationPath, ctx.Param("workflow_id"), &workflowId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter workflow_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RollbackWorkflow(ctx, workflowId)
+	return err
+}
+
+// ListWorkflowRuns converts echo context to params.
+func (w *ServerInterfaceWrapper) ListWorkflowRuns(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "workflow_id" -------------
+	var workflowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "workflow_id", runtime.ParamLocationPath, ctx.Param("workflow_id"), &workflowId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter workflow_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListWorkflowRuns(ctx, workflowId)
+	return err
+}
+
+// StartWorkflowRun converts echo context to params.
+func (w *ServerInterfaceWrapper) StartWorkflowRun(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "workflow_id" -------------
+	var workflowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "workflow_id", runtime.ParamLocationPath, ctx.Param("workflow_id"), &workflowId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter workflow_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.StartWorkflowRun(ctx, workflowId)
+	return err
+}
+
+// ListWorkflowTransitions converts echo context to params.
+func (w *ServerInterfaceWrapper) ListWorkflowTransitions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "workflow_id" -------------
+	var workflowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "workflow_id", runtime.ParamLocationPath, ctx.Param("workflow_id"), &workflowId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter workflow_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListWorkflowTransitions(ctx, workflowId)
+	return err
+}
+
+// GetWorkflowTree converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWorkflowTree(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "workflow_id" -------------
+	var workflowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "workflow_id", runtime.ParamLocationPath, ctx.Param("workflow_id"), &workflowId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter workflow_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWorkflowTreeParams
+	// ------------- Optional query parameter "version" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "version", ctx.QueryParams(), &params.Version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWorkflowTree(ctx, workflowId, params)
+	return err
+}
+
+// ValidateWorkflowPayload converts echo context to params.
+func (w *ServerInterfaceWrapper) ValidateWorkflowPayload(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "workflow_id" -------------
+	var workflowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "workflow_id", runtime.ParamLocationPath, ctx.Param("workflow_id"), &workflowId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter workflow_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ValidateWorkflowPayload(ctx, workflowId)
+	return err
+}
+
+// ListWorkflowVersions converts echo context to params.
+func (w *ServerInterfaceWrapper) ListWorkflowVersions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "workflow_id" -------------
+	var workflowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "workflow_id", runtime.ParamLocationPath, ctx.Param("workflow_id"), &workflowId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter workflow_id: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:read"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ListWorkflowVersions(ctx, workflowId)
+	return err
+}
+
+// TransitionWorkflowVersion converts echo context to params.
+func (w *ServerInterfaceWrapper) TransitionWorkflowVersion(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "workflow_id" -------------
+	var workflowId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "workflow_id", runtime.ParamLocationPath, ctx.Param("workflow_id"), &workflowId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter workflow_id: %s", err))
+	}
+
+	// ------------- Path parameter "version" -------------
+	var version int
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "version", runtime.ParamLocationPath, ctx.Param("version"), &version)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter version: %s", err))
+	}
+
+	ctx.Set(OpenIdConnectScopes, []string{"evolve:write"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.TransitionWorkflowVersion(ctx, workflowId, version)
 	return err
 }
 
@@ -387,19 +2158,58 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.GET(baseURL+"/admin/tenants", wrapper.ListTenants)
+	router.POST(baseURL+"/admin/tenants", wrapper.CreateTenant)
+	router.DELETE(baseURL+"/admin/tenants/:id", wrapper.DeleteTenant)
+	router.GET(baseURL+"/admin/tenants/:id", wrapper.GetTenantByID)
+	router.PATCH(baseURL+"/admin/tenants/:id", wrapper.UpdateTenant)
+	router.POST(baseURL+"/admin/tenants/:id/activate", wrapper.ActivateTenant)
+	router.POST(baseURL+"/admin/tenants/:id/domains", wrapper.AddTenantDomain)
+	router.DELETE(baseURL+"/admin/tenants/:id/domains/:domain", wrapper.RemoveTenantDomain)
+	router.GET(baseURL+"/admin/tenants/:id/members", wrapper.ListTenantMembers)
+	router.POST(baseURL+"/admin/tenants/:id/members", wrapper.AddTenantMember)
+	router.DELETE(baseURL+"/admin/tenants/:id/members/:email", wrapper.RemoveTenantMember)
+	router.POST(baseURL+"/admin/tenants/:id/suspend", wrapper.SuspendTenant)
 	router.GET(baseURL+"/grounding", wrapper.ListGroundingRules)
 	router.POST(baseURL+"/grounding", wrapper.CreateGroundingRule)
 	router.DELETE(baseURL+"/grounding/:id", wrapper.DeleteGroundingRule)
 	router.GET(baseURL+"/grounding/:id", wrapper.GetGroundingRule)
 	router.PUT(baseURL+"/grounding/:id", wrapper.UpdateGroundingRule)
+	router.GET(baseURL+"/grounding/:id/versions", wrapper.ListGroundingRuleVersions)
+	router.POST(baseURL+"/grounding/:id/versions/:version/approve", wrapper.ApproveGroundingRuleVersion)
+	router.POST(baseURL+"/grounding/:id/versions/:version/reject", wrapper.RejectGroundingRuleVersion)
 	router.GET(baseURL+"/health", wrapper.GetHealth)
 	router.GET(baseURL+"/memories", wrapper.ListMemories)
+	router.POST(baseURL+"/memories/batch", wrapper.RememberMemoryBatch)
+	router.POST(baseURL+"/memories/feedback/batch", wrapper.GiveMemoryFeedbackBatch)
 	router.POST(baseURL+"/memories/search", wrapper.SearchMemories)
+	router.GET(baseURL+"/memories/:id/feedback", wrapper.ListMemoryFeedback)
 	router.POST(baseURL+"/memories/:id/feedback", wrapper.GiveMemoryFeedback)
+	router.GET(baseURL+"/memories/:id/recalls", wrapper.ListMemoryRecalls)
 	router.GET(baseURL+"/status", wrapper.GetStatus)
 	router.GET(baseURL+"/tenant", wrapper.GetTenant)
+	router.GET(baseURL+"/tenant/stats", wrapper.GetTenantStats)
+	router.GET(baseURL+"/tenants", wrapper.ListUserTenants)
 	router.GET(baseURL+"/workflows", wrapper.ListWorkflows)
 	router.PUT(baseURL+"/workflows", wrapper.PutWorkflow)
+	router.POST(baseURL+"/workflows/import", wrapper.ImportWorkflow)
+	router.POST(baseURL+"/workflows/moves", wrapper.MoveWorkflowElements)
+	router.GET(baseURL+"/workflows/runs/:run_id", wrapper.GetWorkflowRun)
+	router.PATCH(baseURL+"/workflows/runs/:run_id", wrapper.UpdateWorkflowRun)
+	router.POST(baseURL+"/workflows/runs/:run_id/complete", wrapper.CompleteWorkflowRun)
 	router.GET(baseURL+"/workflows/:id", wrapper.GetWorkflow)
+	router.POST(baseURL+"/workflows/:id/clone", wrapper.CloneWorkflowSubtree)
+	router.GET(baseURL+"/workflows/:workflow_id/compatibility", wrapper.CheckWorkflowCompatibility)
+	router.GET(baseURL+"/workflows/:workflow_id/diff", wrapper.DiffWorkflowVersions)
+	router.GET(baseURL+"/workflows/:workflow_id/export", wrapper.ExportWorkflow)
+	router.GET(baseURL+"/workflows/:workflow_id/performance", wrapper.GetWorkflowPerformance)
+	router.POST(baseURL+"/workflows/:workflow_id/rollback", wrapper.RollbackWorkflow)
+	router.GET(baseURL+"/workflows/:workflow_id/runs", wrapper.ListWorkflowRuns)
+	router.POST(baseURL+"/workflows/:workflow_id/runs", wrapper.StartWorkflowRun)
+	router.GET(baseURL+"/workflows/:workflow_id/transitions", wrapper.ListWorkflowTransitions)
+	router.GET(baseURL+"/workflows/:workflow_id/tree", wrapper.GetWorkflowTree)
+	router.POST(baseURL+"/workflows/:workflow_id/validate", wrapper.ValidateWorkflowPayload)
+	router.GET(baseURL+"/workflows/:workflow_id/versions", wrapper.ListWorkflowVersions)
+	router.POST(baseURL+"/workflows/:workflow_id/versions/:version/transitions", wrapper.TransitionWorkflowVersion)
 
 }
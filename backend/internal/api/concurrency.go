@@ -0,0 +1,50 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/internal/services"
+	"github.com/labstack/echo/v4"
+)
+
+// Memories, grounding rules and workflow versions carry a counter that every
+// edit increments: the version of a memory, the version in effect of a rule,
+// the revision of a workflow version. It is sent as a strong ETag, and edits may send it back
+// in If-Match to fail with 412 instead of overwriting a newer edit.
+
+// setETag sends version as the ETag of the response.
+func setETag(c echo.Context, version int) {
+	c.Response().Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
+
+// ifMatch returns the version named by an If-Match header, or 0 when there is
+// none or it is "*".
+func ifMatch(header *string) (int, error) {
+	if header == nil {
+		return 0, nil
+	}
+	tag := strings.TrimSpace(*header)
+	if tag == "" || tag == "*" {
+		return 0, nil
+	}
+	if unquoted, ok := strings.CutPrefix(tag, `"`); ok && strings.HasSuffix(unquoted, `"`) {
+		if version, err := strconv.Atoi(strings.TrimSuffix(unquoted, `"`)); err == nil && version > 0 {
+			return version, nil
+		}
+	}
+	return 0, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("If-Match must be a single ETag as sent by the server, not %s", tag))
+}
+
+// editError writes a failed If-Match as 412 and an edit that lost a race as
+// 409 problem details, and any other error as toHTTPError does.
+func editError(c echo.Context, err error) error {
+	if errors.Is(err, services.ErrPreconditionFailed) || errors.Is(err, repository.ErrConflict) {
+		return writeProblem(c, itemProblem(err))
+	}
+	return toHTTPError(err)
+}
@@ -1,8 +1,12 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
+	"evolutionary-mcp/backend/internal/auth"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/quota"
 	"evolutionary-mcp/backend/pkg/models"
 	"github.com/labstack/echo/v4"
 	openapi_types "github.com/oapi-codegen/runtime/types"
@@ -25,26 +29,30 @@ func (s *Server) ListGroundingRules(c echo.Context) error {
 	return c.JSON(http.StatusOK, rules)
 }
 
-// CreateGroundingRule creates a new rule
+// CreateGroundingRule proposes a new rule, pending until a curator approves
+// its first version. Only admins can propose a global rule
 // (POST /api/v1/grounding)
 func (s *Server) CreateGroundingRule(c echo.Context) error {
-	ctx := c.Request().Context()
-	tenantID, _ := ctx.Value("tenant_id").(string)
-	if tenantID == "" {
-		return echo.NewHTTPError(http.StatusUnauthorized, "Tenant ID missing")
-	}
-
 	var rule models.GroundingRule
 	if err := c.Bind(&rule); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
-	rule.TenantID = tenantID
+	if rule.IsGlobal {
+		if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+			return err
+		}
+	}
 
-	if err := s.Repo.CreateGroundingRule(ctx, &rule); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	proposal, err := s.Memories.ProposeGroundingRule(c.Request().Context(), &rule)
+	if errors.Is(err, quota.ErrExceeded) {
+		return quotaProblem(c, err)
+	}
+	if err != nil {
+		return toHTTPError(err)
 	}
 
-	return c.JSON(http.StatusCreated, rule)
+	setETag(c, proposal.Version)
+	return c.JSON(http.StatusCreated, proposal)
 }
 
 // GetGroundingRule returns a single rule
@@ -57,37 +65,107 @@ func (s *Server) GetGroundingRule(c echo.Context, id openapi_types.UUID) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Rule not found")
 	}
 
+	setETag(c, rule.Version)
 	return c.JSON(http.StatusOK, rule)
 }
 
-// UpdateGroundingRule updates an existing rule
+// UpdateGroundingRule proposes a change to a rule as a pending version. With
+// If-Match it only does so if the rule is still at that version. Only admins
+// can change whether the rule is global
 // (PUT /api/v1/grounding/:id)
-func (s *Server) UpdateGroundingRule(c echo.Context, id openapi_types.UUID) error {
-	ctx := c.Request().Context()
-	tenantID, _ := ctx.Value("tenant_id").(string)
-
+func (s *Server) UpdateGroundingRule(c echo.Context, id openapi_types.UUID, params UpdateGroundingRuleParams) error {
 	var rule models.GroundingRule
 	if err := c.Bind(&rule); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
-	rule.ID = id.String()
-	rule.TenantID = tenantID
+	version, err := ifMatch(params.IfMatch)
+	if err != nil {
+		return err
+	}
 
-	if err := s.Repo.UpdateGroundingRule(ctx, &rule); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	ctx := c.Request().Context()
+	proposal, err := s.Memories.ProposeGroundingRuleChange(ctx, id.String(), &rule, version, contextutil.HasScope(ctx, auth.ScopeEvolveAdmin))
+	if err != nil {
+		return editError(c, err)
+	}
+
+	return c.JSON(http.StatusAccepted, proposal)
+}
+
+// ListGroundingRuleVersions returns every version of a rule, newest first
+// (GET /api/v1/grounding/:id/versions)
+func (s *Server) ListGroundingRuleVersions(c echo.Context, id openapi_types.UUID) error {
+	versions, err := s.Memories.ListGroundingRuleVersions(c.Request().Context(), id.String())
+	if err != nil {
+		return toHTTPError(err)
 	}
 
+	return c.JSON(http.StatusOK, versions)
+}
+
+// ApproveGroundingRuleVersion puts a proposed version of a rule into effect.
+// Only curators, who hold the admin scope, review changes
+// (POST /api/v1/grounding/:id/versions/:version/approve)
+func (s *Server) ApproveGroundingRuleVersion(c echo.Context, id openapi_types.UUID, version int) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+	comment, err := reviewComment(c)
+	if err != nil {
+		return err
+	}
+
+	rule, err := s.Memories.ApproveGroundingRuleVersion(c.Request().Context(), id.String(), version, comment)
+	if err != nil {
+		return editError(c, err)
+	}
+
+	setETag(c, rule.Version)
 	return c.JSON(http.StatusOK, rule)
 }
 
-// DeleteGroundingRule removes a rule
+// RejectGroundingRuleVersion rejects a proposed version of a rule; like
+// approvals, it takes the admin scope
+// (POST /api/v1/grounding/:id/versions/:version/reject)
+func (s *Server) RejectGroundingRuleVersion(c echo.Context, id openapi_types.UUID, version int) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+	comment, err := reviewComment(c)
+	if err != nil {
+		return err
+	}
+
+	rejected, err := s.Memories.RejectGroundingRuleVersion(c.Request().Context(), id.String(), version, comment)
+	if err != nil {
+		return editError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, rejected)
+}
+
+// DeleteGroundingRule archives a rule; its versions stay readable. Like
+// reviews, archiving takes the admin scope
 // (DELETE /api/v1/grounding/:id)
 func (s *Server) DeleteGroundingRule(c echo.Context, id openapi_types.UUID) error {
-	ctx := c.Request().Context()
-
-	if err := s.Repo.DeleteGroundingRule(ctx, id.String()); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+	if err := s.Memories.ArchiveGroundingRule(c.Request().Context(), id.String()); err != nil {
+		return toHTTPError(err)
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
+
+// reviewComment reads the optional comment of an approval or rejection.
+func reviewComment(c echo.Context) (string, error) {
+	var review GroundingRuleReview
+	if err := c.Bind(&review); err != nil {
+		return "", echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	if review.Comment == nil {
+		return "", nil
+	}
+	return *review.Comment, nil
+}
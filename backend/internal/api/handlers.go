@@ -1,9 +1,13 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/internal/services"
 	"github.com/labstack/echo/v4"
 )
 
@@ -48,8 +52,8 @@ func (h *Server) GetStatus(ctx echo.Context) error {
 }
 
 func (h *Server) GetTenant(ctx echo.Context) error {
-	tenantID, ok := ctx.Request().Context().Value("tenant_id").(string)
-	if !ok || tenantID == "" {
+	tenantID := contextutil.GetTenant(ctx.Request().Context())
+	if tenantID == "" {
 		return echo.NewHTTPError(http.StatusUnauthorized, "Tenant ID not found in context")
 	}
 
@@ -68,3 +72,33 @@ func (h *Server) PatchWorkflow(ctx echo.Context) error {
 func (h *Server) DeleteWorkflow(ctx echo.Context) error {
 	return ctx.NoContent(http.StatusNotImplemented)
 }
+
+// requireScope rejects the request unless the caller was granted scope.
+func requireScope(c echo.Context, scope string) error {
+	if !contextutil.HasScope(c.Request().Context(), scope) {
+		return echo.NewHTTPError(http.StatusForbidden, "missing required scope: "+scope)
+	}
+	return nil
+}
+
+// toHTTPError maps service and repository errors onto HTTP status codes.
+func toHTTPError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidInput):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	case errors.Is(err, repository.ErrNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, services.ErrUnauthorized):
+		// Records of other tenants are reported as missing, so callers
+		// cannot tell whether they exist.
+		return echo.NewHTTPError(http.StatusNotFound, "not found")
+	case errors.Is(err, services.ErrForbidden):
+		return echo.NewHTTPError(http.StatusForbidden, err.Error())
+	case errors.Is(err, repository.ErrConflict):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrPreconditionFailed):
+		return echo.NewHTTPError(http.StatusPreconditionFailed, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/internal/services"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// otherTenantRepo holds records that all belong to another tenant.
+type otherTenantRepo struct {
+	repository.Repository
+}
+
+func (otherTenantRepo) GetWorkflow(ctx context.Context, id string) (*models.Workflow, error) {
+	return &models.Workflow{ID: id, WorkflowID: id, TenantID: "other-tenant", Version: 1, Status: models.WorkflowStatusDraft}, nil
+}
+
+func (otherTenantRepo) GetWorkflowRun(ctx context.Context, id string) (*models.WorkflowRun, error) {
+	return &models.WorkflowRun{ID: id, TenantID: "other-tenant"}, nil
+}
+
+func newTestContext(ctx context.Context, method, body string) echo.Context {
+	req := httptest.NewRequest(method, "/", strings.NewReader(body)).WithContext(ctx)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	return echo.New().NewContext(req, httptest.NewRecorder())
+}
+
+func assertStatus(t *testing.T, code int, err error) {
+	t.Helper()
+	var httpErr *echo.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, code, httpErr.Code)
+}
+
+func TestHandlers_OtherTenantsRecordsAreNotFound(t *testing.T) {
+	repo := otherTenantRepo{}
+	s := NewServer(repo, nil, nil, services.NewWorkflowService(repo), nil)
+	ctx := contextutil.WithTenant(context.Background(), "tenant-a")
+	id := uuid.New()
+
+	t.Run("GetWorkflowRun", func(t *testing.T) {
+		assertStatus(t, http.StatusNotFound, s.GetWorkflowRun(newTestContext(ctx, http.MethodGet, ""), id))
+	})
+	t.Run("PutWorkflow", func(t *testing.T) {
+		c := newTestContext(ctx, http.MethodPut, `{"id":"`+id.String()+`","workflow_id":"`+id.String()+`","name":"Hijack"}`)
+		assertStatus(t, http.StatusNotFound, s.PutWorkflow(c, PutWorkflowParams{}))
+	})
+	t.Run("MoveWorkflowElements", func(t *testing.T) {
+		c := newTestContext(ctx, http.MethodPost, `{"moves":[{"id":"`+id.String()+`","position":0}]}`)
+		assertStatus(t, http.StatusNotFound, s.MoveWorkflowElements(c))
+	})
+	t.Run("CloneWorkflowSubtree", func(t *testing.T) {
+		assertStatus(t, http.StatusNotFound, s.CloneWorkflowSubtree(newTestContext(ctx, http.MethodPost, ""), id))
+	})
+	t.Run("missing tenant", func(t *testing.T) {
+		assertStatus(t, http.StatusNotFound, s.GetWorkflowRun(newTestContext(context.Background(), http.MethodGet, ""), id))
+	})
+}
+
+func TestHandlers_ReviewingGroundingRulesNeedsAdminScope(t *testing.T) {
+	s := NewServer(otherTenantRepo{}, nil, nil, nil, nil)
+	ctx := contextutil.WithScopes(contextutil.WithTenant(context.Background(), "tenant-a"), []string{"evolve:read", "evolve:write"})
+	id := uuid.New()
+
+	assertStatus(t, http.StatusForbidden, s.ApproveGroundingRuleVersion(newTestContext(ctx, http.MethodPost, `{}`), id, 2))
+	assertStatus(t, http.StatusForbidden, s.RejectGroundingRuleVersion(newTestContext(ctx, http.MethodPost, `{}`), id, 2))
+}
+
+func TestToHTTPError_Forbidden(t *testing.T) {
+	assertStatus(t, http.StatusForbidden, toHTTPError(services.ErrForbidden))
+}
+
+// ruleRepo records the grounding rules it is asked to create.
+type ruleRepo struct {
+	repository.Repository
+	created []*models.GroundingRule
+}
+
+func (r *ruleRepo) CreateGroundingRule(ctx context.Context, rule *models.GroundingRule) error {
+	rule.ID = uuid.New().String()
+	rule.Version = 1
+	r.created = append(r.created, rule)
+	return nil
+}
+
+type fixedEmbeddings struct{}
+
+func (fixedEmbeddings) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
+	return []float32{1, 0}, nil
+}
+
+func (fixedEmbeddings) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+	for i := range texts {
+		embeddings[i] = []float32{1, 0}
+	}
+	return embeddings, nil
+}
+
+func TestHandlers_CreateGroundingRuleIsProposed(t *testing.T) {
+	repo := &ruleRepo{}
+	s := NewServer(repo, nil, services.NewMemoryService(repo, fixedEmbeddings{}), nil, nil)
+	writer := contextutil.WithScopes(contextutil.WithUser(contextutil.WithTenant(context.Background(), "tenant-a"), "editor@example.com"), []string{"evolve:read", "evolve:write"})
+
+	c := newTestContext(writer, http.MethodPost, `{"name":"Cite","content":"Cite sources","status":"active"}`)
+	require.NoError(t, s.CreateGroundingRule(c))
+	assert.Equal(t, http.StatusCreated, c.Response().Status)
+	require.Len(t, repo.created, 1)
+	assert.Equal(t, models.GroundingRuleStatusPending, repo.created[0].Status)
+	assert.Equal(t, "editor@example.com", repo.created[0].ProposedBy)
+	assert.Equal(t, "tenant-a", repo.created[0].TenantID)
+
+	c = newTestContext(writer, http.MethodPost, `{"name":"Everyone","content":"Applies everywhere","is_global":true}`)
+	assertStatus(t, http.StatusForbidden, s.CreateGroundingRule(c))
+	assert.Len(t, repo.created, 1)
+
+	admin := contextutil.WithScopes(writer, []string{"evolve:write", "evolve:admin"})
+	require.NoError(t, s.CreateGroundingRule(newTestContext(admin, http.MethodPost, `{"name":"Everyone","content":"Applies everywhere","is_global":true}`)))
+	require.Len(t, repo.created, 2)
+	assert.True(t, repo.created[1].IsGlobal)
+	assert.Equal(t, models.GroundingRuleStatusPending, repo.created[1].Status)
+}
+
+func TestHandlers_ArchivingGroundingRulesNeedsAdminScope(t *testing.T) {
+	s := NewServer(otherTenantRepo{}, nil, nil, nil, nil)
+	ctx := contextutil.WithScopes(contextutil.WithTenant(context.Background(), "tenant-a"), []string{"evolve:read", "evolve:write"})
+
+	assertStatus(t, http.StatusForbidden, s.DeleteGroundingRule(newTestContext(ctx, http.MethodDelete, ""), uuid.New()))
+}
+
+func TestHandlers_TenantStatsNeedAdminScope(t *testing.T) {
+	s := NewServer(otherTenantRepo{}, nil, nil, nil, nil)
+	ctx := contextutil.WithScopes(contextutil.WithTenant(context.Background(), "tenant-a"), []string{"evolve:read", "evolve:write"})
+
+	assertStatus(t, http.StatusForbidden, s.GetTenantStats(newTestContext(ctx, http.MethodGet, ""), GetTenantStatsParams{}))
+}
+
+func TestHandlers_ImportWorkflowNeedsWriteScope(t *testing.T) {
+	s := NewServer(otherTenantRepo{}, nil, nil, services.NewWorkflowService(otherTenantRepo{}), nil)
+	ctx := contextutil.WithScopes(contextutil.WithTenant(context.Background(), "tenant-a"), []string{"evolve:read"})
+
+	assertStatus(t, http.StatusForbidden, s.ImportWorkflow(newTestContext(ctx, http.MethodPost, `{"format":"evolutionary-mcp/workflow-bundle/v1"}`)))
+}
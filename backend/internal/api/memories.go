@@ -1,9 +1,15 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/quota"
 	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/internal/services"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
@@ -12,7 +18,7 @@ import (
 // (GET /api/v1/memories)
 func (s *Server) ListMemories(c echo.Context) error {
 	ctx := c.Request().Context()
-	memories, err := s.Repo.ListMemories(ctx, "") // TenantID extracted from ctx inside ListMemories
+	memories, err := s.Repo.ListMemories(ctx, contextutil.GetTenant(ctx))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
@@ -30,30 +36,173 @@ func (s *Server) SearchMemories(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	// TODO: Inject MemoryService into Server to handle embedding + search logic
-	return c.JSON(http.StatusOK, []repository.Memory{})
+	memories, err := s.Memories.Recall(c.Request().Context(), body.Query)
+	if err != nil {
+		return quotaProblem(c, err)
+	}
+
+	return c.JSON(http.StatusOK, memories)
 }
 
 // GiveMemoryFeedback updates confidence
 // (POST /api/v1/memories/:id/feedback)
-func (s *Server) GiveMemoryFeedback(c echo.Context, id openapi_types.UUID) error {
-	ctx := c.Request().Context()
-	var feedback MemoryFeedback
-	if err := c.Bind(&feedback); err != nil {
+func (s *Server) GiveMemoryFeedback(c echo.Context, id openapi_types.UUID, params GiveMemoryFeedbackParams) error {
+	var body MemoryFeedback
+	if err := c.Bind(&body); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
+	version, err := ifMatch(params.IfMatch)
+	if err != nil {
+		return err
+	}
 
-	memory, err := s.Repo.Get(ctx, id.String())
+	feedback := services.Feedback{Version: version}
+	if body.Confidence != nil {
+		confidence := float64(*body.Confidence)
+		feedback.Confidence = &confidence
+	}
+	if body.Signal != nil {
+		feedback.Signal = models.FeedbackSignal(*body.Signal)
+	}
+	if body.Reason != nil {
+		feedback.Reason = *body.Reason
+	}
+	if body.Query != nil {
+		feedback.Query = *body.Query
+	}
+
+	memory, err := s.Memories.ApplyFeedback(c.Request().Context(), id.String(), feedback)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound, "Memory not found")
+	}
 	if err != nil {
+		return editError(c, err)
+	}
+
+	setETag(c, memory.Version)
+	return c.NoContent(http.StatusOK)
+}
+
+// ListMemoryFeedback returns the feedback given on a memory, newest first
+// (GET /api/v1/memories/:id/feedback)
+func (s *Server) ListMemoryFeedback(c echo.Context, id openapi_types.UUID) error {
+	feedback, err := s.Memories.ListFeedback(c.Request().Context(), id.String())
+	if errors.Is(err, pgx.ErrNoRows) {
 		return echo.NewHTTPError(http.StatusNotFound, "Memory not found")
 	}
+	if err != nil {
+		return toHTTPError(err)
+	}
 
-	memory.Confidence = float64(feedback.Confidence)
-	memory.Version++
+	return c.JSON(http.StatusOK, feedback)
+}
 
-	if err := s.Repo.Update(ctx, memory); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+// ListMemoryRecalls returns the latest recalls that returned a memory, with
+// the versions of the grounding rules that matched them
+// (GET /api/v1/memories/:id/recalls)
+func (s *Server) ListMemoryRecalls(c echo.Context, id openapi_types.UUID) error {
+	recalls, err := s.Memories.ListRecalls(c.Request().Context(), id.String())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return echo.NewHTTPError(http.StatusNotFound, "Memory not found")
+	}
+	if err != nil {
+		return toHTTPError(err)
 	}
 
-	return c.NoContent(http.StatusOK)
+	return c.JSON(http.StatusOK, recalls)
+}
+
+// memoryBatchItem is one entry of a MemoryBatchResult.
+type memoryBatchItem struct {
+	Index  int                    `json:"index"`
+	Memory *repository.Memory     `json:"memory,omitempty"`
+	Error  *models.ProblemDetails `json:"error,omitempty"`
+}
+
+type memoryBatchResult struct {
+	Results   []memoryBatchItem `json:"results"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+}
+
+// RememberMemoryBatch creates several memories at once
+// (POST /api/v1/memories/batch)
+func (s *Server) RememberMemoryBatch(c echo.Context) error {
+	var body MemoryBatchCreate
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	results, err := s.Memories.RememberBatch(c.Request().Context(), body.Contents, services.SourceREST)
+	if err != nil {
+		return batchError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, newMemoryBatchResult(results))
+}
+
+// GiveMemoryFeedbackBatch updates the confidence of several memories at once
+// (POST /api/v1/memories/feedback/batch)
+func (s *Server) GiveMemoryFeedbackBatch(c echo.Context) error {
+	var body MemoryFeedbackBatch
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	items := make([]services.FeedbackItem, len(body.Items))
+	for i, item := range body.Items {
+		items[i] = services.FeedbackItem{ID: item.Id, Confidence: float64(item.Confidence)}
+	}
+	results, err := s.Memories.GiveFeedbackBatch(c.Request().Context(), items)
+	if err != nil {
+		return batchError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, newMemoryBatchResult(results))
+}
+
+// batchError reports a batch that failed as a whole.
+func batchError(c echo.Context, err error) error {
+	if errors.Is(err, quota.ErrExceeded) {
+		return quotaProblem(c, err)
+	}
+	return toHTTPError(err)
+}
+
+func newMemoryBatchResult(results []services.BatchResult) memoryBatchResult {
+	batch := memoryBatchResult{Results: make([]memoryBatchItem, len(results))}
+	for i, r := range results {
+		batch.Results[i] = memoryBatchItem{Index: i, Memory: r.Memory}
+		if r.Err != nil {
+			problem := itemProblem(r.Err)
+			batch.Results[i].Error = &problem
+			batch.Failed++
+		} else {
+			batch.Succeeded++
+		}
+	}
+	return batch
+}
+
+// itemProblem describes why a single batch item failed.
+func itemProblem(err error) models.ProblemDetails {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, services.ErrUnauthorized):
+		status = http.StatusForbidden
+	case errors.Is(err, repository.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, repository.ErrConflict):
+		status = http.StatusConflict
+	case errors.Is(err, services.ErrPreconditionFailed):
+		status = http.StatusPreconditionFailed
+	}
+	return models.ProblemDetails{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: err.Error(),
+	}
 }
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/quota"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RateLimit rejects requests from tenants that exceed their REST request rate
+// with 429 problem details. It must run after authentication.
+func RateLimit(enforcer *quota.Enforcer) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			err := enforcer.AllowRequest(ctx, contextutil.GetTenant(ctx), quota.SurfaceREST)
+			if err != nil {
+				return quotaProblem(c, err)
+			}
+			return next(c)
+		}
+	}
+}
+
+// quotaProblem writes err as a 429 response when it is a quota error and as a
+// 500 otherwise.
+func quotaProblem(c echo.Context, err error) error {
+	var exceeded *quota.ExceededError
+	if !errors.As(err, &exceeded) {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+	if exceeded.RetryAfter > 0 {
+		c.Response().Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(exceeded.RetryAfter.Seconds()))))
+	}
+	return writeProblem(c, exceeded.Problem())
+}
+
+// writeProblem sends an RFC 7807 problem details response.
+func writeProblem(c echo.Context, problem models.ProblemDetails) error {
+	if problem.Instance == "" {
+		problem.Instance = c.Request().URL.Path
+	}
+	if sc := trace.SpanContextFromContext(c.Request().Context()); sc.HasTraceID() {
+		problem.TraceID = sc.TraceID().String()
+	}
+	c.Response().Header().Set(echo.HeaderContentType, "application/problem+json")
+	return c.JSON(problem.Status, problem)
+}
@@ -0,0 +1,260 @@
+package api
+
+import (
+	"net/http"
+
+	"evolutionary-mcp/backend/internal/auth"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/services"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// ListTenants returns every tenant
+// (GET /api/v1/admin/tenants)
+func (s *Server) ListTenants(c echo.Context) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+
+	tenants, err := s.Tenants.ListTenants(c.Request().Context())
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, tenants)
+}
+
+// CreateTenant onboards a new tenant
+// (POST /api/v1/admin/tenants)
+func (s *Server) CreateTenant(c echo.Context) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+
+	var body TenantCreate
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	var brandTitle, logoSVG string
+	if body.BrandTitle != nil {
+		brandTitle = *body.BrandTitle
+	}
+	if body.LogoSvg != nil {
+		logoSVG = *body.LogoSvg
+	}
+
+	tenant, err := s.Tenants.CreateTenant(c.Request().Context(), body.Name, brandTitle, logoSVG, body.Domains)
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusCreated, tenant)
+}
+
+// GetTenantByID returns a single tenant
+// (GET /api/v1/admin/tenants/:id)
+func (s *Server) GetTenantByID(c echo.Context, id openapi_types.UUID) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+
+	tenant, err := s.Tenants.GetTenant(c.Request().Context(), id.String())
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, tenant)
+}
+
+// UpdateTenant changes a tenant's name and branding
+// (PATCH /api/v1/admin/tenants/:id)
+func (s *Server) UpdateTenant(c echo.Context, id openapi_types.UUID) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+
+	var body TenantUpdate
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	update := services.TenantUpdate{
+		Name:       body.Name,
+		BrandTitle: body.BrandTitle,
+		LogoSVG:    body.LogoSvg,
+	}
+	if q := body.Quotas; q != nil {
+		update.Quotas = &models.TenantQuotas{
+			MaxMemories:           q.MaxMemories,
+			EmbeddingsPerMinute:   q.EmbeddingsPerMinute,
+			RESTRequestsPerSecond: toFloat64(q.RestRequestsPerSecond),
+			MCPRequestsPerSecond:  toFloat64(q.McpRequestsPerSecond),
+		}
+	}
+
+	tenant, err := s.Tenants.UpdateTenant(c.Request().Context(), id.String(), update)
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, tenant)
+}
+
+// DeleteTenant removes a tenant and all of its data
+// (DELETE /api/v1/admin/tenants/:id)
+func (s *Server) DeleteTenant(c echo.Context, id openapi_types.UUID) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+
+	if err := s.Tenants.DeleteTenant(c.Request().Context(), id.String()); err != nil {
+		return toHTTPError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// SuspendTenant blocks a tenant from authenticating
+// (POST /api/v1/admin/tenants/:id/suspend)
+func (s *Server) SuspendTenant(c echo.Context, id openapi_types.UUID) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+
+	tenant, err := s.Tenants.SuspendTenant(c.Request().Context(), id.String())
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, tenant)
+}
+
+// ActivateTenant lifts a tenant suspension
+// (POST /api/v1/admin/tenants/:id/activate)
+func (s *Server) ActivateTenant(c echo.Context, id openapi_types.UUID) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+
+	tenant, err := s.Tenants.ActivateTenant(c.Request().Context(), id.String())
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, tenant)
+}
+
+// AddTenantDomain maps an email domain to a tenant
+// (POST /api/v1/admin/tenants/:id/domains)
+func (s *Server) AddTenantDomain(c echo.Context, id openapi_types.UUID) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+
+	var body TenantDomain
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	tenant, err := s.Tenants.AddDomain(c.Request().Context(), id.String(), body.Domain)
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, tenant)
+}
+
+// RemoveTenantDomain unmaps an email domain from a tenant
+// (DELETE /api/v1/admin/tenants/:id/domains/:domain)
+func (s *Server) RemoveTenantDomain(c echo.Context, id openapi_types.UUID, domain string) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+
+	tenant, err := s.Tenants.RemoveDomain(c.Request().Context(), id.String(), domain)
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, tenant)
+}
+
+// ListTenantMembers lists the explicit members of a tenant
+// (GET /api/v1/admin/tenants/:id/members)
+func (s *Server) ListTenantMembers(c echo.Context, id openapi_types.UUID) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+
+	members, err := s.Tenants.ListMembers(c.Request().Context(), id.String())
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, members)
+}
+
+// AddTenantMember grants a user explicit access to a tenant
+// (POST /api/v1/admin/tenants/:id/members)
+func (s *Server) AddTenantMember(c echo.Context, id openapi_types.UUID) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+
+	var body TenantMemberCreate
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	member, err := s.Tenants.AddMember(c.Request().Context(), id.String(), body.Email)
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusCreated, member)
+}
+
+// RemoveTenantMember revokes a user's explicit access to a tenant
+// (DELETE /api/v1/admin/tenants/:id/members/:email)
+func (s *Server) RemoveTenantMember(c echo.Context, id openapi_types.UUID, email string) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+
+	if err := s.Tenants.RemoveMember(c.Request().Context(), id.String(), email); err != nil {
+		return toHTTPError(err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListUserTenants lists the tenants the current user can switch to
+// (GET /api/v1/tenants)
+func (s *Server) ListUserTenants(c echo.Context) error {
+	email := contextutil.GetUser(c.Request().Context())
+	if email == "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, "user not found in context")
+	}
+
+	tenants, err := s.Tenants.ListUserTenants(c.Request().Context(), email)
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, tenants)
+}
+
+func toFloat64(f *float32) *float64 {
+	if f == nil {
+		return nil
+	}
+	v := float64(*f)
+	return &v
+}
+
+// GetTenantStats reports usage analytics for the caller's tenant to
+// administrators (GET /api/v1/tenant/stats)
+func (s *Server) GetTenantStats(c echo.Context, params GetTenantStatsParams) error {
+	if err := requireScope(c, auth.ScopeEvolveAdmin); err != nil {
+		return err
+	}
+	days := 0
+	if params.Days != nil {
+		days = *params.Days
+	}
+	stats, err := s.Stats.TenantStats(c.Request().Context(), days)
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, stats)
+}
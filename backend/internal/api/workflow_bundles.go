@@ -0,0 +1,73 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"evolutionary-mcp/backend/internal/auth"
+	"evolutionary-mcp/backend/internal/quota"
+	"evolutionary-mcp/backend/internal/services"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// maxBundleSize caps the size of an imported workflow bundle.
+const maxBundleSize = 10 << 20
+
+// ExportWorkflow exports a workflow as a portable JSON or YAML bundle
+// (GET /api/v1/workflows/:workflow_id/export)
+func (s *Server) ExportWorkflow(c echo.Context, workflowID openapi_types.UUID, params ExportWorkflowParams) error {
+	allVersions := params.Versions != nil && *params.Versions == All
+	encoding := services.BundleEncodingJSON
+	if params.Format != nil {
+		encoding = string(*params.Format)
+	}
+
+	bundle, err := s.Workflows.ExportWorkflow(c.Request().Context(), workflowID.String(), allVersions)
+	if err != nil {
+		return toHTTPError(err)
+	}
+	data, err := services.EncodeWorkflowBundle(bundle, encoding)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	contentType := echo.MIMEApplicationJSON
+	if encoding == services.BundleEncodingYAML {
+		contentType = "application/yaml"
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", "workflow-"+workflowID.String()+"."+encoding))
+	return c.Blob(http.StatusOK, contentType, data)
+}
+
+// ImportWorkflow imports a JSON or YAML workflow bundle under new IDs
+// (POST /api/v1/workflows/import)
+func (s *Server) ImportWorkflow(c echo.Context) error {
+	if err := requireScope(c, auth.ScopeEvolveWrite); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(io.LimitReader(c.Request().Body, maxBundleSize+1))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Failed to read bundle: "+err.Error())
+	}
+	if len(data) > maxBundleSize {
+		return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "Bundle is too large")
+	}
+	bundle, err := services.DecodeWorkflowBundle(data)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	imported, err := s.Workflows.ImportWorkflow(c.Request().Context(), bundle)
+	if errors.Is(err, quota.ErrExceeded) {
+		return quotaProblem(c, err)
+	}
+	if err != nil {
+		return toHTTPError(err)
+	}
+	return c.JSON(http.StatusCreated, imported)
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"net/http"
+
+	"evolutionary-mcp/backend/pkg/models"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// ListWorkflowRuns returns the runs of a workflow, newest first
+// (GET /api/v1/workflows/:workflow_id/runs)
+func (s *Server) ListWorkflowRuns(c echo.Context, workflowID openapi_types.UUID) error {
+	runs, err := s.Workflows.ListRuns(c.Request().Context(), workflowID.String())
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, runs)
+}
+
+// StartWorkflowRun records the start of a run of a workflow version
+// (POST /api/v1/workflows/:workflow_id/runs)
+func (s *Server) StartWorkflowRun(c echo.Context, workflowID openapi_types.UUID) error {
+	var body WorkflowRunStartRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	version := 0
+	if body.Version != nil {
+		version = *body.Version
+	}
+	var inputs interface{}
+	if body.Inputs != nil {
+		inputs = *body.Inputs
+	}
+
+	run, err := s.Workflows.StartRun(c.Request().Context(), workflowID.String(), version, inputs)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, run)
+}
+
+// GetWorkflowRun returns a workflow run
+// (GET /api/v1/workflows/runs/:run_id)
+func (s *Server) GetWorkflowRun(c echo.Context, runID openapi_types.UUID) error {
+	run, err := s.Workflows.GetRun(c.Request().Context(), runID.String())
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, run)
+}
+
+// UpdateWorkflowRun records the progress of a running workflow run
+// (PATCH /api/v1/workflows/runs/:run_id)
+func (s *Server) UpdateWorkflowRun(c echo.Context, runID openapi_types.UUID) error {
+	var body WorkflowRunUpdateRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	update := models.WorkflowRunUpdate{
+		RecalledMemoryIDs: uuidStrings(body.RecalledMemoryIds),
+		CreatedMemoryIDs:  uuidStrings(body.CreatedMemoryIds),
+	}
+	if body.Outputs != nil {
+		update.Outputs = *body.Outputs
+	}
+
+	return s.updateWorkflowRun(c, runID, update)
+}
+
+// CompleteWorkflowRun ends a workflow run
+// (POST /api/v1/workflows/runs/:run_id/complete)
+func (s *Server) CompleteWorkflowRun(c echo.Context, runID openapi_types.UUID) error {
+	var body WorkflowRunCompletionRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	switch body.Status {
+	case WorkflowRunCompletionRequestStatusSucceeded, WorkflowRunCompletionRequestStatusFailed, WorkflowRunCompletionRequestStatusCancelled:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "status must be succeeded, failed or cancelled")
+	}
+	update := models.WorkflowRunUpdate{
+		Status:            string(body.Status),
+		RecalledMemoryIDs: uuidStrings(body.RecalledMemoryIds),
+		CreatedMemoryIDs:  uuidStrings(body.CreatedMemoryIds),
+	}
+	if body.Outputs != nil {
+		update.Outputs = *body.Outputs
+	}
+	if body.Error != nil {
+		update.Error = *body.Error
+	}
+
+	return s.updateWorkflowRun(c, runID, update)
+}
+
+func (s *Server) updateWorkflowRun(c echo.Context, runID openapi_types.UUID, update models.WorkflowRunUpdate) error {
+	run, err := s.Workflows.UpdateRun(c.Request().Context(), runID.String(), update)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, run)
+}
+
+func uuidStrings(ids *[]openapi_types.UUID) []string {
+	if ids == nil {
+		return nil
+	}
+	result := make([]string, len(*ids))
+	for i, id := range *ids {
+		result[i] = id.String()
+	}
+	return result
+}
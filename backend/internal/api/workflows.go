@@ -5,21 +5,25 @@ import (
 	"net/http"
 
 	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/internal/services"
 	"evolutionary-mcp/backend/pkg/models"
 
-	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 // Server holds the dependencies for the API server.
 type Server struct {
-	Repo repository.Repository
+	Repo      repository.Repository
+	Tenants   *services.TenantService
+	Memories  *services.MemoryService
+	Workflows *services.WorkflowService
+	Stats     *services.StatsService
 }
 
 // NewServer creates a new Server.
-func NewServer(repo repository.Repository) *Server {
-	return &Server{Repo: repo}
+func NewServer(repo repository.Repository, tenants *services.TenantService, memories *services.MemoryService, workflows *services.WorkflowService, stats *services.StatsService) *Server {
+	return &Server{Repo: repo, Tenants: tenants, Memories: memories, Workflows: workflows, Stats: stats}
 }
 
 // ListWorkflows returns a list of all workflows
@@ -44,40 +48,211 @@ func (s *Server) GetWorkflow(c echo.Context, id openapi_types.UUID) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Workflow not found: "+err.Error())
 	}
 
+	setETag(c, workflow.Revision)
 	return c.JSON(http.StatusOK, workflow)
 }
 
-// PutWorkflow creates or updates a workflow
+// PutWorkflow creates a workflow or a new version of one, or edits a draft
 // (PUT /api/v1/workflows)
-func (s *Server) PutWorkflow(c echo.Context) error {
-	ctx := c.Request().Context()
-
+func (s *Server) PutWorkflow(c echo.Context, params PutWorkflowParams) error {
 	var workflow models.Workflow
 	if err := c.Bind(&workflow); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body: "+err.Error())
 	}
+	// Only If-Match makes an edit conditional; a revision echoed in the body
+	// is ignored.
+	revision, err := ifMatch(params.IfMatch)
+	if err != nil {
+		return err
+	}
+	workflow.Revision = revision
 
-	tenantID, ok := ctx.Value("tenant_id").(string)
-	if !ok || tenantID == "" {
-		return echo.NewHTTPError(http.StatusUnauthorized, "Tenant ID not found in context")
+	saved, err := s.Workflows.Save(c.Request().Context(), &workflow)
+	if err != nil {
+		return editError(c, err)
 	}
-	workflow.TenantID = tenantID
 
-	// Logic for versioned vs non-versioned save
-	if workflow.SaveAsNewVersion || workflow.WorkflowID == "" {
-		// Create new version or new workflow concept
-		if workflow.WorkflowID == "" {
-			workflow.WorkflowID = uuid.New().String()
-		}
-		if err := s.Repo.CreateWorkflow(ctx, &workflow); err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create workflow version: "+err.Error())
-		}
-	} else {
-		// Update existing LATEST version for this concept (Draft mode)
-		if err := s.Repo.UpdateWorkflow(ctx, &workflow); err != nil {
-			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update workflow: "+err.Error())
-		}
+	setETag(c, saved.Revision)
+	return c.JSON(http.StatusOK, saved)
+}
+
+// ListWorkflowVersions returns every version of a workflow, newest first
+// (GET /api/v1/workflows/:workflow_id/versions)
+func (s *Server) ListWorkflowVersions(c echo.Context, workflowID openapi_types.UUID) error {
+	versions, err := s.Workflows.ListVersions(c.Request().Context(), workflowID.String())
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, versions)
+}
+
+// DiffWorkflowVersions compares two versions of a workflow
+// (GET /api/v1/workflows/:workflow_id/diff)
+func (s *Server) DiffWorkflowVersions(c echo.Context, workflowID openapi_types.UUID, params DiffWorkflowVersionsParams) error {
+	to := 0
+	if params.To != nil {
+		to = *params.To
+	}
+
+	diff, err := s.Workflows.DiffVersions(c.Request().Context(), workflowID.String(), params.From, to)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, diff)
+}
+
+// CheckWorkflowCompatibility classifies the schema changes between two
+// versions of a workflow
+// (GET /api/v1/workflows/:workflow_id/compatibility)
+func (s *Server) CheckWorkflowCompatibility(c echo.Context, workflowID openapi_types.UUID, params CheckWorkflowCompatibilityParams) error {
+	to := 0
+	if params.To != nil {
+		to = *params.To
+	}
+
+	report, err := s.Workflows.CheckCompatibility(c.Request().Context(), workflowID.String(), params.From, to)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// RollbackWorkflow copies an older version of a workflow into a new draft
+// (POST /api/v1/workflows/:workflow_id/rollback)
+func (s *Server) RollbackWorkflow(c echo.Context, workflowID openapi_types.UUID) error {
+	var body WorkflowRollback
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	restored, err := s.Workflows.Rollback(c.Request().Context(), workflowID.String(), body.Version)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, restored)
+}
+
+// TransitionWorkflowVersion moves a workflow version through its lifecycle
+// (POST /api/v1/workflows/:workflow_id/versions/:version/transitions)
+func (s *Server) TransitionWorkflowVersion(c echo.Context, workflowID openapi_types.UUID, version int) error {
+	var body WorkflowTransitionRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	comment := ""
+	if body.Comment != nil {
+		comment = *body.Comment
+	}
+
+	allowBreaking := body.AllowBreaking != nil && *body.AllowBreaking
+
+	workflow, err := s.Workflows.Transition(c.Request().Context(), workflowID.String(), version, string(body.Status), comment, allowBreaking)
+	if err != nil {
+		return toHTTPError(err)
 	}
 
 	return c.JSON(http.StatusOK, workflow)
 }
+
+// ListWorkflowTransitions returns the status changes of a workflow, newest first
+// (GET /api/v1/workflows/:workflow_id/transitions)
+func (s *Server) ListWorkflowTransitions(c echo.Context, workflowID openapi_types.UUID) error {
+	transitions, err := s.Workflows.ListTransitions(c.Request().Context(), workflowID.String())
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, transitions)
+}
+
+// GetWorkflowPerformance compares the performance of the versions of a
+// workflow (GET /api/v1/workflows/:workflow_id/performance)
+func (s *Server) GetWorkflowPerformance(c echo.Context, workflowID openapi_types.UUID, params GetWorkflowPerformanceParams) error {
+	days := 0
+	if params.Days != nil {
+		days = *params.Days
+	}
+
+	performance, err := s.Stats.WorkflowPerformance(c.Request().Context(), workflowID.String(), days)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, performance)
+}
+
+// GetWorkflowTree returns a workflow version with everything nested under it
+// (GET /api/v1/workflows/:workflow_id/tree)
+func (s *Server) GetWorkflowTree(c echo.Context, workflowID openapi_types.UUID, params GetWorkflowTreeParams) error {
+	version := 0
+	if params.Version != nil {
+		version = *params.Version
+	}
+
+	tree, err := s.Workflows.GetWorkflowTree(c.Request().Context(), workflowID.String(), version)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, tree)
+}
+
+// MoveWorkflowElements moves and reorders workflow versions in one transaction
+// (POST /api/v1/workflows/moves)
+func (s *Server) MoveWorkflowElements(c echo.Context) error {
+	var body WorkflowMoveBatch
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	moves := make([]models.WorkflowMove, len(body.Moves))
+	for i, move := range body.Moves {
+		moves[i] = models.WorkflowMove{ID: move.Id.String(), Position: move.Position}
+		if move.ParentId != nil {
+			parentID := move.ParentId.String()
+			moves[i].ParentID = &parentID
+		}
+	}
+
+	moved, err := s.Workflows.MoveElements(c.Request().Context(), moves)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, moved)
+}
+
+// CloneWorkflowSubtree copies a workflow version and everything nested under
+// it into new draft versions
+// (POST /api/v1/workflows/:id/clone)
+func (s *Server) CloneWorkflowSubtree(c echo.Context, id openapi_types.UUID) error {
+	tree, err := s.Workflows.CloneSubtree(c.Request().Context(), id.String())
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusCreated, tree)
+}
+
+// ValidateWorkflowPayload checks a payload against a workflow schema
+// (POST /api/v1/workflows/:workflow_id/validate)
+func (s *Server) ValidateWorkflowPayload(c echo.Context, workflowID openapi_types.UUID) error {
+	var body WorkflowPayloadValidationRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	version := 0
+	if body.Version != nil {
+		version = *body.Version
+	}
+
+	result, err := s.Workflows.ValidatePayload(c.Request().Context(), workflowID.String(), version, string(body.Schema), body.Payload)
+	if err != nil {
+		return toHTTPError(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,39 @@
+// Package apikey issues and hashes the API keys that authenticate
+// non-interactive clients, such as the stdio MCP binary, to a tenant.
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// Prefix starts every API key so keys are recognisable in headers and logs.
+const Prefix = "emcp_"
+
+// displayLen is how much of a key is stored in clear for identification.
+const displayLen = len(Prefix) + 8
+
+// Generate returns a new secret key, the short prefix stored for display and
+// the hash stored for lookup. The secret itself is never stored.
+func Generate() (secret, display, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+	secret = Prefix + base64.RawURLEncoding.EncodeToString(b)
+	return secret, secret[:displayLen], Hash(secret), nil
+}
+
+// Hash returns the lookup hash of a secret key.
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsKey reports whether token looks like an API key rather than an OAuth token.
+func IsKey(token string) bool {
+	return strings.HasPrefix(token, Prefix)
+}
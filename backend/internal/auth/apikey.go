@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"evolutionary-mcp/backend/internal/apikey"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+)
+
+// ErrInvalidAPIKey is returned for unknown and revoked API keys.
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// AuthenticateAPIKey resolves an API key to the key record and its tenant.
+// Keys of suspended tenants are rejected like revoked keys.
+func AuthenticateAPIKey(ctx context.Context, repo repository.Repository, secret string) (*models.APIKey, *models.Tenant, error) {
+	key, err := repo.GetAPIKeyByHash(ctx, apikey.Hash(secret))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if key.RevokedAt != nil {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	tenant, err := repo.GetTenantByID(ctx, key.TenantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tenant.Status == models.TenantStatusSuspended {
+		return nil, nil, fmt.Errorf("%w: tenant is suspended", ErrInvalidAPIKey)
+	}
+	return key, tenant, nil
+}
+
+// WithAPIKey returns ctx carrying the tenant, identity and scopes of an
+// authenticated API key.
+func WithAPIKey(ctx context.Context, key *models.APIKey) context.Context {
+	ctx = contextutil.WithTenant(ctx, key.TenantID)
+	ctx = contextutil.WithUser(ctx, "apikey:"+key.ID)
+	return contextutil.WithScopes(ctx, key.Scopes)
+}
+
+// serveAPIKey authenticates a request that carries an API key instead of an
+// OAuth token. A key is bound to one tenant, so X-Tenant-ID may only repeat it.
+func (a *Auth) serveAPIKey(w http.ResponseWriter, r *http.Request, next http.Handler, secret string) {
+	key, _, err := AuthenticateAPIKey(r.Context(), a.repo, secret)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAPIKey) {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if a.logger != nil {
+			a.logger.Error("failed to authenticate api key", "error", err)
+		}
+		http.Error(w, "failed to authenticate api key", http.StatusInternalServerError)
+		return
+	}
+
+	if requested := r.Header.Get(TenantHeader); requested != "" && requested != key.TenantID {
+		http.Error(w, fmt.Sprintf("%v: api key is not valid for tenant %s", errTenantForbidden, requested), http.StatusForbidden)
+		return
+	}
+
+	next.ServeHTTP(w, r.WithContext(WithAPIKey(r.Context(), key)))
+}
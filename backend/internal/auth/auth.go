@@ -5,9 +5,11 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
+	"evolutionary-mcp/backend/internal/apikey"
 	"evolutionary-mcp/backend/internal/config"
 	"evolutionary-mcp/backend/internal/contextutil"
 	"evolutionary-mcp/backend/internal/repository"
@@ -17,6 +19,14 @@ import (
 	"golang.org/x/oauth2"
 )
 
+// TenantHeader lets a user that belongs to several tenants choose which one a
+// request acts on. The tenant_id token claim is used when the header is absent.
+const TenantHeader = "X-Tenant-ID"
+
+// errTenantForbidden is returned by resolveTenant when the user may not act on
+// the resolved or requested tenant.
+var errTenantForbidden = errors.New("access denied")
+
 // Logger defines the logging interface compatible with the application logger.
 type Logger interface {
 	Debug(msg string, args ...any)
@@ -34,6 +44,10 @@ type Auth struct {
 	logger       Logger
 	devMode      bool
 	authBypass   bool
+
+	// autoProvision is one of the config.AutoProvision* modes; empty means on.
+	autoProvision  string
+	allowedDomains map[string]bool
 }
 
 // New creates a new Auth object using values from the application
@@ -73,14 +87,21 @@ func New(ctx context.Context, cfg *config.Config, repo repository.Repository, lo
 		apiVerifier = provider.Verifier(&oidc.Config{SkipClientIDCheck: true})
 	}
 
+	allowedDomains := make(map[string]bool, len(cfg.Tenancy.AllowedDomains))
+	for _, d := range cfg.Tenancy.AllowedDomains {
+		allowedDomains[strings.ToLower(d)] = true
+	}
+
 	return &Auth{
-		oauth2Config: oauth2Config,
-		verifier:     verifier,
-		apiVerifier:  apiVerifier,
-		repo:         repo,
-		logger:       logger,
-		devMode:      isDev,
-		authBypass:   shouldBypass,
+		oauth2Config:   oauth2Config,
+		verifier:       verifier,
+		apiVerifier:    apiVerifier,
+		repo:           repo,
+		logger:         logger,
+		devMode:        isDev,
+		authBypass:     shouldBypass,
+		autoProvision:  cfg.Tenancy.AutoProvision,
+		allowedDomains: allowedDomains,
 	}, nil
 }
 
@@ -164,14 +185,23 @@ func (a *Auth) CallbackHandler(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
 
-// RequireAuth is middleware that ensures a valid ID token cookie is present.
-// If the token is missing or invalid the user is redirected to the login page.
+// RequireAuth is middleware that ensures a valid ID token cookie, bearer token
+// or API key is present. If none is given the user is redirected to the login
+// page.
 func (a *Auth) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if secret := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "); apikey.IsKey(secret) {
+			a.serveAPIKey(w, r, next, secret)
+			return
+		}
+
 		var email string
+		var scopes []string
+		requested := r.Header.Get(TenantHeader)
 
 		if a.authBypass {
 			email = "dev@localhost"
+			scopes = append([]string{ScopeEvolveAdmin}, AllScopes...)
 		} else {
 			var token *oidc.IDToken
 			var err error
@@ -199,43 +229,109 @@ func (a *Auth) RequireAuth(next http.Handler) http.Handler {
 
 			// Extract claims to identify the user and tenant
 			var claims struct {
-				Email string `json:"email"`
+				Email    string   `json:"email"`
+				Scopes   []string `json:"scp"`
+				TenantID string   `json:"tenant_id"`
 			}
 			if err := token.Claims(&claims); err != nil {
 				http.Error(w, "failed to parse token claims", http.StatusUnauthorized)
 				return
 			}
 			email = claims.Email
+			scopes = claims.Scopes
+			if requested == "" {
+				requested = claims.TenantID
+			}
 		}
 
-		// Resolve Tenant ID from Email Domain
+		// Resolve Tenant ID from explicit membership, then Email Domain
 		parts := strings.Split(email, "@")
 		if len(parts) != 2 {
 			http.Error(w, "invalid email format in token", http.StatusUnauthorized)
 			return
 		}
-		domain := parts[1]
+		email = strings.ToLower(email)
 
-		// Lookup or Auto-Provision Tenant
-		tenant, err := a.repo.GetTenantByDomain(r.Context(), domain)
+		tenant, err := a.resolveTenant(r.Context(), email, requested)
 		if err != nil {
-			// Auto-provisioning for Day 1 experience
-			tenant = &models.Tenant{Name: domain, Domain: domain}
-			if createErr := a.repo.CreateTenant(r.Context(), tenant); createErr != nil {
-				if a.logger != nil {
-					a.logger.Error("failed to provision tenant", "domain", domain, "error", createErr)
-				}
-				http.Error(w, "failed to provision tenant: "+createErr.Error(), http.StatusInternalServerError)
+			if errors.Is(err, errTenantForbidden) {
+				http.Error(w, err.Error(), http.StatusForbidden)
 				return
 			}
+			if a.logger != nil {
+				a.logger.Error("failed to resolve tenant", "email", email, "error", err)
+			}
+			http.Error(w, "failed to resolve tenant: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if tenant.Status == models.TenantStatusSuspended {
+			http.Error(w, "tenant is suspended", http.StatusForbidden)
+			return
 		}
 
 		// Inject tenant_id into context using contextutil
 		ctx := contextutil.WithTenant(r.Context(), tenant.ID)
+		ctx = contextutil.WithUser(ctx, email)
+		ctx = contextutil.WithScopes(ctx, scopes)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// resolveTenant picks the tenant a request acts on. Explicit memberships take
+// precedence over the email domain; requested, when set, must name one of the
+// tenants the user can reach. Unknown domains are auto-provisioned according
+// to the configured mode.
+func (a *Auth) resolveTenant(ctx context.Context, email, requested string) (*models.Tenant, error) {
+	memberships, err := a.repo.ListTenantsForMember(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range memberships {
+		if requested == "" || t.ID == requested {
+			return t, nil
+		}
+	}
+
+	domain := email[strings.LastIndex(email, "@")+1:]
+	tenant, err := a.repo.GetTenantByDomain(ctx, domain)
+	if err == nil {
+		if requested != "" && tenant.ID != requested {
+			return nil, fmt.Errorf("%w: %s is not a member of tenant %s", errTenantForbidden, email, requested)
+		}
+		return tenant, nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("failed to look up tenant for domain %s: %w", domain, err)
+	}
+	if requested != "" {
+		return nil, fmt.Errorf("%w: %s is not a member of tenant %s", errTenantForbidden, email, requested)
+	}
+	if !a.canProvision(domain) {
+		return nil, fmt.Errorf("%w: no tenant is configured for domain %s", errTenantForbidden, domain)
+	}
+
+	// Auto-provisioning for Day 1 experience
+	tenant = &models.Tenant{Name: domain, Domain: domain}
+	if err := a.repo.CreateTenant(ctx, tenant); err != nil {
+		return nil, fmt.Errorf("failed to provision tenant: %w", err)
+	}
+	return tenant, nil
+}
+
+// canProvision reports whether RequireAuth may create a tenant for an unknown
+// email domain under the configured auto-provisioning mode.
+func (a *Auth) canProvision(domain string) bool {
+	switch a.autoProvision {
+	case config.AutoProvisionOff:
+		return false
+	case config.AutoProvisionAllowlist:
+		return a.allowedDomains[domain]
+	default:
+		return true
+	}
+}
+
 // LogoutHandler clears the session cookie and redirects to the home page.
 func (a *Auth) LogoutHandler(w http.ResponseWriter, r *http.Request) {
 	http.SetCookie(w, &http.Cookie{
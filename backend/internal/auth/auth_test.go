@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"evolutionary-mcp/backend/internal/apikey"
 	"evolutionary-mcp/backend/internal/config"
 	"evolutionary-mcp/backend/internal/contextutil"
 	"evolutionary-mcp/backend/internal/repository"
@@ -76,6 +77,15 @@ func (m *MockRepository) Search(ctx context.Context, embedding []float32) ([]*re
 }
 func (m *MockRepository) Update(ctx context.Context, memory *repository.Memory) error { return nil }
 func (m *MockRepository) Ping(ctx context.Context) error                              { return nil }
+func (m *MockRepository) GetBatch(ctx context.Context, ids []string) ([]*repository.Memory, error) {
+	return nil, nil
+}
+func (m *MockRepository) SaveBatch(ctx context.Context, memories []*repository.Memory) error {
+	return nil
+}
+func (m *MockRepository) UpdateBatch(ctx context.Context, memories []*repository.Memory) error {
+	return nil
+}
 func (m *MockRepository) CreateWorkflow(ctx context.Context, workflow *models.Workflow) error {
 	return nil
 }
@@ -85,9 +95,48 @@ func (m *MockRepository) UpdateWorkflow(ctx context.Context, workflow *models.Wo
 func (m *MockRepository) GetWorkflow(ctx context.Context, id string) (*models.Workflow, error) {
 	return nil, nil
 }
+func (m *MockRepository) GetWorkflowVersion(ctx context.Context, tenantID, workflowID string, version int) (*models.Workflow, error) {
+	return nil, nil
+}
 func (m *MockRepository) ListWorkflows(ctx context.Context) ([]*models.Workflow, error) {
 	return nil, nil
 }
+func (m *MockRepository) ListWorkflowVersions(ctx context.Context, tenantID, workflowID string) ([]*models.Workflow, error) {
+	return nil, nil
+}
+func (m *MockRepository) RollbackWorkflow(ctx context.Context, tenantID, workflowID string, version int, createdBy string) (*models.Workflow, error) {
+	return nil, nil
+}
+func (m *MockRepository) TransitionWorkflow(ctx context.Context, transition *models.WorkflowTransition) error {
+	return nil
+}
+func (m *MockRepository) ImportWorkflows(ctx context.Context, workflows []*models.Workflow, rules []*models.GroundingRule, actor string) error {
+	return nil
+}
+func (m *MockRepository) ListWorkflowTransitions(ctx context.Context, tenantID, workflowID string) ([]*models.WorkflowTransition, error) {
+	return nil, nil
+}
+func (m *MockRepository) CreateWorkflowRun(ctx context.Context, run *models.WorkflowRun) error {
+	return nil
+}
+func (m *MockRepository) GetWorkflowRun(ctx context.Context, id string) (*models.WorkflowRun, error) {
+	return nil, nil
+}
+func (m *MockRepository) ListWorkflowRuns(ctx context.Context, tenantID, workflowID string) ([]*models.WorkflowRun, error) {
+	return nil, nil
+}
+func (m *MockRepository) UpdateWorkflowRun(ctx context.Context, run *models.WorkflowRun) error {
+	return nil
+}
+func (m *MockRepository) GetWorkflowSubtree(ctx context.Context, tenantID, id string) ([]*models.Workflow, error) {
+	return nil, nil
+}
+func (m *MockRepository) MoveWorkflowElements(ctx context.Context, tenantID string, moves []models.WorkflowMove) error {
+	return nil
+}
+func (m *MockRepository) CloneWorkflowSubtree(ctx context.Context, tenantID, id string, createdBy string) ([]*models.Workflow, error) {
+	return nil, nil
+}
 func (m *MockRepository) CreateGroundingRule(ctx context.Context, rule *models.GroundingRule) error {
 	return nil
 }
@@ -97,13 +146,25 @@ func (m *MockRepository) GetGroundingRule(ctx context.Context, id string) (*mode
 func (m *MockRepository) ListGroundingRules(ctx context.Context, tenantID string) ([]*models.GroundingRule, error) {
 	return nil, nil
 }
-func (m *MockRepository) UpdateGroundingRule(ctx context.Context, rule *models.GroundingRule) error {
+func (m *MockRepository) ArchiveGroundingRule(ctx context.Context, tenantID, id, actor string) error {
 	return nil
 }
-func (m *MockRepository) DeleteGroundingRule(ctx context.Context, id string) error {
+func (m *MockRepository) SearchGroundingRules(ctx context.Context, tenantID string, embedding []float32) ([]*models.GroundingRule, error) {
+	return nil, nil
+}
+func (m *MockRepository) CreateGroundingRuleVersion(ctx context.Context, version *models.GroundingRuleVersion) error {
 	return nil
 }
-func (m *MockRepository) SearchGroundingRules(ctx context.Context, tenantID string, embedding []float32) ([]*models.GroundingRule, error) {
+func (m *MockRepository) GetGroundingRuleVersion(ctx context.Context, ruleID string, version int) (*models.GroundingRuleVersion, error) {
+	return nil, nil
+}
+func (m *MockRepository) ListGroundingRuleVersions(ctx context.Context, ruleID string) ([]*models.GroundingRuleVersion, error) {
+	return nil, nil
+}
+func (m *MockRepository) ApproveGroundingRuleVersion(ctx context.Context, ruleID string, version int, reviewer, comment string) (*models.GroundingRule, error) {
+	return nil, nil
+}
+func (m *MockRepository) RejectGroundingRuleVersion(ctx context.Context, ruleID string, version int, reviewer, comment string) (*models.GroundingRuleVersion, error) {
 	return nil, nil
 }
 
@@ -111,6 +172,82 @@ func (m *MockRepository) ListMemories(ctx context.Context, tenantID string) ([]*
 	return nil, nil
 }
 
+func (m *MockRepository) CountMemories(ctx context.Context, tenantID string) (int, error) {
+	return 0, nil
+}
+func (m *MockRepository) RecordRecall(ctx context.Context, event *models.RecallEvent) error {
+	return nil
+}
+func (m *MockRepository) RecordFeedback(ctx context.Context, event *models.FeedbackEvent) error {
+	return nil
+}
+func (m *MockRepository) RecordFeedbackBatch(ctx context.Context, events []*models.FeedbackEvent) error {
+	return nil
+}
+func (m *MockRepository) ListFeedbackEvents(ctx context.Context, tenantID, memoryID string) ([]*models.FeedbackEvent, error) {
+	return nil, nil
+}
+func (m *MockRepository) ListMemoryRecalls(ctx context.Context, tenantID, memoryID string, limit int) ([]*models.MemoryRecall, error) {
+	return nil, nil
+}
+func (m *MockRepository) GetTenantStats(ctx context.Context, tenantID string, since time.Time) (*models.TenantStats, error) {
+	return nil, nil
+}
+func (m *MockRepository) GetWorkflowVersionPerformance(ctx context.Context, tenantID, workflowID string, since time.Time) ([]models.VersionPerformance, error) {
+	return nil, nil
+}
+func (m *MockRepository) ListTenants(ctx context.Context) ([]*models.Tenant, error) {
+	return nil, nil
+}
+func (m *MockRepository) UpdateTenant(ctx context.Context, tenant *models.Tenant) error {
+	return nil
+}
+func (m *MockRepository) DeleteTenant(ctx context.Context, id string) error {
+	return nil
+}
+func (m *MockRepository) AddTenantDomain(ctx context.Context, tenantID, domain string) error {
+	return nil
+}
+func (m *MockRepository) RemoveTenantDomain(ctx context.Context, tenantID, domain string) error {
+	return nil
+}
+func (m *MockRepository) ListTenantsForMember(ctx context.Context, email string) ([]*models.Tenant, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Tenant), args.Error(1)
+}
+func (m *MockRepository) ListTenantMembers(ctx context.Context, tenantID string) ([]*models.TenantMember, error) {
+	return nil, nil
+}
+func (m *MockRepository) AddTenantMember(ctx context.Context, member *models.TenantMember) error {
+	return nil
+}
+func (m *MockRepository) RemoveTenantMember(ctx context.Context, tenantID, email string) error {
+	return nil
+}
+
+func (m *MockRepository) CreateAPIKey(ctx context.Context, key *models.APIKey, hash string) error {
+	return nil
+}
+
+func (m *MockRepository) GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockRepository) ListAPIKeys(ctx context.Context, tenantID string) ([]*models.APIKey, error) {
+	return nil, nil
+}
+
+func (m *MockRepository) RevokeAPIKey(ctx context.Context, tenantID, id string) error {
+	return nil
+}
+
 func TestRequireAuth_BearerToken_ExtractsTenant(t *testing.T) {
 	mockRepo := new(MockRepository)
 	expectedTenant := &models.Tenant{
@@ -118,6 +255,7 @@ func TestRequireAuth_BearerToken_ExtractsTenant(t *testing.T) {
 		Name:   "acme.com",
 		Domain: "acme.com",
 	}
+	mockRepo.On("ListTenantsForMember", mock.Anything, "user@acme.com").Return(nil, nil)
 	mockRepo.On("GetTenantByDomain", mock.Anything, "acme.com").Return(expectedTenant, nil)
 
 	issuer := "https://test-issuer.com"
@@ -159,7 +297,8 @@ func TestRequireAuth_BearerToken_ExtractsTenant(t *testing.T) {
 
 func TestRequireAuth_BypassMode(t *testing.T) {
 	mockRepo := new(MockRepository)
-	mockRepo.On("GetTenantByDomain", mock.Anything, "localhost").Return(nil, fmt.Errorf("not found"))
+	mockRepo.On("ListTenantsForMember", mock.Anything, "dev@localhost").Return(nil, nil)
+	mockRepo.On("GetTenantByDomain", mock.Anything, "localhost").Return(nil, repository.ErrNotFound)
 	mockRepo.On("CreateTenant", mock.Anything, mock.MatchedBy(func(tenant *models.Tenant) bool {
 		return tenant.Domain == "localhost"
 	})).Run(func(args mock.Arguments) {
@@ -186,3 +325,210 @@ func TestRequireAuth_BypassMode(t *testing.T) {
 	a.RequireAuth(nextHandler).ServeHTTP(rec, req)
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
+
+func TestRequireAuth_AutoProvisionOff_RejectsUnknownDomain(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("ListTenantsForMember", mock.Anything, "dev@localhost").Return(nil, nil)
+	mockRepo.On("GetTenantByDomain", mock.Anything, "localhost").Return(nil, repository.ErrNotFound)
+
+	cfg := &config.Config{
+		Environment:   "DEV",
+		DevModeBypass: true,
+	}
+	cfg.Tenancy.AutoProvision = config.AutoProvisionOff
+	a, err := New(context.Background(), cfg, mockRepo, &NoOpLogger{})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/api/v1/workflows", nil)
+	rec := httptest.NewRecorder()
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not be reached")
+	})
+
+	a.RequireAuth(nextHandler).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	mockRepo.AssertNotCalled(t, "CreateTenant", mock.Anything, mock.Anything)
+}
+
+func TestRequireAuth_LookupFailure_DoesNotProvision(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("ListTenantsForMember", mock.Anything, "dev@localhost").Return(nil, nil)
+	mockRepo.On("GetTenantByDomain", mock.Anything, "localhost").Return(nil, fmt.Errorf("connection refused"))
+
+	cfg := &config.Config{
+		Environment:   "DEV",
+		DevModeBypass: true,
+	}
+	a, err := New(context.Background(), cfg, mockRepo, &NoOpLogger{})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/api/v1/workflows", nil)
+	rec := httptest.NewRecorder()
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not be reached")
+	})
+
+	a.RequireAuth(nextHandler).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	mockRepo.AssertNotCalled(t, "CreateTenant", mock.Anything, mock.Anything)
+}
+
+func TestRequireAuth_SuspendedTenant(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("ListTenantsForMember", mock.Anything, "dev@localhost").Return(nil, nil)
+	mockRepo.On("GetTenantByDomain", mock.Anything, "localhost").Return(&models.Tenant{
+		ID:     "suspended-tenant",
+		Domain: "localhost",
+		Status: models.TenantStatusSuspended,
+	}, nil)
+
+	cfg := &config.Config{
+		Environment:   "DEV",
+		DevModeBypass: true,
+	}
+	a, err := New(context.Background(), cfg, mockRepo, &NoOpLogger{})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/api/v1/workflows", nil)
+	rec := httptest.NewRecorder()
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not be reached")
+	})
+
+	a.RequireAuth(nextHandler).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestCanProvision(t *testing.T) {
+	allowlist := &Auth{
+		autoProvision:  config.AutoProvisionAllowlist,
+		allowedDomains: map[string]bool{"acme.com": true},
+	}
+	assert.True(t, allowlist.canProvision("acme.com"))
+	assert.False(t, allowlist.canProvision("evil.com"))
+
+	assert.True(t, (&Auth{}).canProvision("acme.com"))
+	assert.True(t, (&Auth{autoProvision: config.AutoProvisionOn}).canProvision("acme.com"))
+	assert.False(t, (&Auth{autoProvision: config.AutoProvisionOff}).canProvision("acme.com"))
+}
+
+func TestRequireAuth_MembershipTakesPrecedence(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("ListTenantsForMember", mock.Anything, "dev@localhost").Return([]*models.Tenant{
+		{ID: "member-tenant", Status: models.TenantStatusActive},
+		{ID: "second-tenant", Status: models.TenantStatusActive},
+	}, nil)
+
+	cfg := &config.Config{
+		Environment:   "DEV",
+		DevModeBypass: true,
+	}
+	a, err := New(context.Background(), cfg, mockRepo, &NoOpLogger{})
+	assert.NoError(t, err)
+
+	var got string
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = contextutil.GetTenant(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Without a selection the oldest membership wins over the email domain
+	req := httptest.NewRequest("GET", "/api/v1/workflows", nil)
+	rec := httptest.NewRecorder()
+	a.RequireAuth(nextHandler).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "member-tenant", got)
+	mockRepo.AssertNotCalled(t, "GetTenantByDomain", mock.Anything, mock.Anything)
+
+	// The header switches between memberships
+	req = httptest.NewRequest("GET", "/api/v1/workflows", nil)
+	req.Header.Set(TenantHeader, "second-tenant")
+	rec = httptest.NewRecorder()
+	a.RequireAuth(nextHandler).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "second-tenant", got)
+}
+
+func TestRequireAuth_SwitchToForeignTenantForbidden(t *testing.T) {
+	mockRepo := new(MockRepository)
+	mockRepo.On("ListTenantsForMember", mock.Anything, "dev@localhost").Return(nil, nil)
+	mockRepo.On("GetTenantByDomain", mock.Anything, "localhost").Return(&models.Tenant{ID: "dev-tenant-id"}, nil)
+
+	cfg := &config.Config{
+		Environment:   "DEV",
+		DevModeBypass: true,
+	}
+	a, err := New(context.Background(), cfg, mockRepo, &NoOpLogger{})
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest("GET", "/api/v1/workflows", nil)
+	req.Header.Set(TenantHeader, "someone-elses-tenant")
+	rec := httptest.NewRecorder()
+
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not be reached")
+	})
+
+	a.RequireAuth(nextHandler).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestRequireAuth_APIKey(t *testing.T) {
+	secret, _, hash, err := apikey.Generate()
+	assert.NoError(t, err)
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetAPIKeyByHash", mock.Anything, hash).Return(&models.APIKey{
+		ID:       "key-1",
+		TenantID: "tenant-123",
+		Scopes:   []string{ScopeEvolveRead},
+	}, nil)
+	mockRepo.On("GetAPIKeyByHash", mock.Anything, mock.Anything).Return(nil, repository.ErrNotFound)
+	mockRepo.On("GetTenantByID", mock.Anything, "tenant-123").Return(&models.Tenant{ID: "tenant-123", Status: models.TenantStatusActive}, nil)
+
+	// No verifier is configured: API keys never reach the OIDC path.
+	a := &Auth{repo: mockRepo}
+	nextHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tenant-123", contextutil.GetTenant(r.Context()))
+		assert.True(t, contextutil.HasScope(r.Context(), ScopeEvolveRead))
+		assert.False(t, contextutil.HasScope(r.Context(), ScopeEvolveWrite))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("POST", "/mcp", nil)
+	req.Header.Set("Authorization", "Bearer "+secret)
+	rec := httptest.NewRecorder()
+	a.RequireAuth(nextHandler).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// A key cannot be used to switch tenants
+	req = httptest.NewRequest("POST", "/mcp", nil)
+	req.Header.Set("Authorization", "Bearer "+secret)
+	req.Header.Set(TenantHeader, "other-tenant")
+	rec = httptest.NewRecorder()
+	a.RequireAuth(nextHandler).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	req = httptest.NewRequest("POST", "/mcp", nil)
+	req.Header.Set("Authorization", "Bearer "+apikey.Prefix+"unknown")
+	rec = httptest.NewRecorder()
+	a.RequireAuth(nextHandler).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAuthenticateAPIKey_RevokedOrSuspended(t *testing.T) {
+	revokedAt := time.Now()
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetAPIKeyByHash", mock.Anything, apikey.Hash("emcp_revoked")).Return(&models.APIKey{TenantID: "t1", RevokedAt: &revokedAt}, nil)
+	mockRepo.On("GetAPIKeyByHash", mock.Anything, apikey.Hash("emcp_suspended")).Return(&models.APIKey{TenantID: "t2"}, nil)
+	mockRepo.On("GetTenantByID", mock.Anything, "t2").Return(&models.Tenant{ID: "t2", Status: models.TenantStatusSuspended}, nil)
+
+	_, _, err := AuthenticateAPIKey(context.Background(), mockRepo, "emcp_revoked")
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	_, _, err = AuthenticateAPIKey(context.Background(), mockRepo, "emcp_suspended")
+	assert.ErrorIs(t, err, ErrInvalidAPIKey)
+}
@@ -6,6 +6,9 @@ const (
 	ScopeEmail       = "email"
 	ScopeEvolveRead  = "evolve:read"
 	ScopeEvolveWrite = "evolve:write"
+	// ScopeEvolveAdmin grants tenant administration. It is deliberately not part
+	// of AllScopes and must be granted explicitly by the authorization server.
+	ScopeEvolveAdmin = "evolve:admin"
 )
 
 // AllScopes defines the full set of scopes used by the Swagger UI / Frontend
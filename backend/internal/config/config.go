@@ -2,11 +2,31 @@ package config
 
 import (
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+// Tenant auto-provisioning modes for Config.Tenancy.AutoProvision.
+const (
+	// AutoProvisionOn creates a tenant for any unknown email domain.
+	AutoProvisionOn = "on"
+	// AutoProvisionAllowlist creates tenants only for Tenancy.AllowedDomains.
+	AutoProvisionAllowlist = "allowlist"
+	// AutoProvisionOff requires every tenant to be onboarded by an administrator.
+	AutoProvisionOff = "off"
+)
+
+// MCP transport names for Config.MCP.Transports.
+const (
+	// MCPTransportSSE is the legacy HTTP+SSE transport on /mcp/sse and /mcp/message.
+	MCPTransportSSE = "sse"
+	// MCPTransportStreamableHTTP is the Streamable HTTP transport on /mcp.
+	MCPTransportStreamableHTTP = "streamable-http"
+)
+
 // Config holds the configuration for the application.
 type Config struct {
 	Environment   string `mapstructure:"environment"`
@@ -29,6 +49,31 @@ type Config struct {
 		SwaggerClientID string `mapstructure:"swagger_client_id"`
 		RedirectURL     string `mapstructure:"redirect_url"`
 	}
+	Tenancy struct {
+		AutoProvision  string   `mapstructure:"auto_provision"`
+		AllowedDomains []string `mapstructure:"allowed_domains"`
+	} `mapstructure:"tenancy"`
+	// Quotas are the per-tenant defaults; tenants may override them. Zero means unlimited.
+	Quotas struct {
+		MaxMemories           int     `mapstructure:"max_memories"`
+		EmbeddingsPerMinute   int     `mapstructure:"embeddings_per_minute"`
+		RESTRequestsPerSecond float64 `mapstructure:"rest_requests_per_second"`
+		MCPRequestsPerSecond  float64 `mapstructure:"mcp_requests_per_second"`
+	} `mapstructure:"quotas"`
+	MCP struct {
+		Transports         []string      `mapstructure:"transports"`
+		HeartbeatInterval  time.Duration `mapstructure:"heartbeat_interval"`
+		SessionIdleTimeout time.Duration `mapstructure:"session_idle_timeout"`
+		ReplayBufferSize   int           `mapstructure:"replay_buffer_size"`
+		// Stdio configures cmd/mcp-stdio. With RemoteURL set it proxies to that
+		// backend using APIKey; otherwise it opens the database itself and acts
+		// on the tenant of APIKey or, failing that, TenantID.
+		Stdio struct {
+			APIKey    string `mapstructure:"api_key"`
+			TenantID  string `mapstructure:"tenant_id"`
+			RemoteURL string `mapstructure:"remote_url"`
+		} `mapstructure:"stdio"`
+	} `mapstructure:"mcp"`
 }
 
 // LoadConfig loads the configuration from a file and the environment.
@@ -64,8 +109,8 @@ func LoadConfig(envPath string) (*Config, error) {
 	}
 
 	// ---> START DEBUGGING
-	fmt.Printf("[DEBUG] Viper 'ENVIRONMENT': %s\n", viper.GetString("ENVIRONMENT"))
-	fmt.Printf("[DEBUG] Viper 'DEV_MODE_BYPASS': %v\n", viper.GetBool("DEV_MODE_BYPASS"))
+	fmt.Fprintf(os.Stderr, "[DEBUG] Viper 'ENVIRONMENT': %s\n", viper.GetString("ENVIRONMENT"))
+	fmt.Fprintf(os.Stderr, "[DEBUG] Viper 'DEV_MODE_BYPASS': %v\n", viper.GetBool("DEV_MODE_BYPASS"))
 	// ---> END DEBUGGING
 
 	if env := viper.GetString("ENVIRONMENT"); env != "" {
@@ -76,8 +121,8 @@ func LoadConfig(envPath string) (*Config, error) {
 	}
 
 	// ---> START DEBUGGING
-	fmt.Printf("[DEBUG] Config struct 'Environment': %s\n", config.Environment)
-	fmt.Printf("[DEBUG] Config struct 'DevModeBypass': %v\n", config.DevModeBypass)
+	fmt.Fprintf(os.Stderr, "[DEBUG] Config struct 'Environment': %s\n", config.Environment)
+	fmt.Fprintf(os.Stderr, "[DEBUG] Config struct 'DevModeBypass': %v\n", config.DevModeBypass)
 	// ---> END DEBUGGING
 
 	// env overrides (especially useful in containerized environments)
@@ -119,6 +164,48 @@ func LoadConfig(envPath string) (*Config, error) {
 		config.Auth.RedirectURL = r
 	}
 
+	if ap := viper.GetString("TENANCY_AUTO_PROVISION"); ap != "" {
+		config.Tenancy.AutoProvision = ap
+	}
+	if ad := viper.GetString("TENANCY_ALLOWED_DOMAINS"); ad != "" {
+		config.Tenancy.AllowedDomains = strings.Split(ad, ",")
+	}
+
+	if m := viper.GetInt("QUOTAS_MAX_MEMORIES"); m != 0 {
+		config.Quotas.MaxMemories = m
+	}
+	if e := viper.GetInt("QUOTAS_EMBEDDINGS_PER_MINUTE"); e != 0 {
+		config.Quotas.EmbeddingsPerMinute = e
+	}
+	if r := viper.GetFloat64("QUOTAS_REST_RPS"); r != 0 {
+		config.Quotas.RESTRequestsPerSecond = r
+	}
+	if r := viper.GetFloat64("QUOTAS_MCP_RPS"); r != 0 {
+		config.Quotas.MCPRequestsPerSecond = r
+	}
+
+	if t := viper.GetString("MCP_TRANSPORTS"); t != "" {
+		config.MCP.Transports = strings.Split(t, ",")
+	}
+	if h := viper.GetDuration("MCP_HEARTBEAT_INTERVAL"); h != 0 {
+		config.MCP.HeartbeatInterval = h
+	}
+	if i := viper.GetDuration("MCP_SESSION_IDLE_TIMEOUT"); i != 0 {
+		config.MCP.SessionIdleTimeout = i
+	}
+	if b := viper.GetInt("MCP_REPLAY_BUFFER_SIZE"); b != 0 {
+		config.MCP.ReplayBufferSize = b
+	}
+	if k := viper.GetString("MCP_API_KEY"); k != "" {
+		config.MCP.Stdio.APIKey = k
+	}
+	if t := viper.GetString("MCP_TENANT_ID"); t != "" {
+		config.MCP.Stdio.TenantID = t
+	}
+	if u := viper.GetString("MCP_REMOTE_URL"); u != "" {
+		config.MCP.Stdio.RemoteURL = u
+	}
+
 	// normalize OKTA issuer url (strip trailing slash if any)
 	config.Auth.OktaDomain = normalizeOktaIssuer(config.Auth.OktaDomain)
 
@@ -127,6 +214,39 @@ func LoadConfig(envPath string) (*Config, error) {
 		config.Auth.SwaggerClientID = config.Auth.ClientID
 	}
 
+	// Default to auto-provisioning so existing deployments keep their Day 1 experience
+	config.Tenancy.AutoProvision = strings.ToLower(strings.TrimSpace(config.Tenancy.AutoProvision))
+	switch config.Tenancy.AutoProvision {
+	case "":
+		config.Tenancy.AutoProvision = AutoProvisionOn
+	case AutoProvisionOn, AutoProvisionAllowlist, AutoProvisionOff:
+	default:
+		return nil, fmt.Errorf("invalid tenancy.auto_provision %q (want %s, %s or %s)",
+			config.Tenancy.AutoProvision, AutoProvisionOn, AutoProvisionAllowlist, AutoProvisionOff)
+	}
+	for i, d := range config.Tenancy.AllowedDomains {
+		config.Tenancy.AllowedDomains[i] = strings.ToLower(strings.TrimSpace(d))
+	}
+
+	// Serve both MCP transports unless configured otherwise so existing SSE
+	// clients keep working while newer clients move to Streamable HTTP.
+	if len(config.MCP.Transports) == 0 {
+		config.MCP.Transports = []string{MCPTransportSSE, MCPTransportStreamableHTTP}
+	}
+	for i, t := range config.MCP.Transports {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != MCPTransportSSE && t != MCPTransportStreamableHTTP {
+			return nil, fmt.Errorf("invalid mcp.transports entry %q (want %s or %s)", t, MCPTransportSSE, MCPTransportStreamableHTTP)
+		}
+		config.MCP.Transports[i] = t
+	}
+	if config.MCP.HeartbeatInterval == 0 {
+		config.MCP.HeartbeatInterval = 30 * time.Second
+	}
+	if config.MCP.SessionIdleTimeout == 0 {
+		config.MCP.SessionIdleTimeout = time.Hour
+	}
+
 	return &config, nil
 }
 
@@ -9,6 +9,7 @@ type contextKey string
 const (
 	tenantIDKey contextKey = "tenant_id"
 	userIDKey   contextKey = "user_id"
+	scopesKey   contextKey = "scopes"
 )
 
 // WithTenant returns a new context with the tenant ID attached.
@@ -32,3 +33,24 @@ func GetUser(ctx context.Context) string {
 	val, _ := ctx.Value(userIDKey).(string)
 	return val
 }
+
+// WithScopes returns a new context with the caller's granted OAuth scopes attached.
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey, scopes)
+}
+
+// GetScopes extracts the caller's granted OAuth scopes from the context.
+func GetScopes(ctx context.Context) []string {
+	val, _ := ctx.Value(scopesKey).([]string)
+	return val
+}
+
+// HasScope reports whether the caller was granted the given scope.
+func HasScope(ctx context.Context, scope string) bool {
+	for _, s := range GetScopes(ctx) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+// Package events is an in-process bus for changes to tenant data. Services
+// and REST handlers publish to it; the MCP server subscribes to notify
+// connected clients.
+package events
+
+import "sync"
+
+// Kind says what happened to a resource.
+type Kind string
+
+const (
+	Created Kind = "created"
+	Updated Kind = "updated"
+	Deleted Kind = "deleted"
+)
+
+// Event reports a change to a memory, grounding rule or workflow version.
+type Event struct {
+	Kind Kind `json:"kind"`
+	// URI identifies the resource, e.g. models.MemoryURI(id).
+	URI string `json:"uri"`
+	// TenantID owns the resource. Events about global resources concern
+	// every tenant.
+	TenantID string `json:"tenant_id"`
+	Global   bool   `json:"global,omitempty"`
+}
+
+// Concerns reports whether tenantID may learn about the event.
+func (e Event) Concerns(tenantID string) bool {
+	return e.Global || (tenantID != "" && e.TenantID == tenantID)
+}
+
+// Handler receives published events. It runs on the publisher's goroutine,
+// so it must not block.
+type Handler func(Event)
+
+// Bus delivers every published event to every subscribed handler. The zero
+// value is not usable; create buses with NewBus. A nil *Bus drops events.
+type Bus struct {
+	mu       sync.RWMutex
+	next     int
+	handlers map[int]Handler
+}
+
+// NewBus creates an empty bus.
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[int]Handler)}
+}
+
+// Publish delivers event to the current subscribers.
+func (b *Bus) Publish(event Event) {
+	if b == nil {
+		return
+	}
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers))
+	for _, h := range b.handlers {
+		handlers = append(handlers, h)
+	}
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(event)
+	}
+}
+
+// Subscribe registers h and returns a function that unregisters it.
+func (b *Bus) Subscribe(h Handler) (unsubscribe func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.next
+	b.next++
+	b.handlers[id] = h
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}
+}
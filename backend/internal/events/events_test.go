@@ -0,0 +1,34 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_PublishesToSubscribers(t *testing.T) {
+	bus := NewBus()
+	var got []Event
+	unsubscribe := bus.Subscribe(func(e Event) { got = append(got, e) })
+
+	bus.Publish(Event{Kind: Created, URI: "memory://m1", TenantID: "acme"})
+	unsubscribe()
+	bus.Publish(Event{Kind: Deleted, URI: "memory://m1", TenantID: "acme"})
+
+	assert.Equal(t, []Event{{Kind: Created, URI: "memory://m1", TenantID: "acme"}}, got)
+}
+
+func TestBus_NilDropsEvents(t *testing.T) {
+	var bus *Bus
+	assert.NotPanics(t, func() { bus.Publish(Event{Kind: Updated}) })
+}
+
+func TestEvent_Concerns(t *testing.T) {
+	event := Event{Kind: Updated, URI: "grounding://r1", TenantID: "acme"}
+	assert.True(t, event.Concerns("acme"))
+	assert.False(t, event.Concerns("globex"))
+	assert.False(t, event.Concerns(""))
+
+	event.Global = true
+	assert.True(t, event.Concerns("globex"))
+}
@@ -1,6 +1,7 @@
 package logging
 
 import (
+	"io"
 	"log"
 	"os"
 )
@@ -12,8 +13,13 @@ type Logger struct {
 
 // NewLogger creates a new Logger.
 func NewLogger() *Logger {
+	return NewLoggerTo(os.Stdout)
+}
+
+// NewLoggerTo creates a new Logger writing to w.
+func NewLoggerTo(w io.Writer) *Logger {
 	return &Logger{
-		Logger: log.New(os.Stdout, "", log.LstdFlags),
+		Logger: log.New(w, "", log.LstdFlags),
 	}
 }
 
@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/internal/services"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// BatchItemResult is the outcome of one item of a batch tool call. Exactly
+// one of Memory and Error is set.
+type BatchItemResult struct {
+	Index  int                `json:"index"`
+	Memory *repository.Memory `json:"memory,omitempty"`
+	Error  *ToolError         `json:"error,omitempty"`
+}
+
+// BatchResult is the output of the remember_batch and give_feedback_batch
+// tools, with one result per input item in input order.
+type BatchResult struct {
+	Results   []BatchItemResult `json:"results"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+}
+
+func (s *Server) registerBatchTools() {
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"remember_batch",
+			mcp.WithDescription(fmt.Sprintf("Create several semantic memories at once, e.g. at the end of a conversation. At most %d per call", services.MaxBatchSize)),
+			mcp.WithArray("contents",
+				mcp.Required(),
+				mcp.Description("The content of each memory"),
+				mcp.WithStringItems(),
+				mcp.MinItems(1),
+				mcp.MaxItems(services.MaxBatchSize),
+			),
+			mcp.WithOutputSchema[BatchResult](),
+		),
+		s.handleRememberBatch,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"give_feedback_batch",
+			mcp.WithDescription(fmt.Sprintf("Set the confidence of several memories at once, e.g. of everything recalled for a task. At most %d per call", services.MaxBatchSize)),
+			mcp.WithArray("items",
+				mcp.Required(),
+				mcp.Description("The memories to rate"),
+				mcp.Items(map[string]any{
+					"type": "object",
+					"properties": map[string]any{
+						"id":         map[string]any{"type": "string", "description": "The ID of the memory"},
+						"confidence": map[string]any{"type": "number", "minimum": 0, "maximum": 1, "description": "The new confidence score (0.0 to 1.0)"},
+					},
+					"required": []string{"id", "confidence"},
+				}),
+				mcp.MinItems(1),
+				mcp.MaxItems(services.MaxBatchSize),
+			),
+			mcp.WithOutputSchema[BatchResult](),
+		),
+		s.handleGiveFeedbackBatch,
+	)
+}
+
+func (s *Server) handleRememberBatch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	contents, err := request.RequireStringSlice("contents")
+	if err != nil {
+		return missingParameter("contents"), nil
+	}
+
+	results, err := s.memoryService.RememberBatch(ctx, contents, services.SourceMCP)
+	if err != nil {
+		return toolError("Failed to remember", err), nil
+	}
+
+	batch := newBatchResult(results)
+	return structuredResult(batch, fmt.Sprintf("Remembered %d of %d memories.", batch.Succeeded, len(results))), nil
+}
+
+func (s *Server) handleGiveFeedbackBatch(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var args struct {
+		Items []struct {
+			ID         string   `json:"id"`
+			Confidence *float64 `json:"confidence"`
+		} `json:"items"`
+	}
+	if err := request.BindArguments(&args); err != nil {
+		return newToolError(ErrorCodeInvalidArgument, "Invalid parameter items: "+err.Error()), nil
+	}
+	if len(args.Items) == 0 {
+		return missingParameter("items"), nil
+	}
+	items := make([]services.FeedbackItem, len(args.Items))
+	for i, item := range args.Items {
+		if item.ID == "" || item.Confidence == nil {
+			return newToolError(ErrorCodeInvalidArgument, fmt.Sprintf("items[%d] needs an id and a confidence", i)), nil
+		}
+		items[i] = services.FeedbackItem{ID: item.ID, Confidence: *item.Confidence}
+	}
+
+	results, err := s.memoryService.GiveFeedbackBatch(ctx, items)
+	if err != nil {
+		return toolError("Failed to give feedback", err), nil
+	}
+
+	batch := newBatchResult(results)
+	return structuredResult(batch, fmt.Sprintf("Feedback applied to %d of %d memories.", batch.Succeeded, len(results))), nil
+}
+
+func newBatchResult(results []services.BatchResult) BatchResult {
+	batch := BatchResult{Results: make([]BatchItemResult, len(results))}
+	for i, r := range results {
+		batch.Results[i] = BatchItemResult{Index: i, Memory: r.Memory}
+		if r.Err != nil {
+			batch.Results[i].Error = &ToolError{Code: errorCode(r.Err), Message: r.Err.Error()}
+			batch.Failed++
+		} else {
+			batch.Succeeded++
+		}
+	}
+	return batch
+}
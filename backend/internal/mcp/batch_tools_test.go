@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/internal/services"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchTools_RememberBatch(t *testing.T) {
+	s, repo := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+
+	result := callTool(t, s, ctx, "remember_batch", map[string]any{"contents": []string{"likes tea", "", "works remotely"}})
+	require.False(t, result.IsError, toolText(result))
+	var batch BatchResult
+	structured(t, result, &batch)
+	assert.Equal(t, 2, batch.Succeeded)
+	assert.Equal(t, 1, batch.Failed)
+	require.Len(t, batch.Results, 3)
+	assert.Equal(t, "likes tea", batch.Results[0].Memory.Content)
+	assert.Contains(t, repo.memories, batch.Results[0].Memory.ID)
+	assert.Equal(t, services.SourceMCP, repo.memories[batch.Results[0].Memory.ID].Provenance["source"])
+	assert.Equal(t, ErrorCodeInvalidArgument, batch.Results[1].Error.Code)
+	assert.Nil(t, batch.Results[1].Memory)
+	assert.Equal(t, 2, batch.Results[2].Index)
+	assert.Contains(t, toolText(result), "Remembered 2 of 3 memories.")
+}
+
+func TestBatchTools_GiveFeedbackBatch(t *testing.T) {
+	s, repo := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+	ours, theirs := uuid.NewString(), uuid.NewString()
+	repo.memories[ours] = &repository.Memory{ID: ours, TenantID: "acme", Confidence: 0.5, Version: 1}
+	repo.memories[theirs] = &repository.Memory{ID: theirs, TenantID: "globex", Confidence: 0.5, Version: 1}
+
+	result := callTool(t, s, ctx, "give_feedback_batch", map[string]any{"items": []map[string]any{
+		{"id": ours, "confidence": 0.9},
+		{"id": theirs, "confidence": 0.1},
+		{"id": uuid.NewString(), "confidence": 0.1},
+		{"id": ours, "confidence": 2},
+	}})
+	require.False(t, result.IsError, toolText(result))
+	var batch BatchResult
+	structured(t, result, &batch)
+	require.Len(t, batch.Results, 4)
+	assert.Equal(t, 1, batch.Succeeded)
+	assert.Equal(t, 0.9, batch.Results[0].Memory.Confidence)
+	assert.Equal(t, ErrorCodeForbidden, batch.Results[1].Error.Code)
+	assert.Equal(t, ErrorCodeNotFound, batch.Results[2].Error.Code)
+	assert.Equal(t, ErrorCodeInvalidArgument, batch.Results[3].Error.Code)
+	assert.Equal(t, 0.9, repo.memories[ours].Confidence)
+	assert.Equal(t, 0.5, repo.memories[theirs].Confidence)
+}
+
+func TestBatchTools_RejectOversizedBatches(t *testing.T) {
+	s, _ := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+
+	contents := make([]string, 101)
+	for i := range contents {
+		contents[i] = "note"
+	}
+	result := callTool(t, s, ctx, "remember_batch", map[string]any{"contents": contents})
+	require.True(t, result.IsError)
+	var toolErr ToolError
+	structured(t, result, &toolErr)
+	assert.Equal(t, ErrorCodeInvalidArgument, toolErr.Code)
+
+	result = callTool(t, s, ctx, "give_feedback_batch", map[string]any{"items": []map[string]any{{"id": "m1"}}})
+	require.True(t, result.IsError)
+	structured(t, result, &toolErr)
+	assert.Equal(t, ErrorCodeInvalidArgument, toolErr.Code)
+}
@@ -0,0 +1,123 @@
+package mcp
+
+import (
+	"context"
+
+	"evolutionary-mcp/backend/internal/auth"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) registerGroundingTools() {
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"search_grounding_rules",
+			mcp.WithDescription("Find the active grounding rules most relevant to a natural language query"),
+			mcp.WithString("query", mcp.Required(), mcp.Description("What the rules should be about")),
+			mcp.WithReadOnlyHintAnnotation(true),
+		),
+		s.handleSearchGroundingRules,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"get_grounding_rule",
+			mcp.WithDescription("Get a grounding rule by ID, including its approval status"),
+			mcp.WithString("id", mcp.Required(), mcp.Description("The ID of the grounding rule")),
+			mcp.WithReadOnlyHintAnnotation(true),
+		),
+		s.handleGetGroundingRule,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"propose_grounding_rule",
+			mcp.WithDescription("Suggest a new grounding rule, or with rule_id a new version of an existing one. It stays pending, and is not applied, until a human approves it"),
+			mcp.WithString("name", mcp.Required(), mcp.Description("A short name for the rule")),
+			mcp.WithString("content", mcp.Required(), mcp.Description("The constraint the rule expresses")),
+			mcp.WithString("workflow_id", mcp.Description("The ID of the workflow version the rule applies to, if it is workflow specific")),
+			mcp.WithString("rule_id", mcp.Description("The ID of the rule to change, to propose a new version of it")),
+		),
+		s.handleProposeGroundingRule,
+	)
+}
+
+func (s *Server) handleSearchGroundingRules(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if denied := requireScope(ctx, auth.ScopeEvolveRead); denied != nil {
+		return denied, nil
+	}
+
+	query, err := request.RequireString("query")
+	if err != nil || query == "" {
+		return missingParameter("query"), nil
+	}
+
+	rules, err := s.memoryService.SearchGroundingRules(ctx, query)
+	if err != nil {
+		return toolError("Failed to search grounding rules", err), nil
+	}
+
+	return jsonResult(rules), nil
+}
+
+func (s *Server) handleGetGroundingRule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if denied := requireScope(ctx, auth.ScopeEvolveRead); denied != nil {
+		return denied, nil
+	}
+
+	id, err := request.RequireString("id")
+	if err != nil || id == "" {
+		return missingParameter("id"), nil
+	}
+
+	rule, err := s.memoryService.GetGroundingRule(ctx, id)
+	if err != nil {
+		return toolError("Failed to get grounding rule", err), nil
+	}
+
+	return jsonResult(rule), nil
+}
+
+func (s *Server) handleProposeGroundingRule(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if denied := requireScope(ctx, auth.ScopeEvolveWrite); denied != nil {
+		return denied, nil
+	}
+
+	name, err := request.RequireString("name")
+	if err != nil || name == "" {
+		return missingParameter("name"), nil
+	}
+	content, err := request.RequireString("content")
+	if err != nil || content == "" {
+		return missingParameter("content"), nil
+	}
+
+	proposal := &models.GroundingRule{Name: name, Content: content}
+	if workflowID := request.GetString("workflow_id", ""); workflowID != "" {
+		proposal.WorkflowID = &workflowID
+	}
+
+	if ruleID := request.GetString("rule_id", ""); ruleID != "" {
+		// A new version keeps the scope of the rule, and its workflow unless
+		// another is given.
+		current, err := s.memoryService.GetGroundingRule(ctx, ruleID)
+		if err != nil {
+			return toolError("Failed to propose grounding rule version", err), nil
+		}
+		if proposal.WorkflowID == nil {
+			proposal.WorkflowID = current.WorkflowID
+		}
+		version, err := s.memoryService.ProposeGroundingRuleChange(ctx, ruleID, proposal, 0, false)
+		if err != nil {
+			return toolError("Failed to propose grounding rule version", err), nil
+		}
+		return jsonResult(version), nil
+	}
+
+	rule, err := s.memoryService.ProposeGroundingRule(ctx, proposal)
+	if err != nil {
+		return toolError("Failed to propose grounding rule", err), nil
+	}
+
+	return jsonResult(rule), nil
+}
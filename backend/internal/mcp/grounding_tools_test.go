@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/auth"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroundingTools_ProposeSearchAndGet(t *testing.T) {
+	s, repo := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+	ctx = contextutil.WithUser(ctx, "apikey:42")
+	ctx = contextutil.WithScopes(ctx, []string{auth.ScopeEvolveRead, auth.ScopeEvolveWrite})
+
+	result := callTool(t, s, ctx, "propose_grounding_rule", map[string]any{
+		"name":        "no refunds over 100",
+		"content":     "Escalate refunds above 100 EUR to a human.",
+		"workflow_id": "w2",
+	})
+	require.False(t, result.IsError, toolText(result))
+
+	var proposal models.GroundingRule
+	require.NoError(t, json.Unmarshal([]byte(toolText(result)), &proposal))
+	assert.Equal(t, models.GroundingRuleStatusPending, proposal.Status)
+	assert.Equal(t, "apikey:42", proposal.ProposedBy)
+	assert.NotEmpty(t, repo.rules[proposal.ID].Embedding)
+
+	// Pending rules are not applied until approved.
+	result = callTool(t, s, ctx, "search_grounding_rules", map[string]any{"query": "refunds"})
+	require.False(t, result.IsError, toolText(result))
+	assert.NotContains(t, toolText(result), proposal.ID)
+	assert.Contains(t, toolText(result), "cite sources")
+
+	result = callTool(t, s, ctx, "list_grounding_rules", nil)
+	assert.NotContains(t, toolText(result), proposal.ID)
+
+	result = callTool(t, s, ctx, "get_grounding_rule", map[string]any{"id": proposal.ID})
+	require.False(t, result.IsError, toolText(result))
+	assert.Contains(t, toolText(result), `"status":"pending"`)
+
+	result = callTool(t, s, ctx, "get_grounding_rule", map[string]any{"id": "missing"})
+	assert.True(t, result.IsError)
+}
+
+func TestGroundingTools_ProposeVersion(t *testing.T) {
+	s, repo := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+	ctx = contextutil.WithUser(ctx, "apikey:42")
+	ctx = contextutil.WithScopes(ctx, []string{auth.ScopeEvolveRead, auth.ScopeEvolveWrite})
+
+	result := callTool(t, s, ctx, "propose_grounding_rule", map[string]any{
+		"rule_id": "r1",
+		"name":    "cite sources",
+		"content": "Cite primary sources with links.",
+	})
+	require.False(t, result.IsError, toolText(result))
+
+	var version models.GroundingRuleVersion
+	require.NoError(t, json.Unmarshal([]byte(toolText(result)), &version))
+	assert.Equal(t, "r1", version.RuleID)
+	assert.Equal(t, models.GroundingRuleVersionPending, version.Status)
+	assert.Equal(t, "apikey:42", version.ProposedBy)
+	require.Len(t, repo.versions, 1)
+	assert.NotEmpty(t, repo.versions[0].Embedding)
+	// The rule in effect is unchanged until a curator approves the version.
+	assert.Empty(t, repo.rules["r1"].Content)
+
+	// Global rules of other tenants are not theirs to change.
+	result = callTool(t, s, ctx, "propose_grounding_rule", map[string]any{"rule_id": "r2", "name": "shared", "content": "Changed"})
+	assert.True(t, result.IsError)
+	assert.Len(t, repo.versions, 1)
+}
+
+func TestGroundingTools_ProposeValidatesInput(t *testing.T) {
+	s, _ := newTestServer()
+	ctx := contextutil.WithScopes(contextutil.WithTenant(context.Background(), "acme"), []string{auth.ScopeEvolveRead})
+
+	result := callTool(t, s, ctx, "propose_grounding_rule", map[string]any{"name": "x", "content": "y"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, toolText(result), auth.ScopeEvolveWrite)
+
+	ctx = contextutil.WithScopes(ctx, []string{auth.ScopeEvolveWrite})
+	result = callTool(t, s, ctx, "propose_grounding_rule", map[string]any{"name": "x", "content": "y", "workflow_id": "not-ours"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, toolText(result), "unknown workflow")
+}
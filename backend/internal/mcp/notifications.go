@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	methodNotificationMessage = "notifications/message"
+
+	// loggerName names the server in notifications/message.
+	loggerName = "evolutionary-memory"
+)
+
+// Notifications forwards change events to the MCP sessions of the tenant they
+// concern:
+//
+//   - sessions subscribed to the resource get notifications/resources/updated
+//     when it is updated or deleted,
+//   - every session of the tenant gets notifications/resources/list_changed
+//     when a resource is created or deleted,
+//   - every session of the tenant that set its log level to info or lower
+//     gets a notifications/message describing the change.
+type Notifications struct {
+	mcpServer     *server.MCPServer
+	subscriptions *Subscriptions
+
+	mu       sync.Mutex
+	sessions map[string]*sessionState // session ID -> state
+}
+
+type sessionState struct {
+	tenantID string
+	logLevel mcp.LoggingLevel
+}
+
+func newNotifications(mcpServer *server.MCPServer, subscriptions *Subscriptions) *Notifications {
+	return &Notifications{
+		mcpServer:     mcpServer,
+		subscriptions: subscriptions,
+		sessions:      make(map[string]*sessionState),
+	}
+}
+
+// track records the tenant of the session a request came from. Sessions
+// start out at the error log level, as in the MCP library.
+func (n *Notifications) track(sessionID, tenantID string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if state, ok := n.sessions[sessionID]; ok {
+		state.tenantID = tenantID
+		return
+	}
+	n.sessions[sessionID] = &sessionState{tenantID: tenantID, logLevel: mcp.LoggingLevelError}
+}
+
+func (n *Notifications) setLogLevel(sessionID string, level mcp.LoggingLevel) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if state, ok := n.sessions[sessionID]; ok {
+		state.logLevel = level
+	}
+}
+
+func (n *Notifications) forget(sessionID string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.sessions, sessionID)
+}
+
+// Handle notifies the sessions event concerns. It never blocks.
+func (n *Notifications) Handle(event events.Event) {
+	if event.Kind == events.Updated || event.Kind == events.Deleted {
+		n.subscriptions.ResourceUpdated(event.URI)
+	}
+	if event.Kind == events.Deleted {
+		n.subscriptions.drop(event.URI)
+	}
+
+	n.mu.Lock()
+	type recipient struct {
+		sessionID string
+		log       bool
+	}
+	var recipients []recipient
+	for id, state := range n.sessions {
+		if event.Concerns(state.tenantID) {
+			recipients = append(recipients, recipient{id, mcp.LoggingLevelInfo.ShouldSendTo(state.logLevel)})
+		}
+	}
+	n.mu.Unlock()
+
+	for _, r := range recipients {
+		var err error
+		if event.Kind == events.Created || event.Kind == events.Deleted {
+			err = n.mcpServer.SendNotificationToSpecificClient(r.sessionID, mcp.MethodNotificationResourcesListChanged, nil)
+		}
+		if err == nil && r.log {
+			err = n.mcpServer.SendNotificationToSpecificClient(r.sessionID, methodNotificationMessage, map[string]any{
+				"level":  mcp.LoggingLevelInfo,
+				"logger": loggerName,
+				"data":   event,
+			})
+		}
+		if errors.Is(err, server.ErrSessionNotFound) {
+			n.forget(r.sessionID)
+		}
+	}
+}
+
+// Notify publishes event to the connected clients it concerns. Subscribe it
+// to the event bus.
+func (s *Server) Notify(event events.Event) {
+	s.notifications.Handle(event)
+}
+
+// addNotificationHooks keeps track of the tenant and log level of every
+// session.
+func (s *Server) addNotificationHooks(hooks *server.Hooks) {
+	hooks.AddBeforeAny(func(ctx context.Context, _ any, _ mcp.MCPMethod, _ any) {
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			s.notifications.track(session.SessionID(), contextutil.GetTenant(ctx))
+		}
+	})
+	hooks.AddAfterSetLevel(func(ctx context.Context, _ any, message *mcp.SetLevelRequest, _ *mcp.EmptyResult) {
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			s.notifications.setLogLevel(session.SessionID(), message.Params.Level)
+		}
+	})
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		s.notifications.forget(session.SessionID())
+	})
+}
@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loggingSession is a fakeSession that accepts logging/setLevel.
+type loggingSession struct {
+	*fakeSession
+	level mcp.LoggingLevel
+}
+
+func (l *loggingSession) SetLogLevel(level mcp.LoggingLevel) { l.level = level }
+func (l *loggingSession) GetLogLevel() mcp.LoggingLevel      { return l.level }
+
+// connect registers a session for tenantID and sends it through a request so
+// the server learns its tenant.
+func connect(t *testing.T, s *Server, tenantID, sessionID string) (*loggingSession, context.Context) {
+	t.Helper()
+	session := &loggingSession{fakeSession: &fakeSession{id: sessionID, notifications: make(chan mcp.JSONRPCNotification, 10)}}
+	ctx := contextutil.WithTenant(context.Background(), tenantID)
+	require.NoError(t, s.GetMCPServer().RegisterSession(ctx, session))
+	ctx = s.GetMCPServer().WithContext(ctx, session)
+	s.GetMCPServer().HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+	return session, ctx
+}
+
+func drain(ch chan mcp.JSONRPCNotification) []mcp.JSONRPCNotification {
+	var got []mcp.JSONRPCNotification
+	for {
+		select {
+		case n := <-ch:
+			got = append(got, n)
+		default:
+			return got
+		}
+	}
+}
+
+func TestNotifications_ScopedToTenant(t *testing.T) {
+	s, _ := newTestServer()
+	acme, _ := connect(t, s, "acme", "acme-session")
+	globex, _ := connect(t, s, "globex", "globex-session")
+
+	s.Notify(events.Event{Kind: events.Created, URI: "memory://m3", TenantID: "acme"})
+
+	got := drain(acme.notifications)
+	require.Len(t, got, 1)
+	assert.Equal(t, mcp.MethodNotificationResourcesListChanged, got[0].Method)
+	assert.Empty(t, drain(globex.notifications))
+
+	// Global rules concern everyone.
+	s.Notify(events.Event{Kind: events.Deleted, URI: "grounding://r2", TenantID: "globex", Global: true})
+	assert.Len(t, drain(acme.notifications), 1)
+	assert.Len(t, drain(globex.notifications), 1)
+
+	// Updates only reach subscribers of the resource.
+	s.Notify(events.Event{Kind: events.Updated, URI: "memory://m1", TenantID: "acme"})
+	assert.Empty(t, drain(acme.notifications))
+}
+
+func TestNotifications_LogMessagesFollowLogLevel(t *testing.T) {
+	s, _ := newTestServer()
+	session, ctx := connect(t, s, "acme", "acme-session")
+
+	s.Notify(events.Event{Kind: events.Updated, URI: "memory://m1", TenantID: "acme"})
+	assert.Empty(t, drain(session.notifications), "no log messages before logging/setLevel")
+
+	s.GetMCPServer().HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":2,"method":"logging/setLevel","params":{"level":"info"}}`))
+	s.Notify(events.Event{Kind: events.Updated, URI: "memory://m1", TenantID: "acme"})
+
+	got := drain(session.notifications)
+	require.Len(t, got, 1)
+	assert.Equal(t, "notifications/message", got[0].Method)
+	assert.Equal(t, mcp.LoggingLevelInfo, got[0].Params.AdditionalFields["level"])
+	assert.Equal(t, events.Event{Kind: events.Updated, URI: "memory://m1", TenantID: "acme"}, got[0].Params.AdditionalFields["data"])
+}
+
+func TestNotifications_DeleteDropsSubscriptions(t *testing.T) {
+	s, _ := newTestServer()
+	session, sessionCtx := connect(t, s, "acme", "acme-session")
+	_, ok := s.Subscriptions().Intercept(sessionCtx, session.id, []byte(`{"jsonrpc":"2.0","id":3,"method":"resources/subscribe","params":{"uri":"memory://m1"}}`))
+	require.True(t, ok)
+
+	s.Notify(events.Event{Kind: events.Deleted, URI: "memory://m1", TenantID: "acme"})
+
+	methods := []string{}
+	for _, n := range drain(session.notifications) {
+		methods = append(methods, n.Method)
+	}
+	assert.Equal(t, []string{mcp.MethodNotificationResourceUpdated, mcp.MethodNotificationResourcesListChanged}, methods)
+	assert.Empty(t, s.Subscriptions().sessions)
+}
+
+var _ server.SessionWithLogging = (*loggingSession)(nil)
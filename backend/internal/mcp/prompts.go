@@ -0,0 +1,196 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/quota"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// citationInstructions tells the model how to cite the context it is given.
+const citationInstructions = "Follow the grounding rules below and use the memories where they are relevant. " +
+	"Cite the URI of every rule or memory you rely on in square brackets, e.g. [memory://<id>]. " +
+	"If the context does not cover the question, say so instead of guessing."
+
+func (s *Server) registerPrompts() {
+	s.mcpServer.AddPrompt(
+		mcp.NewPrompt(
+			"grounded_answer",
+			mcp.WithPromptDescription("Answer a question using the tenant's grounding rules and recalled memories, with citations"),
+			mcp.WithArgument("query", mcp.RequiredArgument(), mcp.ArgumentDescription("The question to answer")),
+			mcp.WithArgument("workflow_id", mcp.ArgumentDescription("Restrict workflow-specific rules and memories to the latest version of this workflow")),
+		),
+		s.handleGroundedAnswer,
+	)
+
+	s.mcpServer.AddPrompt(
+		mcp.NewPrompt(
+			"workflow_step_context",
+			mcp.WithPromptDescription("Context for executing a workflow step: its definition plus the grounding rules and memories that apply to it"),
+			mcp.WithArgument("workflow_id", mcp.RequiredArgument(), mcp.ArgumentDescription("The stable ID of the workflow")),
+			mcp.WithArgument("version", mcp.ArgumentDescription("The workflow version; defaults to the latest")),
+			mcp.WithArgument("query", mcp.ArgumentDescription("What the step is about; defaults to the workflow's name and description")),
+		),
+		s.handleWorkflowStepContext,
+	)
+}
+
+func (s *Server) handleGroundedAnswer(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	ctx, err := s.promptContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query := strings.TrimSpace(request.Params.Arguments["query"])
+	if query == "" {
+		return nil, fmt.Errorf("missing required argument: query")
+	}
+
+	var workflow *models.Workflow
+	if workflowID := request.Params.Arguments["workflow_id"]; workflowID != "" {
+		workflow, err = s.workflowService.GetWorkflowVersion(ctx, workflowID, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load workflow %s: %w", workflowID, err)
+		}
+	}
+
+	rules, memories, err := s.groundedContext(ctx, query, workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcp.NewGetPromptResult(
+		"Grounded answer to: "+query,
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(formatContextPack(rules, memories))),
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(query)),
+		},
+	), nil
+}
+
+func (s *Server) handleWorkflowStepContext(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	ctx, err := s.promptContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	workflowID := request.Params.Arguments["workflow_id"]
+	if workflowID == "" {
+		return nil, fmt.Errorf("missing required argument: workflow_id")
+	}
+	version := 0
+	if v := request.Params.Arguments["version"]; v != "" {
+		version, err = strconv.Atoi(v)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("invalid version %q: must be a positive integer", v)
+		}
+	}
+
+	workflow, err := s.workflowService.GetWorkflowVersion(ctx, workflowID, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workflow %s: %w", workflowID, err)
+	}
+	definition, err := json.Marshal(workflow)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode workflow %s: %w", workflowID, err)
+	}
+
+	query := strings.TrimSpace(request.Params.Arguments["query"])
+	if query == "" {
+		query = strings.TrimSpace(workflow.Name + ": " + workflow.Description)
+	}
+	rules, memories, err := s.groundedContext(ctx, query, workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	uri := models.WorkflowURI(workflow.WorkflowID, workflow.Version)
+	intro := fmt.Sprintf("You are executing %s %q (%s). Its definition, including the input and output schemas, follows.",
+		workflow.ElementType, workflow.Name, uri)
+
+	return mcp.NewGetPromptResult(
+		fmt.Sprintf("Context for workflow %s v%d", workflow.Name, workflow.Version),
+		[]mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(intro)),
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewEmbeddedResource(mcp.TextResourceContents{
+				URI:      uri,
+				MIMEType: resourceMIMEType,
+				Text:     string(definition),
+			})),
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(formatContextPack(rules, memories))),
+		},
+	), nil
+}
+
+// promptContext resolves the caller's tenant and applies its MCP request rate,
+// which the MCP library only lets us hook for tools and resources.
+func (s *Server) promptContext(ctx context.Context) (context.Context, error) {
+	if err := requireTenant(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.quotas.AllowRequest(ctx, contextutil.GetTenant(ctx), quota.SurfaceMCP); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// groundedContext searches the grounding rules and recalls the memories
+// relevant to query. With a workflow, rules and memories tied to other
+// workflows are left out.
+func (s *Server) groundedContext(ctx context.Context, query string, workflow *models.Workflow) ([]*models.GroundingRule, []*repository.Memory, error) {
+	rules, err := s.memoryService.SearchGroundingRules(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to search grounding rules: %w", err)
+	}
+	memories, err := s.memoryService.Recall(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to recall memories: %w", err)
+	}
+	if workflow == nil {
+		return rules, memories, nil
+	}
+
+	applicableRules := make([]*models.GroundingRule, 0, len(rules))
+	for _, r := range rules {
+		if r.WorkflowID == nil || *r.WorkflowID == workflow.ID {
+			applicableRules = append(applicableRules, r)
+		}
+	}
+	applicableMemories := make([]*repository.Memory, 0, len(memories))
+	for _, m := range memories {
+		if m.WorkflowID == "" || m.WorkflowID == workflow.ID {
+			applicableMemories = append(applicableMemories, m)
+		}
+	}
+	return applicableRules, applicableMemories, nil
+}
+
+// formatContextPack renders rules and memories as a citable context block.
+func formatContextPack(rules []*models.GroundingRule, memories []*repository.Memory) string {
+	var b strings.Builder
+	b.WriteString(citationInstructions)
+
+	b.WriteString("\n\n## Grounding rules\n")
+	if len(rules) == 0 {
+		b.WriteString("(none)\n")
+	}
+	for _, r := range rules {
+		fmt.Fprintf(&b, "- [%s] %s: %s\n", models.GroundingRuleURI(r.ID), r.Name, r.Content)
+	}
+
+	b.WriteString("\n## Memories\n")
+	if len(memories) == 0 {
+		b.WriteString("(none)\n")
+	}
+	for _, m := range memories {
+		fmt.Fprintf(&b, "- [%s] (confidence %.2f) %s\n", models.MemoryURI(m.ID), m.Confidence, m.Content)
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/repository"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getPrompt(t *testing.T, s *Server, ctx context.Context, name string, args map[string]string) (*mcp.GetPromptResult, error) {
+	t.Helper()
+	msg, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "prompts/get",
+		"params":  map[string]any{"name": name, "arguments": args},
+	})
+	require.NoError(t, err)
+
+	switch resp := s.GetMCPServer().HandleMessage(ctx, msg).(type) {
+	case mcp.JSONRPCResponse:
+		result := resp.Result.(mcp.GetPromptResult)
+		return &result, nil
+	case mcp.JSONRPCError:
+		return nil, errors.New(resp.Error.Message)
+	default:
+		t.Fatalf("unexpected response %T", resp)
+		return nil, nil
+	}
+}
+
+func TestPrompts_GroundedAnswer(t *testing.T) {
+	s, _ := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+
+	result, err := getPrompt(t, s, ctx, "grounded_answer", map[string]string{"query": "which theme?"})
+	require.NoError(t, err)
+	require.Len(t, result.Messages, 2)
+
+	pack := result.Messages[0].Content.(mcp.TextContent).Text
+	assert.Contains(t, pack, "[grounding://r1] cite sources")
+	assert.Contains(t, pack, "[grounding://r2] shared")
+	assert.Contains(t, pack, "[memory://m1] (confidence 0.80) prefers dark mode")
+	assert.NotContains(t, pack, "memory://m2")
+	assert.Equal(t, "which theme?", result.Messages[1].Content.(mcp.TextContent).Text)
+
+	_, err = getPrompt(t, s, ctx, "grounded_answer", map[string]string{})
+	assert.Error(t, err)
+}
+
+func TestPrompts_WorkflowStepContext(t *testing.T) {
+	s, repo := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+	otherVersion := "w1"
+	repo.rules["r1"].WorkflowID = &otherVersion
+	repo.memories["m3"] = &repository.Memory{ID: "m3", TenantID: "acme", Content: "step tip", WorkflowID: "w2"}
+
+	result, err := getPrompt(t, s, ctx, "workflow_step_context", map[string]string{"workflow_id": "wf"})
+	require.NoError(t, err)
+	require.Len(t, result.Messages, 3)
+
+	definition := result.Messages[1].Content.(mcp.EmbeddedResource).Resource.(mcp.TextResourceContents)
+	assert.Equal(t, "workflow://wf/v2", definition.URI)
+	assert.Contains(t, definition.Text, "onboarding v2")
+
+	// r1 is tied to version 1, so it does not apply to the latest version.
+	pack := result.Messages[2].Content.(mcp.TextContent).Text
+	assert.NotContains(t, pack, "grounding://r1")
+	assert.Contains(t, pack, "[memory://m3]")
+
+	result, err = getPrompt(t, s, ctx, "workflow_step_context", map[string]string{"workflow_id": "wf", "version": "1"})
+	require.NoError(t, err)
+	assert.Contains(t, result.Messages[2].Content.(mcp.TextContent).Text, "grounding://r1")
+	assert.NotContains(t, result.Messages[2].Content.(mcp.TextContent).Text, "memory://m3")
+
+	_, err = getPrompt(t, s, ctx, "workflow_step_context", map[string]string{"workflow_id": "missing"})
+	assert.Error(t, err)
+	_, err = getPrompt(t, s, ctx, "workflow_step_context", map[string]string{"workflow_id": "wf", "version": "0"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,277 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// maxMessageSize bounds a single JSON-RPC message read from stdin.
+const maxMessageSize = 10 << 20
+
+// listenRetryDelay is how long the proxy waits before reopening a dropped
+// notification stream.
+const listenRetryDelay = time.Second
+
+// StdioProxy relays newline-delimited JSON-RPC messages between a stdio MCP
+// client and a remote Streamable HTTP endpoint, authenticating with an API
+// key. Server-initiated messages are received on a GET stream that is resumed
+// with Last-Event-ID when it drops.
+type StdioProxy struct {
+	endpoint string
+	apiKey   string
+	client   *http.Client
+	logger   Logger
+
+	outMu sync.Mutex
+	out   io.Writer
+
+	sessionMu sync.Mutex
+	sessionID string
+}
+
+// Logger defines the logging interface compatible with the application logger.
+type Logger interface {
+	Error(msg string, args ...any)
+}
+
+// NewStdioProxy creates a proxy to the MCP endpoint of a remote backend, for
+// example https://memory.example.com/mcp.
+func NewStdioProxy(endpoint, apiKey string, logger Logger) *StdioProxy {
+	return &StdioProxy{
+		endpoint: endpoint,
+		apiKey:   apiKey,
+		client:   &http.Client{},
+		logger:   logger,
+	}
+}
+
+// Run relays messages until in is exhausted or ctx is cancelled, then ends the
+// remote session.
+func (p *StdioProxy) Run(ctx context.Context, in io.Reader, out io.Writer) error {
+	p.out = out
+	ctx, cancel := context.WithCancel(ctx)
+	var listeners sync.WaitGroup
+	defer func() {
+		cancel()
+		listeners.Wait()
+	}()
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		hadSession := p.session() != ""
+		if err := p.forward(ctx, line); err != nil {
+			return err
+		}
+		if !hadSession && p.session() != "" {
+			listeners.Add(1)
+			go func() {
+				defer listeners.Done()
+				p.listen(ctx)
+			}()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read from stdin: %w", err)
+	}
+
+	p.terminate()
+	return nil
+}
+
+// forward posts one client message and relays the response.
+func (p *StdioProxy) forward(ctx context.Context, message []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, bytes.NewReader(message))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+	p.authorize(req)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		p.replyError(message, fmt.Sprintf("remote MCP server unreachable: %v", err))
+		return nil
+	}
+	defer resp.Body.Close()
+
+	if id := resp.Header.Get(server.HeaderKeySessionID); id != "" {
+		p.sessionMu.Lock()
+		p.sessionID = id
+		p.sessionMu.Unlock()
+	}
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		p.replyError(message, fmt.Sprintf("remote MCP server returned %s: %s", resp.Status, strings.TrimSpace(string(body))))
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType == "text/event-stream" {
+		return readSSE(resp.Body, func(_ string, data []byte) error { return p.write(data) })
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if body = bytes.TrimSpace(body); len(body) > 0 {
+		return p.write(body)
+	}
+	return nil
+}
+
+// listen relays server-initiated messages until ctx is cancelled or the
+// remote ends the session.
+func (p *StdioProxy) listen(ctx context.Context) {
+	lastEventID := ""
+	for ctx.Err() == nil {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint, nil)
+		if err != nil {
+			return
+		}
+		req.Header.Set("Accept", "text/event-stream")
+		if lastEventID != "" {
+			req.Header.Set(headerLastEventID, lastEventID)
+		}
+		p.authorize(req)
+
+		resp, err := p.client.Do(req)
+		if err == nil {
+			switch {
+			case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed:
+				// The session is gone or the server does not stream.
+				resp.Body.Close()
+				return
+			case resp.StatusCode == http.StatusOK:
+				err = readSSE(resp.Body, func(id string, data []byte) error {
+					if id != "" {
+						lastEventID = id
+					}
+					return p.write(data)
+				})
+			default:
+				err = fmt.Errorf("unexpected status %s", resp.Status)
+			}
+			resp.Body.Close()
+		}
+		if err != nil && ctx.Err() == nil && p.logger != nil {
+			p.logger.Error("MCP notification stream dropped", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+// terminate ends the remote session, if one was opened.
+func (p *StdioProxy) terminate() {
+	if p.session() == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, p.endpoint, nil)
+	if err != nil {
+		return
+	}
+	p.authorize(req)
+	if resp, err := p.client.Do(req); err == nil {
+		resp.Body.Close()
+	}
+}
+
+func (p *StdioProxy) authorize(req *http.Request) {
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+	if id := p.session(); id != "" {
+		req.Header.Set(server.HeaderKeySessionID, id)
+	}
+}
+
+func (p *StdioProxy) session() string {
+	p.sessionMu.Lock()
+	defer p.sessionMu.Unlock()
+	return p.sessionID
+}
+
+// write sends one message to the client on its own line.
+func (p *StdioProxy) write(message []byte) error {
+	p.outMu.Lock()
+	defer p.outMu.Unlock()
+	if _, err := p.out.Write(append(bytes.TrimSpace(message), '\n')); err != nil {
+		return fmt.Errorf("failed to write to stdout: %w", err)
+	}
+	return nil
+}
+
+// replyError answers a client request the remote could not handle so the
+// client does not wait forever. Notifications have no ID and get no reply.
+func (p *StdioProxy) replyError(message []byte, reason string) {
+	var req struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if json.Unmarshal(message, &req) != nil || len(req.ID) == 0 || string(req.ID) == "null" {
+		if p.logger != nil {
+			p.logger.Error("MCP message not delivered", "reason", reason)
+		}
+		return
+	}
+	reply, _ := json.Marshal(map[string]any{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      req.ID,
+		"error":   map[string]any{"code": mcp.INTERNAL_ERROR, "message": reason},
+	})
+	_ = p.write(reply)
+}
+
+// readSSE calls fn with the ID and data of each event in an SSE stream.
+func readSSE(r io.Reader, fn func(id string, data []byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+
+	var id string
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if data.Len() > 0 {
+				if err := fn(id, data.Bytes()); err != nil {
+					return err
+				}
+			}
+			id = ""
+			data.Reset()
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case strings.HasPrefix(line, "id:"):
+			id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,102 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdioProxy_RelaysToStreamableHTTP(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "1.0.0", server.WithToolCapabilities(true))
+	mcpServer.AddTool(mcp.NewTool("echo", mcp.WithString("text", mcp.Required())),
+		func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(request.GetString("text", "")), nil
+		})
+
+	mux := http.NewServeMux()
+	require.NoError(t, MountHTTPHandlers(mux, mcpServer, HTTPOptions{StreamableHTTP: true}))
+
+	var deletes atomic.Int32
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer emcp_test" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			deletes.Add(1)
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	defer remote.Close()
+
+	in := strings.NewReader(strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":"echo","arguments":{"text":"hello"}}}`,
+	}, "\n") + "\n")
+	var out bytes.Buffer
+
+	proxy := NewStdioProxy(remote.URL+"/mcp", "emcp_test", nil)
+	require.NoError(t, proxy.Run(context.Background(), in, &out))
+
+	var responses []map[string]any
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var msg map[string]any
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &msg))
+		responses = append(responses, msg)
+	}
+	require.Len(t, responses, 2)
+	assert.Equal(t, float64(1), responses[0]["id"])
+	assert.Contains(t, responses[0], "result")
+	assert.Equal(t, float64(2), responses[1]["id"])
+	assert.Contains(t, responses[1]["result"].(map[string]any)["content"].([]any)[0].(map[string]any)["text"], "hello")
+	assert.Equal(t, int32(1), deletes.Load())
+}
+
+func TestStdioProxy_RemoteErrorAnswersRequest(t *testing.T) {
+	remote := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid api key", http.StatusUnauthorized)
+	}))
+	defer remote.Close()
+
+	in := strings.NewReader(`{"jsonrpc":"2.0","id":"a","method":"ping"}` + "\n" + `{"jsonrpc":"2.0","method":"notifications/initialized"}` + "\n")
+	var out bytes.Buffer
+	require.NoError(t, NewStdioProxy(remote.URL, "emcp_wrong", nil).Run(context.Background(), in, &out))
+
+	var reply struct {
+		ID    string `json:"id"`
+		Error struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &reply))
+	assert.Equal(t, "a", reply.ID)
+	assert.Equal(t, mcp.INTERNAL_ERROR, reply.Error.Code)
+	assert.Contains(t, reply.Error.Message, "401")
+}
+
+func TestReadSSE(t *testing.T) {
+	stream := "id: 7\nevent: message\ndata: {\"a\":1}\n\n: comment\n\ndata: {\"b\":\ndata: 2}\n\n"
+	var ids []string
+	var data []string
+	require.NoError(t, readSSE(strings.NewReader(stream), func(id string, d []byte) error {
+		ids = append(ids, id)
+		data = append(data, string(d))
+		return nil
+	}))
+	assert.Equal(t, []string{"7", ""}, ids)
+	assert.Equal(t, []string{`{"a":1}`, "{\"b\":\n2}"}, data)
+}
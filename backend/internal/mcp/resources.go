@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/quota"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const resourceMIMEType = "application/json"
+
+func (s *Server) registerResources() {
+	s.mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			models.MemoryURIScheme+"://{id}",
+			"memory",
+			mcp.WithTemplateDescription("A semantic memory with its confidence, version and provenance"),
+			mcp.WithTemplateMIMEType(resourceMIMEType),
+		),
+		s.handleReadResource,
+	)
+
+	s.mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			models.GroundingRuleURIScheme+"://{id}",
+			"grounding_rule",
+			mcp.WithTemplateDescription("A grounding rule constraining how the agent reasons"),
+			mcp.WithTemplateMIMEType(resourceMIMEType),
+		),
+		s.handleReadResource,
+	)
+
+	s.mcpServer.AddResourceTemplate(
+		mcp.NewResourceTemplate(
+			models.WorkflowURIScheme+"://{workflow_id}/v{version}",
+			"workflow_version",
+			mcp.WithTemplateDescription("One version of a workflow definition, including its input and output schemas"),
+			mcp.WithTemplateMIMEType(resourceMIMEType),
+		),
+		s.handleReadResource,
+	)
+}
+
+// rateLimitResources rejects resource reads from tenants over their MCP
+// request rate.
+func (s *Server) rateLimitResources(next server.ResourceHandlerFunc) server.ResourceHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		if err := requireTenant(ctx); err != nil {
+			return nil, err
+		}
+		if err := s.quotas.AllowRequest(ctx, contextutil.GetTenant(ctx), quota.SurfaceMCP); err != nil {
+			return nil, err
+		}
+		return next(ctx, request)
+	}
+}
+
+func (s *Server) handleReadResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	resource, err := s.readResource(ctx, request.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonBytes, err := json.Marshal(resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", request.Params.URI, err)
+	}
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{
+			URI:      request.Params.URI,
+			MIMEType: resourceMIMEType,
+			Text:     string(jsonBytes),
+		},
+	}, nil
+}
+
+// readResource loads the memory, grounding rule or workflow version a
+// resource URI points to. Missing resources are reported as
+// server.ErrResourceNotFound.
+func (s *Server) readResource(ctx context.Context, uri string) (any, error) {
+	scheme, path, ok := strings.Cut(uri, "://")
+	if !ok || path == "" {
+		return nil, fmt.Errorf("%w: %s", server.ErrResourceNotFound, uri)
+	}
+
+	var resource any
+	var err error
+	switch scheme {
+	case models.MemoryURIScheme:
+		resource, err = s.memoryService.GetMemory(ctx, path)
+	case models.GroundingRuleURIScheme:
+		resource, err = s.memoryService.GetGroundingRule(ctx, path)
+	case models.WorkflowURIScheme:
+		workflowID, version, ok := parseWorkflowPath(path)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", server.ErrResourceNotFound, uri)
+		}
+		resource, err = s.workflowService.GetWorkflowVersion(ctx, workflowID, version)
+	default:
+		return nil, fmt.Errorf("%w: %s", server.ErrResourceNotFound, uri)
+	}
+	if errors.Is(err, pgx.ErrNoRows) || errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", server.ErrResourceNotFound, uri)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resource, nil
+}
+
+// parseWorkflowPath splits the "{workflow_id}/v{version}" part of a workflow
+// resource URI.
+func parseWorkflowPath(path string) (string, int, bool) {
+	workflowID, v, ok := strings.Cut(path, "/v")
+	if !ok || workflowID == "" {
+		return "", 0, false
+	}
+	version, err := strconv.Atoi(v)
+	if err != nil || version < 1 {
+		return "", 0, false
+	}
+	return workflowID, version, true
+}
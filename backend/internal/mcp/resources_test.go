@@ -0,0 +1,400 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/internal/services"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRepo serves a fixed set of records; methods the tests do not use panic
+// through the nil embedded interface.
+type fakeRepo struct {
+	repository.Repository
+	memories  map[string]*repository.Memory
+	rules     map[string]*models.GroundingRule
+	workflows []*models.Workflow
+	feedback  []*models.FeedbackEvent
+	runs      map[string]*models.WorkflowRun
+	versions  []*models.GroundingRuleVersion
+}
+
+func (f *fakeRepo) Get(_ context.Context, id string) (*repository.Memory, error) {
+	if m, ok := f.memories[id]; ok {
+		return m, nil
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (f *fakeRepo) Save(_ context.Context, memory *repository.Memory) error {
+	f.memories[memory.ID] = memory
+	return nil
+}
+
+func (f *fakeRepo) Update(_ context.Context, memory *repository.Memory) error {
+	f.memories[memory.ID] = memory
+	return nil
+}
+
+func (f *fakeRepo) RecordFeedback(_ context.Context, event *models.FeedbackEvent) error {
+	f.feedback = append(f.feedback, event)
+	return nil
+}
+
+func (f *fakeRepo) GetBatch(_ context.Context, ids []string) ([]*repository.Memory, error) {
+	var memories []*repository.Memory
+	for _, id := range ids {
+		if m, ok := f.memories[id]; ok {
+			memories = append(memories, m)
+		}
+	}
+	return memories, nil
+}
+
+func (f *fakeRepo) SaveBatch(_ context.Context, memories []*repository.Memory) error {
+	for _, m := range memories {
+		f.memories[m.ID] = m
+	}
+	return nil
+}
+
+func (f *fakeRepo) UpdateBatch(_ context.Context, memories []*repository.Memory) error {
+	return f.SaveBatch(context.Background(), memories)
+}
+
+func (f *fakeRepo) RecordFeedbackBatch(context.Context, []*models.FeedbackEvent) error {
+	return nil
+}
+
+func (f *fakeRepo) GetGroundingRule(_ context.Context, id string) (*models.GroundingRule, error) {
+	if r, ok := f.rules[id]; ok {
+		return r, nil
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (f *fakeRepo) Search(context.Context, []float32) ([]*repository.Memory, error) {
+	var memories []*repository.Memory
+	for _, m := range f.memories {
+		if m.TenantID == "acme" {
+			memories = append(memories, m)
+		}
+	}
+	return memories, nil
+}
+
+func (f *fakeRepo) RecordRecall(context.Context, *models.RecallEvent) error {
+	return nil
+}
+
+func (f *fakeRepo) SearchGroundingRules(_ context.Context, tenantID string, _ []float32) ([]*models.GroundingRule, error) {
+	var rules []*models.GroundingRule
+	for _, r := range f.rules {
+		if (r.TenantID == tenantID || r.IsGlobal) && r.Status != models.GroundingRuleStatusPending {
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+func (f *fakeRepo) ListGroundingRules(_ context.Context, tenantID string) ([]*models.GroundingRule, error) {
+	var rules []*models.GroundingRule
+	for _, r := range f.rules {
+		if r.TenantID == tenantID || r.IsGlobal {
+			rules = append(rules, r)
+		}
+	}
+	return rules, nil
+}
+
+func (f *fakeRepo) CreateGroundingRule(_ context.Context, rule *models.GroundingRule) error {
+	rule.ID = fmt.Sprintf("r%d", len(f.rules)+1)
+	f.rules[rule.ID] = rule
+	return nil
+}
+
+func (f *fakeRepo) CreateGroundingRuleVersion(_ context.Context, version *models.GroundingRuleVersion) error {
+	f.versions = append(f.versions, version)
+	version.Version = len(f.versions) + 1
+	version.Status = models.GroundingRuleVersionPending
+	return nil
+}
+
+func (f *fakeRepo) GetWorkflow(_ context.Context, id string) (*models.Workflow, error) {
+	for _, w := range f.workflows {
+		if w.ID == id {
+			return w, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (f *fakeRepo) ListWorkflows(context.Context) ([]*models.Workflow, error) {
+	latest := map[string]*models.Workflow{}
+	for _, w := range f.workflows {
+		if cur, ok := latest[w.WorkflowID]; !ok || w.Version > cur.Version {
+			latest[w.WorkflowID] = w
+		}
+	}
+	var workflows []*models.Workflow
+	for _, w := range latest {
+		workflows = append(workflows, w)
+	}
+	return workflows, nil
+}
+
+func (f *fakeRepo) GetWorkflowSubtree(_ context.Context, tenantID, id string) ([]*models.Workflow, error) {
+	root, err := f.GetWorkflow(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	if root.TenantID != tenantID {
+		return nil, repository.ErrNotFound
+	}
+	subtree := []*models.Workflow{root}
+	for i := 0; i < len(subtree); i++ {
+		for _, w := range f.workflows {
+			if w.ParentID != nil && *w.ParentID == subtree[i].ID {
+				subtree = append(subtree, w)
+			}
+		}
+	}
+	return subtree, nil
+}
+
+func (f *fakeRepo) CreateWorkflowRun(_ context.Context, run *models.WorkflowRun) error {
+	if f.runs == nil {
+		f.runs = map[string]*models.WorkflowRun{}
+	}
+	run.ID = fmt.Sprintf("run%d", len(f.runs)+1)
+	run.Status = models.WorkflowRunStatusRunning
+	f.runs[run.ID] = run
+	return nil
+}
+
+func (f *fakeRepo) GetWorkflowRun(_ context.Context, id string) (*models.WorkflowRun, error) {
+	if run, ok := f.runs[id]; ok {
+		return run, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (f *fakeRepo) UpdateWorkflowRun(_ context.Context, run *models.WorkflowRun) error {
+	f.runs[run.ID] = run
+	for _, id := range run.CreatedMemoryIDs {
+		f.memories[id].Provenance = map[string]interface{}{"workflow_run_id": run.ID}
+		f.memories[id].Version++
+	}
+	return nil
+}
+
+func (f *fakeRepo) CreateWorkflow(_ context.Context, workflow *models.Workflow) error {
+	for _, w := range f.workflows {
+		if w.WorkflowID == workflow.WorkflowID && w.Version >= workflow.Version {
+			workflow.Version = w.Version + 1
+		}
+	}
+	workflow.ID = fmt.Sprintf("%s-v%d", workflow.WorkflowID, workflow.Version)
+	f.workflows = append(f.workflows, workflow)
+	return nil
+}
+
+func (f *fakeRepo) GetWorkflowVersion(_ context.Context, tenantID, workflowID string, version int) (*models.Workflow, error) {
+	for _, w := range f.workflows {
+		if w.TenantID == tenantID && w.WorkflowID == workflowID && w.Version == version {
+			return w, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+type fakeML struct{}
+
+func (fakeML) GetEmbedding(context.Context, string) ([]float32, error) {
+	return []float32{1, 0}, nil
+}
+
+func (fakeML) GetEmbeddings(_ context.Context, texts []string) ([][]float32, error) {
+	embeddings := make([][]float32, len(texts))
+	for i := range texts {
+		embeddings[i] = []float32{1, 0}
+	}
+	return embeddings, nil
+}
+
+// fakeSession is an initialized client session that collects notifications.
+type fakeSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func (f *fakeSession) Initialize()       {}
+func (f *fakeSession) Initialized() bool { return true }
+func (f *fakeSession) SessionID() string { return f.id }
+func (f *fakeSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return f.notifications
+}
+
+func newTestServer() (*Server, *fakeRepo) {
+	repo := &fakeRepo{
+		memories: map[string]*repository.Memory{
+			"m1": {ID: "m1", TenantID: "acme", Content: "prefers dark mode", Confidence: 0.8, Version: 1},
+			"m2": {ID: "m2", TenantID: "globex", Content: "secret"},
+		},
+		rules: map[string]*models.GroundingRule{
+			"r1": {ID: "r1", TenantID: "acme", Name: "cite sources"},
+			"r2": {ID: "r2", TenantID: "globex", Name: "shared", IsGlobal: true},
+		},
+		workflows: []*models.Workflow{
+			{ID: "w1", WorkflowID: "wf", Version: 1, TenantID: "acme", Name: "onboarding"},
+			{ID: "w2", WorkflowID: "wf", Version: 2, TenantID: "acme", Name: "onboarding v2"},
+		},
+	}
+	return NewServer(services.NewMemoryService(repo, fakeML{}), services.NewWorkflowService(repo), nil), repo
+}
+
+func readResource(t *testing.T, s *Server, ctx context.Context, uri string) mcp.JSONRPCMessage {
+	t.Helper()
+	msg, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "resources/read",
+		"params":  map[string]any{"uri": uri},
+	})
+	require.NoError(t, err)
+	return s.GetMCPServer().HandleMessage(ctx, msg)
+}
+
+func TestResources_Read(t *testing.T) {
+	s, _ := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+
+	for uri, want := range map[string]string{
+		"memory://m1":      "prefers dark mode",
+		"grounding://r1":   "cite sources",
+		"grounding://r2":   "shared",
+		"workflow://wf/v2": "onboarding v2",
+	} {
+		resp, ok := readResource(t, s, ctx, uri).(mcp.JSONRPCResponse)
+		require.True(t, ok, uri)
+		result := resp.Result.(mcp.ReadResourceResult)
+		require.Len(t, result.Contents, 1)
+		contents := result.Contents[0].(mcp.TextResourceContents)
+		assert.Equal(t, uri, contents.URI)
+		assert.Equal(t, "application/json", contents.MIMEType)
+		assert.Contains(t, contents.Text, want)
+	}
+
+	for _, uri := range []string{"memory://missing", "memory://m2", "workflow://wf/v3", "workflow://wf/latest"} {
+		_, ok := readResource(t, s, ctx, uri).(mcp.JSONRPCError)
+		assert.True(t, ok, uri)
+	}
+}
+
+func TestResources_ListTemplates(t *testing.T) {
+	s, _ := newTestServer()
+	resp := s.GetMCPServer().HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/templates/list"}`))
+
+	result := resp.(mcp.JSONRPCResponse).Result.(mcp.ListResourceTemplatesResult)
+	var templates []string
+	for _, tmpl := range result.ResourceTemplates {
+		templates = append(templates, tmpl.URITemplate.Raw())
+	}
+	assert.ElementsMatch(t, []string{"memory://{id}", "grounding://{id}", "workflow://{workflow_id}/v{version}"}, templates)
+}
+
+func TestSubscriptions_NotifiesSubscribedSessions(t *testing.T) {
+	s, _ := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+	session := &fakeSession{id: "session-1", notifications: make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, s.GetMCPServer().RegisterSession(ctx, session))
+	subs := s.Subscriptions()
+
+	reply, ok := subs.Intercept(ctx, session.id, []byte(`{"jsonrpc":"2.0","id":7,"method":"resources/subscribe","params":{"uri":"grounding://r1"}}`))
+	require.True(t, ok)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":7,"result":{}}`, string(reply))
+
+	// Other messages are left to the MCP server.
+	_, ok = subs.Intercept(ctx, session.id, []byte(`{"jsonrpc":"2.0","id":8,"method":"ping"}`))
+	assert.False(t, ok)
+
+	subs.ResourceUpdated("grounding://other")
+	subs.ResourceUpdated("grounding://r1")
+	select {
+	case n := <-session.notifications:
+		assert.Equal(t, mcp.MethodNotificationResourceUpdated, n.Method)
+		assert.Equal(t, "grounding://r1", n.Params.AdditionalFields["uri"])
+	case <-time.After(time.Second):
+		t.Fatal("no notification sent")
+	}
+	assert.Empty(t, session.notifications)
+
+	_, ok = subs.Intercept(ctx, session.id, []byte(`{"jsonrpc":"2.0","id":9,"method":"resources/unsubscribe","params":{"uri":"grounding://r1"}}`))
+	require.True(t, ok)
+	subs.ResourceUpdated("grounding://r1")
+	assert.Empty(t, session.notifications)
+}
+
+func TestSubscriptions_RejectsUnreadableResources(t *testing.T) {
+	s, _ := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+
+	reply, ok := s.Subscriptions().Intercept(ctx, "session-1", []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"memory://missing"}}`))
+	require.True(t, ok)
+	var resp struct {
+		Error struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(reply, &resp))
+	assert.Equal(t, mcp.RESOURCE_NOT_FOUND, resp.Error.Code)
+
+	// Another tenant's memory cannot be watched either.
+	reply, ok = s.Subscriptions().Intercept(ctx, "session-1", []byte(`{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"memory://m2"}}`))
+	require.True(t, ok)
+	assert.Contains(t, string(reply), `"error"`)
+}
+
+func TestSubscriptions_FeedbackNotifiesMemorySubscribers(t *testing.T) {
+	repo := &fakeRepo{memories: map[string]*repository.Memory{
+		"m1": {ID: "m1", TenantID: "acme", Confidence: 0.5, Version: 1},
+	}}
+	memoryService := services.NewMemoryService(repo, fakeML{})
+	s := NewServer(memoryService, services.NewWorkflowService(repo), nil)
+	bus := events.NewBus()
+	bus.Subscribe(s.Notify)
+	memoryService.WithEvents(bus)
+
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+	session := &fakeSession{id: "session-1", notifications: make(chan mcp.JSONRPCNotification, 10)}
+	require.NoError(t, s.GetMCPServer().RegisterSession(ctx, session))
+	_, ok := s.Subscriptions().Intercept(ctx, session.id, []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"memory://m1"}}`))
+	require.True(t, ok)
+
+	require.NoError(t, memoryService.GiveFeedback(ctx, "m1", 0.9))
+
+	select {
+	case n := <-session.notifications:
+		assert.Equal(t, models.MemoryURI("m1"), n.Params.AdditionalFields["uri"])
+	case <-time.After(time.Second):
+		t.Fatal("no notification sent")
+	}
+
+	// Unregistering the session drops its subscriptions.
+	s.GetMCPServer().UnregisterSession(ctx, session.id)
+	assert.Empty(t, s.Subscriptions().sessions)
+}
+
+var _ server.ClientSession = (*fakeSession)(nil)
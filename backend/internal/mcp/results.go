@@ -0,0 +1,154 @@
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"evolutionary-mcp/backend/internal/quota"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/internal/services"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Error codes reported in the structured content of failed tool calls. They
+// are part of the tool contract: clients branch on them, so never change one.
+const (
+	ErrorCodeInvalidArgument = "invalid_argument"
+	ErrorCodeForbidden       = "forbidden"
+	ErrorCodeNotFound        = "not_found"
+	ErrorCodeConflict        = "conflict"
+	ErrorCodeQuotaExceeded   = "quota_exceeded"
+	ErrorCodeInternal        = "internal"
+)
+
+// ToolError is the structured content of a failed tool call.
+type ToolError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Problem carries RFC 7807 details for quota errors so agents can back off.
+	Problem *models.ProblemDetails `json:"problem,omitempty"`
+}
+
+// RememberResult is the output of the remember tool.
+type RememberResult struct {
+	Memory *repository.Memory `json:"memory"`
+}
+
+// RecallResult is the output of the recall tool, most relevant first.
+type RecallResult struct {
+	Memories []*repository.Memory `json:"memories"`
+}
+
+// GiveFeedbackResult is the output of the give_feedback tool.
+type GiveFeedbackResult struct {
+	MemoryID string `json:"memory_id"`
+	// Confidence is the confidence the memory was left with.
+	Confidence float64               `json:"confidence"`
+	Signal     models.FeedbackSignal `json:"signal,omitempty"`
+}
+
+// GroundingRulesResult is the output of the list_grounding_rules tool.
+type GroundingRulesResult struct {
+	Rules []*models.GroundingRule `json:"rules"`
+}
+
+// newToolError builds a failed tool result. The text content repeats the
+// code for clients that only read text.
+func newToolError(code, message string) *mcp.CallToolResult {
+	return toolErrorResult(ToolError{Code: code, Message: message})
+}
+
+func toolErrorResult(toolErr ToolError) *mcp.CallToolResult {
+	result := mcp.NewToolResultStructured(toolErr, fmt.Sprintf("[%s] %s", toolErr.Code, toolErr.Message))
+	result.IsError = true
+	return result
+}
+
+// missingParameter reports a required argument that was absent or empty.
+func missingParameter(name string) *mcp.CallToolResult {
+	return newToolError(ErrorCodeInvalidArgument, "Missing required parameter: "+name)
+}
+
+// toolError reports a failed tool call, classifying err into an error code.
+func toolError(prefix string, err error) *mcp.CallToolResult {
+	toolErr := ToolError{Code: errorCode(err), Message: fmt.Sprintf("%s: %v", prefix, err)}
+	var exceeded *quota.ExceededError
+	if errors.As(err, &exceeded) {
+		problem := exceeded.Problem()
+		toolErr.Problem = &problem
+	}
+	return toolErrorResult(toolErr)
+}
+
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, quota.ErrExceeded):
+		return ErrorCodeQuotaExceeded
+	case errors.Is(err, services.ErrInvalidInput):
+		return ErrorCodeInvalidArgument
+	case errors.Is(err, services.ErrUnauthorized), errors.Is(err, services.ErrForbidden):
+		return ErrorCodeForbidden
+	case errors.Is(err, repository.ErrNotFound), errors.Is(err, pgx.ErrNoRows):
+		return ErrorCodeNotFound
+	case errors.Is(err, repository.ErrConflict):
+		return ErrorCodeConflict
+	default:
+		return ErrorCodeInternal
+	}
+}
+
+// structuredResult returns value as structured content with a short summary
+// as text. value is encoded up front so an encoding failure is reported as a
+// tool error instead of breaking the response.
+func structuredResult(value any, summary string) *mcp.CallToolResult {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return toolError("Failed to encode result", err)
+	}
+	return mcp.NewToolResultStructured(json.RawMessage(jsonBytes), summary)
+}
+
+// jsonResult returns value encoded as JSON text.
+func jsonResult(value any) *mcp.CallToolResult {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return toolError("Failed to encode result", err)
+	}
+	return mcp.NewToolResultText(string(jsonBytes))
+}
+
+func summarizeMemories(memories []*repository.Memory) string {
+	var b strings.Builder
+	switch len(memories) {
+	case 0:
+		return "No memories matched."
+	case 1:
+		b.WriteString("Recalled 1 memory:")
+	default:
+		fmt.Fprintf(&b, "Recalled %d memories:", len(memories))
+	}
+	for _, m := range memories {
+		fmt.Fprintf(&b, "\n- [%s] (confidence %.2f) %s", models.MemoryURI(m.ID), m.Confidence, m.Content)
+	}
+	return b.String()
+}
+
+func summarizeGroundingRules(rules []*models.GroundingRule) string {
+	var b strings.Builder
+	switch len(rules) {
+	case 0:
+		return "No grounding rules apply."
+	case 1:
+		b.WriteString("1 grounding rule applies:")
+	default:
+		fmt.Fprintf(&b, "%d grounding rules apply:", len(rules))
+	}
+	for _, r := range rules {
+		fmt.Fprintf(&b, "\n- [%s] %s: %s", models.GroundingRuleURI(r.ID), r.Name, r.Content)
+	}
+	return b.String()
+}
@@ -2,32 +2,68 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 
 	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/quota"
+	"evolutionary-mcp/backend/internal/repository"
 	"evolutionary-mcp/backend/internal/services"
+	"evolutionary-mcp/backend/pkg/models"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
 type Server struct {
-	mcpServer    *server.MCPServer
-	memoryService *services.MemoryService
+	mcpServer       *server.MCPServer
+	memoryService   *services.MemoryService
+	workflowService *services.WorkflowService
+	quotas          *quota.Enforcer
+	subscriptions   *Subscriptions
+	notifications   *Notifications
 }
 
-func NewServer(memoryService *services.MemoryService) *Server {
+// NewServer creates the MCP server. quotas may be nil to disable per-tenant
+// rate limiting of tool calls.
+func NewServer(memoryService *services.MemoryService, workflowService *services.WorkflowService, quotas *quota.Enforcer) *Server {
 	s := &Server{
-		mcpServer: server.NewMCPServer(
-			"Evolutionary Memory",
-			"1.0.0",
-			server.WithToolCapabilities(true),
-		),
-		memoryService: memoryService,
+		memoryService:   memoryService,
+		workflowService: workflowService,
+		quotas:          quotas,
 	}
+	hooks := &server.Hooks{}
+	s.mcpServer = server.NewMCPServer(
+		"Evolutionary Memory",
+		"1.0.0",
+		server.WithToolCapabilities(true),
+		server.WithToolHandlerMiddleware(s.rateLimitTools),
+		server.WithResourceCapabilities(true, true),
+		server.WithResourceHandlerMiddleware(s.rateLimitResources),
+		server.WithPromptCapabilities(false),
+		server.WithLogging(),
+		server.WithHooks(hooks),
+	)
+	s.subscriptions = newSubscriptions(s.mcpServer, func(ctx context.Context, uri string) error {
+		_, err := s.readResource(ctx, uri)
+		return err
+	})
+	s.notifications = newNotifications(s.mcpServer, s.subscriptions)
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		s.subscriptions.forget(session.SessionID())
+	})
+	hooks.AddAfterInitialize(func(ctx context.Context, _ any, _ *mcp.InitializeRequest, result *mcp.InitializeResult) {
+		if isLegacySSE(ctx) && result.Capabilities.Resources != nil {
+			result.Capabilities.Resources.Subscribe = false
+		}
+	})
+	s.addNotificationHooks(hooks)
 
 	s.registerTools()
+	s.registerBatchTools()
+	s.registerWorkflowTools()
+	s.registerWorkflowRunTools()
+	s.registerGroundingTools()
+	s.registerResources()
+	s.registerPrompts()
 	return s
 }
 
@@ -35,12 +71,19 @@ func (s *Server) GetMCPServer() *server.MCPServer {
 	return s.mcpServer
 }
 
+// Subscriptions returns the resource subscriptions of the server. Pass it to
+// MountHTTPHandlers.
+func (s *Server) Subscriptions() *Subscriptions {
+	return s.subscriptions
+}
+
 func (s *Server) registerTools() {
 	s.mcpServer.AddTool(
 		mcp.NewTool(
 			"remember",
 			mcp.WithDescription("Create a new semantic memory"),
 			mcp.WithString("content", mcp.Required(), mcp.Description("The content of the memory")),
+			mcp.WithOutputSchema[RememberResult](),
 		),
 		s.handleRemember,
 	)
@@ -50,6 +93,8 @@ func (s *Server) registerTools() {
 			"recall",
 			mcp.WithDescription("Recall semantic memories based on a natural language query"),
 			mcp.WithString("query", mcp.Required(), mcp.Description("The query to search for")),
+			mcp.WithOutputSchema[RecallResult](),
+			mcp.WithReadOnlyHintAnnotation(true),
 		),
 		s.handleRecall,
 	)
@@ -57,9 +102,16 @@ func (s *Server) registerTools() {
 	s.mcpServer.AddTool(
 		mcp.NewTool(
 			"give_feedback",
-			mcp.WithDescription("Evolve a memory by providing a new confidence score"),
+			mcp.WithDescription("Evolve a memory by providing a new confidence score, or a signal that raises or lowers its current one. Give either confidence or signal"),
 			mcp.WithString("id", mcp.Required(), mcp.Description("The ID of the memory")),
-			mcp.WithNumber("confidence", mcp.Required(), mcp.Description("The new confidence score (0.0 to 1.0)")),
+			mcp.WithNumber("confidence", mcp.Description("The new confidence score (0.0 to 1.0)"), mcp.Min(0), mcp.Max(1)),
+			mcp.WithString("signal",
+				mcp.Description("How the memory fared: helpful raises its confidence, not_helpful, outdated and incorrect lower it, increasingly"),
+				mcp.Enum(string(models.FeedbackSignalHelpful), string(models.FeedbackSignalNotHelpful), string(models.FeedbackSignalIncorrect), string(models.FeedbackSignalOutdated)),
+			),
+			mcp.WithString("reason", mcp.Description("Why the memory was (not) useful, for curators to review")),
+			mcp.WithString("query", mcp.Description("The query that surfaced the memory")),
+			mcp.WithOutputSchema[GiveFeedbackResult](),
 		),
 		s.handleGiveFeedback,
 	)
@@ -68,113 +120,101 @@ func (s *Server) registerTools() {
 		mcp.NewTool(
 			"list_grounding_rules",
 			mcp.WithDescription("Retrieve foundational grounding rules and reasoning constraints"),
+			mcp.WithOutputSchema[GroundingRulesResult](),
+			mcp.WithReadOnlyHintAnnotation(true),
 		),
 		s.handleListGroundingRules,
 	)
 }
 
-// withAmbientContext ensures the correct tenant identity is present in the context.
-// In a real implementation, this would extract tenant information from the MCP session
-// or connection metadata. For now, it defaults to a 'mcp-user' tenant if none is provided.
-func (s *Server) withAmbientContext(ctx context.Context) context.Context {
-	tenantID := contextutil.GetTenant(ctx)
-	if tenantID == "" {
-		// Placeholder: In production, map the MCP connection to a Tenant
-		tenantID = "default" 
+// requireTenant rejects requests whose transport did not resolve a tenant.
+// Every MCP entry point authenticates the caller, so a missing tenant is a
+// wiring error and must not fall through to a shared tenant.
+func requireTenant(ctx context.Context) error {
+	if contextutil.GetTenant(ctx) == "" {
+		return fmt.Errorf("%w: tenant_id missing from context", services.ErrUnauthorized)
 	}
-	return contextutil.WithTenant(ctx, tenantID)
+	return nil
 }
 
-func (s *Server) handleRemember(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ctx = s.withAmbientContext(ctx)
-	args, ok := request.Params.Arguments.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments type"), nil
+// rateLimitTools rejects tool calls from tenants over their MCP request rate.
+func (s *Server) rateLimitTools(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if err := requireTenant(ctx); err != nil {
+			return toolError("Unauthorized", err), nil
+		}
+		if err := s.quotas.AllowRequest(ctx, contextutil.GetTenant(ctx), quota.SurfaceMCP); err != nil {
+			return toolError("Rate limited", err), nil
+		}
+		return next(ctx, request)
 	}
+}
 
-	content, ok := args["content"].(string)
-	if !ok || content == "" {
-		return mcp.NewToolResultError("Missing required parameter: content"), nil
+func (s *Server) handleRemember(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	content, err := request.RequireString("content")
+	if err != nil || content == "" {
+		return missingParameter("content"), nil
 	}
 
 	memory, err := s.memoryService.Remember(ctx, content)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to remember: %v", err)), nil
+		return toolError("Failed to remember", err), nil
 	}
 
-	jsonBytes, _ := json.Marshal(memory)
-	return mcp.NewToolResultText(string(jsonBytes)), nil
+	return structuredResult(
+		RememberResult{Memory: memory},
+		fmt.Sprintf("Remembered %s with confidence %.2f.", models.MemoryURI(memory.ID), memory.Confidence),
+	), nil
 }
 
 func (s *Server) handleRecall(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ctx = s.withAmbientContext(ctx)
-	args, ok := request.Params.Arguments.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments type"), nil
-	}
-
-	query, ok := args["query"].(string)
-	if !ok || query == "" {
-		return mcp.NewToolResultError("Missing required parameter: query"), nil
+	query, err := request.RequireString("query")
+	if err != nil || query == "" {
+		return missingParameter("query"), nil
 	}
 
 	memories, err := s.memoryService.Recall(ctx, query)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to recall: %v", err)), nil
+		return toolError("Failed to recall", err), nil
+	}
+	if memories == nil {
+		memories = []*repository.Memory{}
 	}
 
-	jsonBytes, _ := json.Marshal(memories)
-	return mcp.NewToolResultText(string(jsonBytes)), nil
+	return structuredResult(RecallResult{Memories: memories}, summarizeMemories(memories)), nil
 }
 
 func (s *Server) handleGiveFeedback(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ctx = s.withAmbientContext(ctx)
-	args, ok := request.Params.Arguments.(map[string]interface{})
-	if !ok {
-		return mcp.NewToolResultError("Invalid arguments type"), nil
+	id, err := request.RequireString("id")
+	if err != nil || id == "" {
+		return missingParameter("id"), nil
 	}
-
-	id, ok := args["id"].(string)
-	if !ok || id == "" {
-		return mcp.NewToolResultError("Missing required parameter: id"), nil
+	feedback := services.Feedback{
+		Signal: models.FeedbackSignal(request.GetString("signal", "")),
+		Reason: request.GetString("reason", ""),
+		Query:  request.GetString("query", ""),
 	}
-
-	confidence, ok := args["confidence"].(float64)
-	if !ok {
-		return mcp.NewToolResultError("Missing required parameter: confidence"), nil
+	if confidence, err := request.RequireFloat("confidence"); err == nil {
+		feedback.Confidence = &confidence
 	}
 
-	err := s.memoryService.GiveFeedback(ctx, id, confidence)
+	memory, err := s.memoryService.ApplyFeedback(ctx, id, feedback)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to give feedback: %v", err)), nil
+		return toolError("Failed to give feedback", err), nil
 	}
 
-	return mcp.NewToolResultText("Feedback received and memory evolved"), nil
+	return structuredResult(
+		GiveFeedbackResult{MemoryID: id, Confidence: memory.Confidence, Signal: feedback.Signal},
+		fmt.Sprintf("Feedback received: %s now has confidence %.2f.", models.MemoryURI(id), memory.Confidence),
+	), nil
 }
 
 func (s *Server) handleListGroundingRules(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	ctx = s.withAmbientContext(ctx)
-	
+
 	rules, err := s.memoryService.GetGroundingRules(ctx)
 	if err != nil {
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to list grounding rules: %v", err)), nil
+		return toolError("Failed to list grounding rules", err), nil
 	}
 
-	jsonBytes, _ := json.Marshal(rules)
-	return mcp.NewToolResultText(string(jsonBytes)), nil
-}
-
-func MountHTTPHandlers(mux *http.ServeMux, mcpServer *server.MCPServer) {
-	sseServer := server.NewSSEServer(mcpServer, server.WithStaticBasePath("/mcp"))
-	
-	mux.HandleFunc("/mcp", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodPost {
-			sseServer.ServeHTTP(w, r)
-			return
-		}
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-	})
-	
-	mux.HandleFunc("/mcp/sse", sseServer.ServeHTTP)
-	mux.HandleFunc("/mcp/message", sseServer.ServeHTTP)
+	return structuredResult(GroundingRulesResult{Rules: rules}, summarizeGroundingRules(rules)), nil
 }
@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// structured decodes the structured content of a tool result into v.
+func structured(t *testing.T, result *mcp.CallToolResult, v any) {
+	t.Helper()
+	jsonBytes, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(jsonBytes, v))
+}
+
+func TestTools_DeclareOutputSchemas(t *testing.T) {
+	s, _ := newTestServer()
+	for _, name := range []string{"remember", "recall", "give_feedback", "list_grounding_rules", "remember_batch", "give_feedback_batch"} {
+		tool := s.GetMCPServer().GetTool(name)
+		require.NotNil(t, tool, name)
+		assert.Equal(t, "object", tool.Tool.OutputSchema.Type, name)
+		assert.NotEmpty(t, tool.Tool.OutputSchema.Properties, name)
+	}
+}
+
+func TestTools_StructuredResults(t *testing.T) {
+	s, repo := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+
+	result := callTool(t, s, ctx, "remember", map[string]any{"content": "likes tea"})
+	require.False(t, result.IsError, toolText(result))
+	var remembered RememberResult
+	structured(t, result, &remembered)
+	assert.Equal(t, "likes tea", remembered.Memory.Content)
+	assert.Contains(t, repo.memories, remembered.Memory.ID)
+	assert.Contains(t, toolText(result), "memory://"+remembered.Memory.ID)
+
+	result = callTool(t, s, ctx, "recall", map[string]any{"query": "preferences"})
+	require.False(t, result.IsError, toolText(result))
+	var recalled RecallResult
+	structured(t, result, &recalled)
+	assert.Len(t, recalled.Memories, 2)
+	assert.Contains(t, toolText(result), "Recalled 2 memories")
+
+	result = callTool(t, s, ctx, "give_feedback", map[string]any{"id": "m1", "confidence": 0.25})
+	require.False(t, result.IsError, toolText(result))
+	var feedback GiveFeedbackResult
+	structured(t, result, &feedback)
+	assert.Equal(t, GiveFeedbackResult{MemoryID: "m1", Confidence: 0.25}, feedback)
+	assert.Equal(t, 0.25, repo.memories["m1"].Confidence)
+
+	result = callTool(t, s, ctx, "list_grounding_rules", nil)
+	require.False(t, result.IsError, toolText(result))
+	var rules GroundingRulesResult
+	structured(t, result, &rules)
+	assert.Len(t, rules.Rules, 2)
+	assert.Contains(t, toolText(result), "[grounding://r1] cite sources")
+}
+
+func TestTools_GiveFeedbackSignal(t *testing.T) {
+	s, repo := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+
+	result := callTool(t, s, ctx, "give_feedback", map[string]any{
+		"id":     "m1",
+		"signal": "outdated",
+		"reason": "we moved to light mode",
+		"query":  "ui preferences",
+	})
+	require.False(t, result.IsError, toolText(result))
+	var feedback GiveFeedbackResult
+	structured(t, result, &feedback)
+	assert.Equal(t, models.FeedbackSignalOutdated, feedback.Signal)
+	assert.InDelta(t, 0.5, feedback.Confidence, 1e-9)
+	assert.InDelta(t, 0.5, repo.memories["m1"].Confidence, 1e-9)
+
+	require.Len(t, repo.feedback, 1)
+	assert.Equal(t, models.FeedbackSignalOutdated, repo.feedback[0].Signal)
+	assert.Equal(t, "we moved to light mode", repo.feedback[0].Reason)
+	assert.Equal(t, "ui preferences", repo.feedback[0].Query)
+}
+
+func TestTools_ErrorCodes(t *testing.T) {
+	s, _ := newTestServer()
+	ctx := contextutil.WithTenant(context.Background(), "acme")
+
+	cases := []struct {
+		name string
+		tool string
+		args map[string]any
+		code string
+	}{
+		{"missing argument", "recall", nil, ErrorCodeInvalidArgument},
+		{"confidence out of range", "give_feedback", map[string]any{"id": "m1", "confidence": 2}, ErrorCodeInvalidArgument},
+		{"neither confidence nor signal", "give_feedback", map[string]any{"id": "m1"}, ErrorCodeInvalidArgument},
+		{"unknown signal", "give_feedback", map[string]any{"id": "m1", "signal": "meh"}, ErrorCodeInvalidArgument},
+		{"unknown memory", "give_feedback", map[string]any{"id": "missing", "confidence": 0.5}, ErrorCodeNotFound},
+		{"other tenant's memory", "give_feedback", map[string]any{"id": "m2", "confidence": 0.5}, ErrorCodeForbidden},
+		{"missing scope", "list_workflows", nil, ErrorCodeForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := callTool(t, s, ctx, tc.tool, tc.args)
+			require.True(t, result.IsError)
+			var toolErr ToolError
+			structured(t, result, &toolErr)
+			assert.Equal(t, tc.code, toolErr.Code)
+			assert.Contains(t, toolText(result), "["+tc.code+"]")
+		})
+	}
+}
+
+func TestTools_RequireTenant(t *testing.T) {
+	s, repo := newTestServer()
+
+	result := callTool(t, s, context.Background(), "remember", map[string]any{"content": "orphaned"})
+	require.True(t, result.IsError)
+	var toolErr ToolError
+	structured(t, result, &toolErr)
+	assert.Equal(t, ErrorCodeForbidden, toolErr.Code)
+	assert.Len(t, repo.memories, 2)
+}
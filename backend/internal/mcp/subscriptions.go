@@ -0,0 +1,195 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	methodResourcesSubscribe   = "resources/subscribe"
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+
+	// stdioSessionID is the ID the MCP library gives its single stdio session.
+	stdioSessionID = "stdio"
+)
+
+// Subscriptions answers resources/subscribe and resources/unsubscribe, which
+// the MCP library advertises but does not handle, and sends
+// notifications/resources/updated to the sessions subscribed to a resource
+// when it changes.
+type Subscriptions struct {
+	mcpServer *server.MCPServer
+	// authorize returns an error unless the caller in ctx may read uri.
+	authorize func(ctx context.Context, uri string) error
+
+	mu       sync.Mutex
+	sessions map[string]map[string]struct{} // uri -> session IDs
+}
+
+func newSubscriptions(mcpServer *server.MCPServer, authorize func(ctx context.Context, uri string) error) *Subscriptions {
+	return &Subscriptions{
+		mcpServer: mcpServer,
+		authorize: authorize,
+		sessions:  make(map[string]map[string]struct{}),
+	}
+}
+
+// Intercept handles message if it is a subscription request from sessionID
+// and returns the JSON-RPC response. It returns false for every other
+// message, which must be passed on to the MCP server.
+func (s *Subscriptions) Intercept(ctx context.Context, sessionID string, message []byte) ([]byte, bool) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if json.Unmarshal(message, &req) != nil {
+		return nil, false
+	}
+	if req.Method != methodResourcesSubscribe && req.Method != methodResourcesUnsubscribe {
+		return nil, false
+	}
+
+	if req.Params.URI == "" {
+		return rpcError(req.ID, mcp.INVALID_PARAMS, "uri is required"), true
+	}
+	if req.Method == methodResourcesUnsubscribe {
+		s.unsubscribe(sessionID, req.Params.URI)
+		return rpcResult(req.ID), true
+	}
+	if err := s.authorize(ctx, req.Params.URI); err != nil {
+		code := mcp.INTERNAL_ERROR
+		if errors.Is(err, server.ErrResourceNotFound) {
+			code = mcp.RESOURCE_NOT_FOUND
+		}
+		return rpcError(req.ID, code, err.Error()), true
+	}
+	s.subscribe(sessionID, req.Params.URI)
+	return rpcResult(req.ID), true
+}
+
+// ResourceUpdated notifies the sessions subscribed to uri. Sessions that
+// have gone away are dropped.
+func (s *Subscriptions) ResourceUpdated(uri string) {
+	s.mu.Lock()
+	sessionIDs := make([]string, 0, len(s.sessions[uri]))
+	for id := range s.sessions[uri] {
+		sessionIDs = append(sessionIDs, id)
+	}
+	s.mu.Unlock()
+
+	for _, id := range sessionIDs {
+		err := s.mcpServer.SendNotificationToSpecificClient(id, mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+		if errors.Is(err, server.ErrSessionNotFound) {
+			s.forget(id)
+		}
+	}
+}
+
+func (s *Subscriptions) subscribe(sessionID, uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sessions[uri] == nil {
+		s.sessions[uri] = make(map[string]struct{})
+	}
+	s.sessions[uri][sessionID] = struct{}{}
+}
+
+func (s *Subscriptions) unsubscribe(sessionID, uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions[uri], sessionID)
+	if len(s.sessions[uri]) == 0 {
+		delete(s.sessions, uri)
+	}
+}
+
+// drop removes every subscription to uri, e.g. once it has been deleted.
+func (s *Subscriptions) drop(uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, uri)
+}
+
+// forget drops every subscription of a session.
+func (s *Subscriptions) forget(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for uri, ids := range s.sessions {
+		delete(ids, sessionID)
+		if len(ids) == 0 {
+			delete(s.sessions, uri)
+		}
+	}
+}
+
+func rpcResult(id json.RawMessage) []byte {
+	reply, _ := json.Marshal(map[string]any{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      id,
+		"result":  map[string]any{},
+	})
+	return reply
+}
+
+func rpcError(id json.RawMessage, code int, message string) []byte {
+	reply, _ := json.Marshal(map[string]any{
+		"jsonrpc": mcp.JSONRPC_VERSION,
+		"id":      id,
+		"error":   map[string]any{"code": code, "message": message},
+	})
+	return reply
+}
+
+// ServeStdio serves MCP over stdin and stdout, answering subscription
+// requests itself. identity stamps the caller's tenant onto every request.
+func (s *Server) ServeStdio(ctx context.Context, identity server.StdioContextFunc, errLogger *log.Logger, in io.Reader, out io.Writer) error {
+	stdioServer := server.NewStdioServer(s.mcpServer)
+	stdioServer.SetErrorLogger(errLogger)
+	stdioServer.SetContextFunc(identity)
+
+	w := &lockedWriter{w: out}
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	go func() {
+		scanner := bufio.NewScanner(in)
+		scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if reply, ok := s.subscriptions.Intercept(identity(ctx), stdioSessionID, line); ok {
+				_, _ = w.Write(append(reply, '\n'))
+				continue
+			}
+			if _, err := pw.Write(append(line, '\n')); err != nil {
+				return
+			}
+		}
+		pw.CloseWithError(scanner.Err())
+	}()
+
+	return stdioServer.Listen(ctx, pr, w)
+}
+
+// lockedWriter serializes writes so intercepted replies do not interleave
+// with the stdio server's own messages.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
@@ -0,0 +1,407 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// HTTPOptions selects and tunes the HTTP transports served under /mcp.
+type HTTPOptions struct {
+	// SSE enables the legacy HTTP+SSE transport on /mcp/sse and /mcp/message.
+	SSE bool
+	// StreamableHTTP enables the Streamable HTTP transport on /mcp.
+	StreamableHTTP bool
+	// HeartbeatInterval is how often idle GET streams are pinged. Zero disables pings.
+	HeartbeatInterval time.Duration
+	// SessionIdleTimeout expires Streamable HTTP sessions that have not been
+	// used for this long. Zero keeps sessions until the client deletes them.
+	SessionIdleTimeout time.Duration
+	// ReplayBufferSize is the number of server-sent events kept per session for
+	// clients resuming a stream with Last-Event-ID.
+	ReplayBufferSize int
+	// Subscriptions, when set, answers resource subscription requests on the
+	// Streamable HTTP transport. The legacy SSE transport does not support them
+	// and does not advertise them to its clients.
+	Subscriptions *Subscriptions
+}
+
+// DefaultReplayBufferSize is used when HTTPOptions.ReplayBufferSize is zero.
+const DefaultReplayBufferSize = 100
+
+const (
+	headerLastEventID = "Last-Event-ID"
+	sessionIDPrefix   = "mcp-session-"
+)
+
+// MountHTTPHandlers registers the enabled MCP transports on mux.
+func MountHTTPHandlers(mux *http.ServeMux, mcpServer *server.MCPServer, opts HTTPOptions) error {
+	if !opts.SSE && !opts.StreamableHTTP {
+		return errors.New("no MCP transport enabled")
+	}
+
+	if opts.StreamableHTTP {
+		mux.Handle("/mcp", newStreamableHandler(mcpServer, opts))
+	}
+
+	if opts.SSE {
+		sseServer := server.NewSSEServer(mcpServer,
+			server.WithStaticBasePath("/mcp"),
+			server.WithSSEContextFunc(func(ctx context.Context, _ *http.Request) context.Context {
+				return context.WithValue(ctx, legacySSEKey{}, true)
+			}),
+		)
+		mux.HandleFunc("/mcp/sse", func(w http.ResponseWriter, r *http.Request) {
+			keepStreamOpen(w)
+			sseServer.ServeHTTP(w, r)
+		})
+		mux.HandleFunc("/mcp/message", sseServer.ServeHTTP)
+	}
+	return nil
+}
+
+// legacySSEKey marks the context of requests on the legacy SSE transport.
+type legacySSEKey struct{}
+
+// isLegacySSE reports whether ctx belongs to a request on the legacy SSE
+// transport, which cannot deliver resource subscriptions.
+func isLegacySSE(ctx context.Context) bool {
+	legacy, _ := ctx.Value(legacySSEKey{}).(bool)
+	return legacy
+}
+
+// keepStreamOpen lifts the server's write timeout for a long-lived event
+// stream, which would otherwise be cut off before the first heartbeat.
+func keepStreamOpen(w http.ResponseWriter) {
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+}
+
+// streamableHandler serves the Streamable HTTP transport. It tracks sessions
+// so unknown or deleted session IDs are rejected with 404, and numbers every
+// server-sent event so clients can resume a dropped stream by sending GET with
+// Last-Event-ID.
+type streamableHandler struct {
+	next          http.Handler
+	sessions      *sessionStore
+	subscriptions *Subscriptions
+}
+
+func newStreamableHandler(mcpServer *server.MCPServer, opts HTTPOptions) *streamableHandler {
+	size := opts.ReplayBufferSize
+	if size <= 0 {
+		size = DefaultReplayBufferSize
+	}
+	sessions := newSessionStore(opts.SessionIdleTimeout, size)
+
+	streamOpts := []server.StreamableHTTPOption{
+		server.WithEndpointPath("/mcp"),
+		server.WithSessionIdManagerResolver(sessions),
+	}
+	if opts.HeartbeatInterval > 0 {
+		streamOpts = append(streamOpts, server.WithHeartbeatInterval(opts.HeartbeatInterval))
+	}
+
+	return &streamableHandler{
+		next:          server.NewStreamableHTTPServer(mcpServer, streamOpts...),
+		sessions:      sessions,
+		subscriptions: opts.Subscriptions,
+	}
+}
+
+func (h *streamableHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.Header.Get(server.HeaderKeySessionID)
+	tenantID := contextutil.GetTenant(r.Context())
+
+	switch r.Method {
+	case http.MethodPost:
+		if h.subscriptions != nil && h.sessions.active(sessionID, tenantID) && h.intercept(w, r, sessionID) {
+			return
+		}
+	case http.MethodGet, http.MethodDelete:
+		// Only POST may open a session; the other methods must name a live one.
+		if sessionID == "" {
+			http.Error(w, "Missing "+server.HeaderKeySessionID+" header", http.StatusBadRequest)
+			return
+		}
+		if !h.sessions.active(sessionID, tenantID) {
+			http.Error(w, "Unknown or terminated session", http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodGet {
+			keepStreamOpen(w)
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	rec := &eventRecorder{ResponseWriter: w, sessions: h.sessions, sessionID: sessionID, tenantID: tenantID, replayAfter: -1}
+	if r.Method == http.MethodGet {
+		if last := r.Header.Get(headerLastEventID); last != "" {
+			id, err := strconv.ParseInt(last, 10, 64)
+			if err != nil {
+				http.Error(w, "Invalid "+headerLastEventID+" header", http.StatusBadRequest)
+				return
+			}
+			rec.replayAfter = id
+		}
+	}
+	h.next.ServeHTTP(rec, r)
+}
+
+// intercept answers resource subscription requests, which the MCP library
+// does not handle. It reports whether the request was answered; if not, the
+// body is restored for the next handler.
+func (h *streamableHandler) intercept(w http.ResponseWriter, r *http.Request, sessionID string) bool {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize))
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+
+	reply, ok := h.subscriptions.Intercept(r.Context(), sessionID, body)
+	if !ok {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(reply)
+	return true
+}
+
+// eventRecorder numbers the server-sent events written to a session's streams
+// and, for resumed GET streams, replays the events the client missed before
+// any new ones.
+type eventRecorder struct {
+	http.ResponseWriter
+	sessions    *sessionStore
+	sessionID   string
+	tenantID    string
+	replayAfter int64
+	replayed    bool
+}
+
+func (w *eventRecorder) Write(p []byte) (int, error) {
+	if !w.streaming() {
+		return w.ResponseWriter.Write(p)
+	}
+	if err := w.replay(); err != nil {
+		return 0, err
+	}
+	if !bytes.HasPrefix(p, []byte("event: ")) {
+		return w.ResponseWriter.Write(p)
+	}
+
+	id, ok := w.sessions.record(w.sessionID, w.tenantID, p)
+	if !ok {
+		return w.ResponseWriter.Write(p)
+	}
+	if _, err := fmt.Fprintf(w.ResponseWriter, "id: %d\n", id); err != nil {
+		return 0, err
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *eventRecorder) Flush() {
+	if w.streaming() {
+		_ = w.replay()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// streaming reports whether the response has been turned into an SSE stream.
+func (w *eventRecorder) streaming() bool {
+	return w.Header().Get("Content-Type") == "text/event-stream"
+}
+
+func (w *eventRecorder) replay() error {
+	if w.replayed || w.replayAfter < 0 {
+		return nil
+	}
+	w.replayed = true
+	for _, ev := range w.sessions.eventsAfter(w.sessionID, w.tenantID, w.replayAfter) {
+		if _, err := fmt.Fprintf(w.ResponseWriter, "id: %d\n%s", ev.id, ev.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type sseEvent struct {
+	id   int64
+	data []byte
+}
+
+type session struct {
+	tenantID   string
+	lastSeen   time.Time
+	terminated bool
+	nextID     int64
+	events     []sseEvent
+}
+
+// sessionStore issues and tracks Streamable HTTP session IDs and keeps the
+// most recent events sent on each session for replay. Every session belongs to
+// the tenant that created it and is unknown to other tenants. It implements
+// server.SessionIdManagerResolver.
+type sessionStore struct {
+	idleTimeout time.Duration
+	bufferSize  int
+	now         func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+func newSessionStore(idleTimeout time.Duration, bufferSize int) *sessionStore {
+	return &sessionStore{
+		idleTimeout: idleTimeout,
+		bufferSize:  bufferSize,
+		now:         time.Now,
+		sessions:    make(map[string]*session),
+	}
+}
+
+// ResolveSessionIdManager returns the session IDs of the tenant the request
+// was authenticated as.
+func (s *sessionStore) ResolveSessionIdManager(r *http.Request) server.SessionIdManager {
+	return tenantSessions{store: s, tenantID: contextutil.GetTenant(r.Context())}
+}
+
+// tenantSessions is the server.SessionIdManager of a single tenant.
+type tenantSessions struct {
+	store    *sessionStore
+	tenantID string
+}
+
+func (t tenantSessions) Generate() string { return t.store.Generate(t.tenantID) }
+
+func (t tenantSessions) Validate(sessionID string) (bool, error) {
+	return t.store.Validate(sessionID, t.tenantID)
+}
+
+func (t tenantSessions) Terminate(sessionID string) (bool, error) {
+	return t.store.Terminate(sessionID, t.tenantID)
+}
+
+// Generate creates a new session ID for tenantID.
+func (s *sessionStore) Generate(tenantID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	id := sessionIDPrefix + uuid.New().String()
+	s.sessions[id] = &session{tenantID: tenantID, lastSeen: s.now()}
+	return id
+}
+
+// Validate accepts only session IDs issued by Generate to tenantID that have
+// not expired.
+func (s *sessionStore) Validate(sessionID, tenantID string) (isTerminated bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.lookup(sessionID, tenantID)
+	if !ok {
+		return false, fmt.Errorf("unknown session id: %q", sessionID)
+	}
+	return sess.terminated, nil
+}
+
+// Terminate ends a session of tenantID at the client's request.
+func (s *sessionStore) Terminate(sessionID, tenantID string) (isNotAllowed bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.lookup(sessionID, tenantID)
+	if !ok {
+		return false, fmt.Errorf("unknown session id: %q", sessionID)
+	}
+	sess.terminated = true
+	sess.events = nil
+	return false, nil
+}
+
+// active reports whether sessionID names a live session of tenantID.
+func (s *sessionStore) active(sessionID, tenantID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.lookup(sessionID, tenantID)
+	return ok && !sess.terminated
+}
+
+// record stores an event for replay and returns its ID. It returns false when
+// the session is unknown, in which case the event is sent without an ID.
+func (s *sessionStore) record(sessionID, tenantID string, data []byte) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.lookup(sessionID, tenantID)
+	if !ok || sess.terminated {
+		return 0, false
+	}
+	sess.nextID++
+	sess.events = append(sess.events, sseEvent{id: sess.nextID, data: bytes.Clone(data)})
+	if len(sess.events) > s.bufferSize {
+		sess.events = sess.events[len(sess.events)-s.bufferSize:]
+	}
+	return sess.nextID, true
+}
+
+// eventsAfter returns the buffered events of a session of tenantID with an ID
+// above after.
+func (s *sessionStore) eventsAfter(sessionID, tenantID string, after int64) []sseEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.lookup(sessionID, tenantID)
+	if !ok {
+		return nil
+	}
+	var events []sseEvent
+	for _, ev := range sess.events {
+		if ev.id > after {
+			events = append(events, ev)
+		}
+	}
+	return events
+}
+
+// lookup returns a session of tenantID and marks it as used. Sessions of other
+// tenants are reported as unknown. Callers must hold s.mu.
+func (s *sessionStore) lookup(sessionID, tenantID string) (*session, bool) {
+	sess, ok := s.sessions[sessionID]
+	if !ok || sess.tenantID != tenantID {
+		return nil, false
+	}
+	now := s.now()
+	if s.idleTimeout > 0 && now.Sub(sess.lastSeen) > s.idleTimeout {
+		delete(s.sessions, sessionID)
+		return nil, false
+	}
+	sess.lastSeen = now
+	return sess, true
+}
+
+// expire drops sessions idle for longer than the timeout. Callers must hold s.mu.
+func (s *sessionStore) expire() {
+	if s.idleTimeout <= 0 {
+		return
+	}
+	now := s.now()
+	for id, sess := range s.sessions {
+		if now.Sub(sess.lastSeen) > s.idleTimeout {
+			delete(s.sessions, id)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// post sends body as the acme tenant.
+func post(t *testing.T, h http.Handler, sessionID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	return postAs(t, h, "acme", sessionID, body)
+}
+
+// postAs sends body as tenantID, as the auth middleware would.
+func postAs(t *testing.T, h http.Handler, tenantID, sessionID, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+	req = req.WithContext(contextutil.WithTenant(req.Context(), tenantID))
+	req.Header.Set("Content-Type", "application/json")
+	if sessionID != "" {
+		req.Header.Set(server.HeaderKeySessionID, sessionID)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMountHTTPHandlers_StreamableHTTPSessions(t *testing.T) {
+	mux := http.NewServeMux()
+	require.NoError(t, MountHTTPHandlers(mux, server.NewMCPServer("test", "1.0.0"), HTTPOptions{StreamableHTTP: true}))
+
+	rec := post(t, mux, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	sessionID := rec.Header().Get(server.HeaderKeySessionID)
+	require.NotEmpty(t, sessionID)
+
+	rec = post(t, mux, sessionID, `{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = post(t, mux, "mcp-session-unknown", `{"jsonrpc":"2.0","id":3,"method":"ping"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// Sessions are unknown to other tenants.
+	rec = postAs(t, mux, "globex", sessionID, `{"jsonrpc":"2.0","id":3,"method":"ping"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	req := httptest.NewRequest(http.MethodDelete, "/mcp", nil)
+	req = req.WithContext(contextutil.WithTenant(req.Context(), "globex"))
+	req.Header.Set(server.HeaderKeySessionID, sessionID)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/mcp", nil)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/mcp", nil)
+	req = req.WithContext(contextutil.WithTenant(req.Context(), "acme"))
+	req.Header.Set(server.HeaderKeySessionID, sessionID)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = post(t, mux, sessionID, `{"jsonrpc":"2.0","id":4,"method":"ping"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// SSE was not enabled.
+	req = httptest.NewRequest(http.MethodGet, "/mcp/sse", nil)
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMountHTTPHandlers_NoTransport(t *testing.T) {
+	err := MountHTTPHandlers(http.NewServeMux(), server.NewMCPServer("test", "1.0.0"), HTTPOptions{})
+	assert.Error(t, err)
+}
+
+func TestStreamableHandler_ResumesFromLastEventID(t *testing.T) {
+	sessions := newSessionStore(time.Hour, 2)
+	sessionID := sessions.Generate("acme")
+	sessions.record(sessionID, "acme", []byte("event: message\ndata: {\"n\":1}\n\n"))
+	sessions.record(sessionID, "acme", []byte("event: message\ndata: {\"n\":2}\n\n"))
+	sessions.record(sessionID, "acme", []byte("event: message\ndata: {\"n\":3}\n\n"))
+
+	h := &streamableHandler{
+		sessions: sessions,
+		next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			_, _ = w.Write([]byte("event: message\ndata: {\"n\":4}\n\n"))
+		}),
+	}
+
+	// Another tenant cannot resume the stream.
+	req := httptest.NewRequest(http.MethodGet, "/mcp", nil)
+	req = req.WithContext(contextutil.WithTenant(req.Context(), "globex"))
+	req.Header.Set(server.HeaderKeySessionID, sessionID)
+	req.Header.Set(headerLastEventID, "1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.NotContains(t, rec.Body.String(), `"n":2`)
+
+	req = httptest.NewRequest(http.MethodGet, "/mcp", nil)
+	req = req.WithContext(contextutil.WithTenant(req.Context(), "acme"))
+	req.Header.Set(server.HeaderKeySessionID, sessionID)
+	req.Header.Set(headerLastEventID, "1")
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	// Event 1 was acknowledged; events 2 and 3 are replayed before event 4.
+	assert.Equal(t,
+		"id: 2\nevent: message\ndata: {\"n\":2}\n\n"+
+			"id: 3\nevent: message\ndata: {\"n\":3}\n\n"+
+			"id: 4\nevent: message\ndata: {\"n\":4}\n\n",
+		rec.Body.String())
+
+	// Only the newest two events are kept.
+	events := sessions.eventsAfter(sessionID, "acme", 0)
+	require.Len(t, events, 2)
+	assert.Equal(t, int64(3), events[0].id)
+}
+
+func TestSessionStore_ExpiresIdleSessions(t *testing.T) {
+	sessions := newSessionStore(time.Minute, DefaultReplayBufferSize)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sessions.now = func() time.Time { return now }
+
+	id := sessions.Generate("acme")
+	assert.True(t, sessions.active(id, "acme"))
+	assert.False(t, sessions.active(id, "globex"))
+	_, err := sessions.Validate(id, "globex")
+	assert.Error(t, err)
+
+	now = now.Add(2 * time.Minute)
+	assert.False(t, sessions.active(id, "acme"))
+	_, err = sessions.Validate(id, "acme")
+	assert.Error(t, err)
+}
+
+func TestMountHTTPHandlers_AnswersSubscriptions(t *testing.T) {
+	s, _ := newTestServer()
+	mux := http.NewServeMux()
+	require.NoError(t, MountHTTPHandlers(mux, s.GetMCPServer(), HTTPOptions{StreamableHTTP: true, Subscriptions: s.Subscriptions()}))
+
+	rec := post(t, mux, "", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"subscribe":true`)
+	sessionID := rec.Header().Get(server.HeaderKeySessionID)
+
+	rec = post(t, mux, sessionID, `{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"grounding://r2"}}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":2,"result":{}}`, rec.Body.String())
+
+	// Without a session there is nothing to notify, so the request goes on
+	// to the MCP server, which rejects it.
+	rec = post(t, mux, "", `{"jsonrpc":"2.0","id":3,"method":"resources/subscribe","params":{"uri":"grounding://r2"}}`)
+	assert.NotContains(t, rec.Body.String(), `"result"`)
+}
+
+func TestStreamableHandler_StreamOutlivesWriteTimeout(t *testing.T) {
+	sessions := newSessionStore(time.Hour, DefaultReplayBufferSize)
+	sessionID := sessions.Generate("acme")
+	h := &streamableHandler{
+		sessions: sessions,
+		next: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.WriteHeader(http.StatusOK)
+			w.(http.Flusher).Flush()
+			time.Sleep(200 * time.Millisecond)
+			_, _ = w.Write([]byte("event: message\ndata: {}\n\n"))
+		}),
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(contextutil.WithTenant(r.Context(), "acme")))
+	}))
+	srv.Config.WriteTimeout = 50 * time.Millisecond
+	srv.Start()
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/mcp", nil)
+	require.NoError(t, err)
+	req.Header.Set(server.HeaderKeySessionID, sessionID)
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "data: {}")
+}
+
+func TestServer_LegacySSEDoesNotAdvertiseSubscriptions(t *testing.T) {
+	s, _ := newTestServer()
+	initialize := []byte(`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26","capabilities":{},"clientInfo":{"name":"test","version":"1"}}}`)
+
+	reply, err := json.Marshal(s.GetMCPServer().HandleMessage(context.Background(), initialize))
+	require.NoError(t, err)
+	assert.Contains(t, string(reply), `"subscribe":true`)
+
+	sseCtx := context.WithValue(context.Background(), legacySSEKey{}, true)
+	reply, err = json.Marshal(s.GetMCPServer().HandleMessage(sseCtx, initialize))
+	require.NoError(t, err)
+	assert.NotContains(t, string(reply), `"subscribe"`)
+	assert.Contains(t, string(reply), `"listChanged":true`)
+}
@@ -0,0 +1,128 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"evolutionary-mcp/backend/internal/auth"
+	"evolutionary-mcp/backend/internal/services"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) registerWorkflowRunTools() {
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"start_workflow_run",
+			mcp.WithDescription("Record that you are starting to execute a workflow. Returns the run, whose ID you pass to update_workflow_run and complete_workflow_run"),
+			mcp.WithString("workflow_id", mcp.Required(), mcp.Description("The stable ID of the workflow")),
+			mcp.WithNumber("version", mcp.Description("The version you execute; defaults to the latest")),
+			mcp.WithAny("inputs", mcp.Description("The inputs of the run; must match the input schema of the workflow")),
+		),
+		s.handleStartWorkflowRun,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"update_workflow_run",
+			mcp.WithDescription("Record the progress of a workflow run: the memories recalled and created so far, and partial outputs"),
+			mcp.WithString("run_id", mcp.Required(), mcp.Description("The ID of the run")),
+			mcp.WithAny("outputs", mcp.Description("The outputs so far; replaces the outputs recorded before")),
+			mcp.WithArray("recalled_memory_ids", mcp.WithStringItems(), mcp.Description("IDs of memories recalled during the run")),
+			mcp.WithArray("created_memory_ids", mcp.WithStringItems(), mcp.Description("IDs of memories created during the run")),
+		),
+		s.handleUpdateWorkflowRun,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"complete_workflow_run",
+			mcp.WithDescription("Record that a workflow run has ended. The outputs of a succeeded run must match the output schema of the workflow"),
+			mcp.WithString("run_id", mcp.Required(), mcp.Description("The ID of the run")),
+			mcp.WithString("status", mcp.Required(),
+				mcp.Enum(models.WorkflowRunStatusSucceeded, models.WorkflowRunStatusFailed, models.WorkflowRunStatusCancelled),
+				mcp.Description("How the run ended")),
+			mcp.WithAny("outputs", mcp.Description("The outputs of the run")),
+			mcp.WithString("error", mcp.Description("Why the run failed or was cancelled")),
+			mcp.WithArray("recalled_memory_ids", mcp.WithStringItems(), mcp.Description("IDs of memories recalled during the run")),
+			mcp.WithArray("created_memory_ids", mcp.WithStringItems(), mcp.Description("IDs of memories created during the run")),
+		),
+		s.handleCompleteWorkflowRun,
+	)
+}
+
+func (s *Server) handleStartWorkflowRun(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if denied := requireScope(ctx, auth.ScopeEvolveWrite); denied != nil {
+		return denied, nil
+	}
+
+	workflowID, err := request.RequireString("workflow_id")
+	if err != nil || workflowID == "" {
+		return missingParameter("workflow_id"), nil
+	}
+	version, err := versionArgument(request)
+	if err != nil {
+		return toolError("Invalid parameter", err), nil
+	}
+
+	run, err := s.workflowService.StartRun(ctx, workflowID, version, request.GetArguments()["inputs"])
+	if err != nil {
+		return toolError("Failed to start workflow run", err), nil
+	}
+
+	return jsonResult(run), nil
+}
+
+func (s *Server) handleUpdateWorkflowRun(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if denied := requireScope(ctx, auth.ScopeEvolveWrite); denied != nil {
+		return denied, nil
+	}
+
+	runID, err := request.RequireString("run_id")
+	if err != nil || runID == "" {
+		return missingParameter("run_id"), nil
+	}
+
+	run, err := s.workflowService.UpdateRun(ctx, runID, runUpdate(request))
+	if err != nil {
+		return toolError("Failed to update workflow run", err), nil
+	}
+
+	return jsonResult(run), nil
+}
+
+func (s *Server) handleCompleteWorkflowRun(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if denied := requireScope(ctx, auth.ScopeEvolveWrite); denied != nil {
+		return denied, nil
+	}
+
+	runID, err := request.RequireString("run_id")
+	if err != nil || runID == "" {
+		return missingParameter("run_id"), nil
+	}
+	update := runUpdate(request)
+	update.Status, err = request.RequireString("status")
+	if err != nil || update.Status == "" {
+		return missingParameter("status"), nil
+	}
+	if update.Status == models.WorkflowRunStatusRunning {
+		return toolError("Invalid parameter", fmt.Errorf("%w: status must say how the run ended", services.ErrInvalidInput)), nil
+	}
+	update.Error = request.GetString("error", "")
+
+	run, err := s.workflowService.UpdateRun(ctx, runID, update)
+	if err != nil {
+		return toolError("Failed to complete workflow run", err), nil
+	}
+
+	return jsonResult(run), nil
+}
+
+// runUpdate reads the outputs and memory IDs shared by the run update tools.
+func runUpdate(request mcp.CallToolRequest) models.WorkflowRunUpdate {
+	return models.WorkflowRunUpdate{
+		Outputs:           request.GetArguments()["outputs"],
+		RecalledMemoryIDs: request.GetStringSlice("recalled_memory_ids", nil),
+		CreatedMemoryIDs:  request.GetStringSlice("created_memory_ids", nil),
+	}
+}
@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/auth"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowRunTools(t *testing.T) {
+	s, repo := newTestServer()
+	ctx := contextutil.WithUser(
+		contextutil.WithScopes(contextutil.WithTenant(context.Background(), "acme"), []string{auth.ScopeEvolveRead, auth.ScopeEvolveWrite}),
+		"agent@example.com")
+	const memoryID = "6f1c2d3e-0000-4000-8000-000000000001"
+	repo.memories[memoryID] = &repository.Memory{ID: memoryID, TenantID: "acme", Content: "welcome mails go out at 9", Version: 1}
+
+	result := callTool(t, s, ctx, "start_workflow_run", map[string]any{"workflow_id": "wf", "inputs": map[string]any{"email": "ada@example.com"}})
+	require.False(t, result.IsError, toolText(result))
+	var run models.WorkflowRun
+	require.NoError(t, json.Unmarshal([]byte(toolText(result)), &run))
+	assert.Equal(t, "w2", run.VersionID)
+	assert.Equal(t, "agent@example.com", run.StartedBy)
+
+	result = callTool(t, s, ctx, "update_workflow_run", map[string]any{"run_id": run.ID, "recalled_memory_ids": []string{memoryID}})
+	require.False(t, result.IsError, toolText(result))
+	assert.Contains(t, toolText(result), `"recalled_memory_ids":["`+memoryID+`"]`)
+
+	result = callTool(t, s, ctx, "complete_workflow_run", map[string]any{
+		"run_id":             run.ID,
+		"status":             "succeeded",
+		"outputs":            map[string]any{"welcome_sent": true},
+		"created_memory_ids": []string{memoryID},
+	})
+	require.False(t, result.IsError, toolText(result))
+	assert.Equal(t, models.WorkflowRunStatusSucceeded, repo.runs[run.ID].Status)
+	assert.Equal(t, run.ID, repo.memories[memoryID].Provenance["workflow_run_id"])
+	assert.Equal(t, 2, repo.memories[memoryID].Version)
+
+	result = callTool(t, s, ctx, "complete_workflow_run", map[string]any{"run_id": run.ID, "status": "failed"})
+	assert.True(t, result.IsError)
+
+	result = callTool(t, s, contextutil.WithScopes(ctx, []string{auth.ScopeEvolveRead}), "start_workflow_run", map[string]any{"workflow_id": "wf"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, toolText(result), auth.ScopeEvolveWrite)
+}
@@ -0,0 +1,206 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"evolutionary-mcp/backend/internal/auth"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/services"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func (s *Server) registerWorkflowTools() {
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"list_workflows",
+			mcp.WithDescription("List the latest version of every workflow"),
+			mcp.WithReadOnlyHintAnnotation(true),
+		),
+		s.handleListWorkflows,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"get_workflow",
+			mcp.WithDescription("Get a workflow definition, including its input and output schemas"),
+			mcp.WithString("workflow_id", mcp.Required(), mcp.Description("The stable ID of the workflow")),
+			mcp.WithNumber("version", mcp.Description("The version to get; defaults to the latest")),
+			mcp.WithReadOnlyHintAnnotation(true),
+		),
+		s.handleGetWorkflow,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"get_workflow_tree",
+			mcp.WithDescription("Get a workflow version with its child elements and their details, nested"),
+			mcp.WithString("workflow_id", mcp.Required(), mcp.Description("The stable ID of the workflow")),
+			mcp.WithNumber("version", mcp.Description("The version to get; defaults to the latest")),
+			mcp.WithReadOnlyHintAnnotation(true),
+		),
+		s.handleGetWorkflowTree,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"propose_workflow_version",
+			mcp.WithDescription("Propose a new draft version of a workflow for curators to review. Omitted fields are copied from the latest version"),
+			mcp.WithString("workflow_id", mcp.Required(), mcp.Description("The stable ID of the workflow")),
+			mcp.WithString("name", mcp.Description("The new name")),
+			mcp.WithString("description", mcp.Description("The new description")),
+			mcp.WithObject("input_schema", mcp.Description("The new JSON Schema of the workflow input")),
+			mcp.WithObject("output_schema", mcp.Description("The new JSON Schema of the workflow output")),
+		),
+		s.handleProposeWorkflowVersion,
+	)
+
+	s.mcpServer.AddTool(
+		mcp.NewTool(
+			"validate_workflow_payload",
+			mcp.WithDescription("Check a payload against the input or output JSON Schema of a workflow, e.g. before returning a result. Lists every value that does not match"),
+			mcp.WithString("workflow_id", mcp.Required(), mcp.Description("The stable ID of the workflow")),
+			mcp.WithString("schema", mcp.Required(), mcp.Enum(models.WorkflowSchemaInput, models.WorkflowSchemaOutput), mcp.Description("Which schema to validate against")),
+			mcp.WithAny("payload", mcp.Required(), mcp.Description("The value to validate")),
+			mcp.WithNumber("version", mcp.Description("The version to validate against; defaults to the latest")),
+			mcp.WithReadOnlyHintAnnotation(true),
+		),
+		s.handleValidateWorkflowPayload,
+	)
+}
+
+// requireScope returns an error result unless the caller was granted scope.
+func requireScope(ctx context.Context, scope string) *mcp.CallToolResult {
+	if !contextutil.HasScope(ctx, scope) {
+		return newToolError(ErrorCodeForbidden, "Forbidden: missing required scope: "+scope)
+	}
+	return nil
+}
+
+// versionArgument reads the optional version argument; 0 means latest.
+func versionArgument(request mcp.CallToolRequest) (int, error) {
+	version := request.GetInt("version", 0)
+	if version < 0 {
+		return 0, fmt.Errorf("%w: version must be positive", services.ErrInvalidInput)
+	}
+	return version, nil
+}
+
+func (s *Server) handleListWorkflows(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if denied := requireScope(ctx, auth.ScopeEvolveRead); denied != nil {
+		return denied, nil
+	}
+
+	workflows, err := s.workflowService.ListWorkflows(ctx)
+	if err != nil {
+		return toolError("Failed to list workflows", err), nil
+	}
+
+	return jsonResult(workflows), nil
+}
+
+func (s *Server) handleGetWorkflow(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if denied := requireScope(ctx, auth.ScopeEvolveRead); denied != nil {
+		return denied, nil
+	}
+
+	workflowID, err := request.RequireString("workflow_id")
+	if err != nil || workflowID == "" {
+		return missingParameter("workflow_id"), nil
+	}
+	version, err := versionArgument(request)
+	if err != nil {
+		return toolError("Invalid parameter", err), nil
+	}
+
+	workflow, err := s.workflowService.GetWorkflowVersion(ctx, workflowID, version)
+	if err != nil {
+		return toolError("Failed to get workflow", err), nil
+	}
+
+	return jsonResult(workflow), nil
+}
+
+func (s *Server) handleGetWorkflowTree(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if denied := requireScope(ctx, auth.ScopeEvolveRead); denied != nil {
+		return denied, nil
+	}
+
+	workflowID, err := request.RequireString("workflow_id")
+	if err != nil || workflowID == "" {
+		return missingParameter("workflow_id"), nil
+	}
+	version, err := versionArgument(request)
+	if err != nil {
+		return toolError("Invalid parameter", err), nil
+	}
+
+	tree, err := s.workflowService.GetWorkflowTree(ctx, workflowID, version)
+	if err != nil {
+		return toolError("Failed to get workflow tree", err), nil
+	}
+
+	return jsonResult(tree), nil
+}
+
+func (s *Server) handleProposeWorkflowVersion(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if denied := requireScope(ctx, auth.ScopeEvolveWrite); denied != nil {
+		return denied, nil
+	}
+
+	workflowID, err := request.RequireString("workflow_id")
+	if err != nil || workflowID == "" {
+		return missingParameter("workflow_id"), nil
+	}
+
+	args := request.GetArguments()
+	proposal := &models.Workflow{
+		WorkflowID:  workflowID,
+		Name:        request.GetString("name", ""),
+		Description: request.GetString("description", ""),
+	}
+	if schema, ok := args["input_schema"].(map[string]interface{}); ok {
+		proposal.InputSchema = schema
+	}
+	if schema, ok := args["output_schema"].(map[string]interface{}); ok {
+		proposal.OutputSchema = schema
+	}
+
+	draft, err := s.workflowService.ProposeVersion(ctx, proposal)
+	if err != nil {
+		return toolError("Failed to propose workflow version", err), nil
+	}
+
+	return jsonResult(draft), nil
+}
+
+func (s *Server) handleValidateWorkflowPayload(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if denied := requireScope(ctx, auth.ScopeEvolveRead); denied != nil {
+		return denied, nil
+	}
+
+	workflowID, err := request.RequireString("workflow_id")
+	if err != nil || workflowID == "" {
+		return missingParameter("workflow_id"), nil
+	}
+	schema, err := request.RequireString("schema")
+	if err != nil || schema == "" {
+		return missingParameter("schema"), nil
+	}
+	payload, ok := request.GetArguments()["payload"]
+	if !ok {
+		return missingParameter("payload"), nil
+	}
+	version, err := versionArgument(request)
+	if err != nil {
+		return toolError("Invalid parameter", err), nil
+	}
+
+	result, err := s.workflowService.ValidatePayload(ctx, workflowID, version, schema, payload)
+	if err != nil {
+		return toolError("Failed to validate payload", err), nil
+	}
+
+	return jsonResult(result), nil
+}
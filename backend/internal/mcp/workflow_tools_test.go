@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/auth"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func callTool(t *testing.T, s *Server, ctx context.Context, name string, args map[string]any) *mcp.CallToolResult {
+	t.Helper()
+	msg, err := json.Marshal(map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "tools/call",
+		"params":  map[string]any{"name": name, "arguments": args},
+	})
+	require.NoError(t, err)
+	resp, ok := s.GetMCPServer().HandleMessage(ctx, msg).(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	return resp.Result.(*mcp.CallToolResult)
+}
+
+func toolText(result *mcp.CallToolResult) string {
+	return result.Content[0].(mcp.TextContent).Text
+}
+
+func TestWorkflowTools_RequireScopes(t *testing.T) {
+	s, _ := newTestServer()
+	ctx := contextutil.WithScopes(contextutil.WithTenant(context.Background(), "acme"), []string{auth.ScopeEvolveRead})
+
+	result := callTool(t, s, ctx, "get_workflow", map[string]any{"workflow_id": "wf", "version": 1})
+	require.False(t, result.IsError, toolText(result))
+	assert.Contains(t, toolText(result), `"name":"onboarding"`)
+
+	result = callTool(t, s, ctx, "propose_workflow_version", map[string]any{"workflow_id": "wf", "name": "onboarding v3"})
+	assert.True(t, result.IsError)
+	assert.Contains(t, toolText(result), auth.ScopeEvolveWrite)
+
+	result = callTool(t, s, contextutil.WithTenant(context.Background(), "acme"), "list_workflows", nil)
+	assert.True(t, result.IsError)
+}
+
+func TestWorkflowTools_ProposeWorkflowVersion(t *testing.T) {
+	s, repo := newTestServer()
+	ctx := contextutil.WithScopes(contextutil.WithTenant(context.Background(), "acme"), []string{auth.ScopeEvolveRead, auth.ScopeEvolveWrite})
+
+	result := callTool(t, s, ctx, "propose_workflow_version", map[string]any{
+		"workflow_id":  "wf",
+		"description":  "adds a welcome call",
+		"input_schema": map[string]any{"type": "object"},
+	})
+	require.False(t, result.IsError, toolText(result))
+
+	var draft models.Workflow
+	require.NoError(t, json.Unmarshal([]byte(toolText(result)), &draft))
+	assert.Equal(t, 3, draft.Version)
+	assert.Equal(t, models.WorkflowStatusDraft, draft.Status)
+	assert.Equal(t, "onboarding v2", draft.Name)
+	assert.Len(t, repo.workflows, 3)
+
+	result = callTool(t, s, ctx, "get_workflow", map[string]any{"workflow_id": "wf"})
+	assert.Contains(t, toolText(result), `"version":3`)
+
+	result = callTool(t, s, ctx, "propose_workflow_version", map[string]any{"workflow_id": "missing"})
+	assert.True(t, result.IsError)
+}
+
+func TestWorkflowTools_GetWorkflowTree(t *testing.T) {
+	s, repo := newTestServer()
+	ctx := contextutil.WithScopes(contextutil.WithTenant(context.Background(), "acme"), []string{auth.ScopeEvolveRead})
+	parent := "w2"
+	repo.workflows = append(repo.workflows, &models.Workflow{ID: "e1", WorkflowID: "step", Version: 1, TenantID: "acme", Name: "collect details", ParentID: &parent})
+
+	result := callTool(t, s, ctx, "get_workflow_tree", map[string]any{"workflow_id": "wf"})
+	require.False(t, result.IsError, toolText(result))
+
+	var tree models.WorkflowNode
+	require.NoError(t, json.Unmarshal([]byte(toolText(result)), &tree))
+	assert.Equal(t, "w2", tree.ID)
+	require.Len(t, tree.Children, 1)
+	assert.Equal(t, "collect details", tree.Children[0].Name)
+}
+
+func TestWorkflowTools_ValidateWorkflowPayload(t *testing.T) {
+	s, repo := newTestServer()
+	ctx := contextutil.WithScopes(contextutil.WithTenant(context.Background(), "acme"), []string{auth.ScopeEvolveRead})
+	repo.workflows[1].OutputSchema = map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"welcome_sent"},
+		"properties": map[string]interface{}{
+			"welcome_sent": map[string]interface{}{"type": "boolean"},
+		},
+	}
+
+	result := callTool(t, s, ctx, "validate_workflow_payload", map[string]any{
+		"workflow_id": "wf",
+		"schema":      "output",
+		"payload":     map[string]any{"welcome_sent": "yes"},
+	})
+	require.False(t, result.IsError, toolText(result))
+
+	var validation models.SchemaValidation
+	require.NoError(t, json.Unmarshal([]byte(toolText(result)), &validation))
+	assert.Equal(t, 2, validation.Version)
+	assert.False(t, validation.Valid)
+	require.Len(t, validation.Errors, 1)
+	assert.Equal(t, "/welcome_sent", validation.Errors[0].Path)
+	assert.Equal(t, "/properties/welcome_sent/type", validation.Errors[0].SchemaPath)
+
+	result = callTool(t, s, ctx, "validate_workflow_payload", map[string]any{"workflow_id": "wf", "schema": "output"})
+	assert.True(t, result.IsError)
+}
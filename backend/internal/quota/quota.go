@@ -0,0 +1,351 @@
+// Package quota bounds how much of the service a single tenant may consume:
+// the number of stored memories, embeddings generated per minute and request
+// rates on the REST and MCP surfaces.
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/time/rate"
+)
+
+// ErrExceeded is wrapped by every *ExceededError.
+var ErrExceeded = errors.New("quota exceeded")
+
+// Limit names, used in errors and as the "limit" metric attribute.
+const (
+	LimitMemories            = "memories"
+	LimitEmbeddingsPerMinute = "embeddings_per_minute"
+	LimitRESTRequests        = "rest_requests_per_second"
+	LimitMCPRequests         = "mcp_requests_per_second"
+)
+
+// Surface identifies the protocol a request arrived on.
+type Surface string
+
+const (
+	SurfaceREST Surface = "rest"
+	SurfaceMCP  Surface = "mcp"
+)
+
+// ExceededError reports which limit a tenant ran into and, for rate limits,
+// how long the caller should wait before retrying.
+type ExceededError struct {
+	TenantID   string
+	Limit      string
+	Max        float64
+	RetryAfter time.Duration
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s: tenant %s reached its %s limit of %g", ErrExceeded, e.TenantID, e.Limit, e.Max)
+}
+
+func (e *ExceededError) Unwrap() error { return ErrExceeded }
+
+// ProblemType identifies quota errors in RFC 7807 responses.
+const ProblemType = "urn:evolutionary-mcp:problem:quota-exceeded"
+
+// Problem renders the error as RFC 7807 problem details.
+func (e *ExceededError) Problem() models.ProblemDetails {
+	return models.ProblemDetails{
+		Type:   ProblemType,
+		Title:  "Quota exceeded",
+		Status: http.StatusTooManyRequests,
+		Detail: e.Error(),
+	}
+}
+
+// Limits holds the server-wide defaults. Zero means unlimited.
+type Limits struct {
+	MaxMemories           int
+	EmbeddingsPerMinute   int
+	RESTRequestsPerSecond float64
+	MCPRequestsPerSecond  float64
+}
+
+// Store is the subset of the repository the enforcer needs.
+type Store interface {
+	GetTenantByID(ctx context.Context, id string) (*models.Tenant, error)
+	CountMemories(ctx context.Context, tenantID string) (int, error)
+}
+
+// Logger defines the logging interface compatible with the application logger.
+type Logger interface {
+	Error(msg string, args ...any)
+}
+
+// overrideTTL bounds how long per-tenant overrides are cached before the
+// tenant is reloaded.
+const overrideTTL = time.Minute
+
+type tenantState struct {
+	limits     Limits
+	loadedAt   time.Time
+	rest       *rate.Limiter
+	mcp        *rate.Limiter
+	embeddings *rate.Limiter
+}
+
+// Enforcer applies per-tenant limits. A nil *Enforcer allows everything.
+type Enforcer struct {
+	defaults Limits
+	store    Store
+	logger   Logger
+	now      func() time.Time
+
+	mu      sync.Mutex
+	tenants map[string]*tenantState
+
+	requests   metric.Int64Counter
+	embeddings metric.Int64Counter
+	rejections metric.Int64Counter
+}
+
+// NewEnforcer creates an Enforcer applying defaults to every tenant unless the
+// tenant carries its own overrides.
+func NewEnforcer(defaults Limits, store Store, logger Logger) *Enforcer {
+	meter := otel.Meter("evolutionary-mcp/backend/quota")
+
+	requests, err := meter.Int64Counter("tenant_requests_total", metric.WithDescription("Requests admitted per tenant and surface"))
+	if err != nil {
+		logger.Error("failed to create tenant_requests_total metric", "error", err)
+	}
+	embeddings, err := meter.Int64Counter("tenant_embeddings_total", metric.WithDescription("Embeddings generated per tenant"))
+	if err != nil {
+		logger.Error("failed to create tenant_embeddings_total metric", "error", err)
+	}
+	rejections, err := meter.Int64Counter("tenant_quota_rejections_total", metric.WithDescription("Requests rejected because a tenant exceeded a quota"))
+	if err != nil {
+		logger.Error("failed to create tenant_quota_rejections_total metric", "error", err)
+	}
+
+	return &Enforcer{
+		defaults:   defaults,
+		store:      store,
+		logger:     logger,
+		now:        time.Now,
+		tenants:    make(map[string]*tenantState),
+		requests:   requests,
+		embeddings: embeddings,
+		rejections: rejections,
+	}
+}
+
+// AllowRequest admits one request from tenantID on the given surface.
+func (e *Enforcer) AllowRequest(ctx context.Context, tenantID string, surface Surface) error {
+	if e == nil {
+		return nil
+	}
+	st := e.state(ctx, tenantID)
+
+	limiter, limit, max := st.rest, LimitRESTRequests, st.limits.RESTRequestsPerSecond
+	if surface == SurfaceMCP {
+		limiter, limit, max = st.mcp, LimitMCPRequests, st.limits.MCPRequestsPerSecond
+	}
+	if err := e.take(ctx, tenantID, limiter, limit, max); err != nil {
+		return err
+	}
+
+	if e.requests != nil {
+		e.requests.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("tenant_id", tenantID),
+			attribute.String("surface", string(surface)),
+		))
+	}
+	return nil
+}
+
+// AllowEmbedding admits one call to the embedding model for tenantID.
+func (e *Enforcer) AllowEmbedding(ctx context.Context, tenantID string) error {
+	return e.AllowEmbeddings(ctx, tenantID, 1)
+}
+
+// AllowEmbeddings admits n embeddings for tenantID, all or none, e.g. for a
+// batch embedded in one call to the model.
+func (e *Enforcer) AllowEmbeddings(ctx context.Context, tenantID string, n int) error {
+	if e == nil {
+		return nil
+	}
+	st := e.state(ctx, tenantID)
+	if err := e.takeN(ctx, tenantID, st.embeddings, n, LimitEmbeddingsPerMinute, float64(st.limits.EmbeddingsPerMinute)); err != nil {
+		return err
+	}
+
+	if e.embeddings != nil {
+		e.embeddings.Add(ctx, int64(n), metric.WithAttributes(attribute.String("tenant_id", tenantID)))
+	}
+	return nil
+}
+
+// CheckMemories fails once tenantID stores as many memories as it may.
+func (e *Enforcer) CheckMemories(ctx context.Context, tenantID string) error {
+	return e.CheckNewMemories(ctx, tenantID, 1)
+}
+
+// CheckNewMemories fails unless tenantID may store n more memories.
+func (e *Enforcer) CheckNewMemories(ctx context.Context, tenantID string, n int) error {
+	if e == nil {
+		return nil
+	}
+	max := e.state(ctx, tenantID).limits.MaxMemories
+	if max <= 0 {
+		return nil
+	}
+
+	count, err := e.store.CountMemories(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("failed to count memories: %w", err)
+	}
+	if count+n > max {
+		e.reject(ctx, tenantID, LimitMemories)
+		return &ExceededError{TenantID: tenantID, Limit: LimitMemories, Max: float64(max)}
+	}
+	return nil
+}
+
+// take consumes a token from limiter, which is nil when the limit is disabled.
+func (e *Enforcer) take(ctx context.Context, tenantID string, limiter *rate.Limiter, limit string, max float64) error {
+	return e.takeN(ctx, tenantID, limiter, 1, limit, max)
+}
+
+// takeN consumes n tokens from limiter at once. Requests for more tokens than
+// the limiter's burst can never succeed and are rejected without a retry hint.
+func (e *Enforcer) takeN(ctx context.Context, tenantID string, limiter *rate.Limiter, n int, limit string, max float64) error {
+	if limiter == nil {
+		return nil
+	}
+	r := limiter.ReserveN(e.now(), n)
+	if !r.OK() {
+		e.reject(ctx, tenantID, limit)
+		return &ExceededError{TenantID: tenantID, Limit: limit, Max: max}
+	}
+	if delay := r.DelayFrom(e.now()); delay > 0 {
+		r.CancelAt(e.now())
+		e.reject(ctx, tenantID, limit)
+		return &ExceededError{TenantID: tenantID, Limit: limit, Max: max, RetryAfter: delay}
+	}
+	return nil
+}
+
+func (e *Enforcer) reject(ctx context.Context, tenantID, limit string) {
+	if e.rejections != nil {
+		e.rejections.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("tenant_id", tenantID),
+			attribute.String("limit", limit),
+		))
+	}
+}
+
+// state returns a snapshot of the limits and limiters for tenantID, reloading
+// the tenant's overrides when the cached copy is older than overrideTTL.
+// Limiters keep their token balance across reloads.
+func (e *Enforcer) state(ctx context.Context, tenantID string) tenantState {
+	e.mu.Lock()
+	if st, ok := e.tenants[tenantID]; ok && e.now().Sub(st.loadedAt) < overrideTTL {
+		snapshot := *st
+		e.mu.Unlock()
+		return snapshot
+	}
+	e.mu.Unlock()
+
+	limits, err := e.limitsFor(ctx, tenantID)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	st, ok := e.tenants[tenantID]
+	if err != nil {
+		// Keep the last known limits, or the defaults, and retry on the next
+		// request rather than caching a transient failure.
+		e.logger.Error("failed to load tenant quotas", "tenant_id", tenantID, "error", err)
+		if ok {
+			return *st
+		}
+	}
+	if !ok {
+		st = &tenantState{}
+		e.tenants[tenantID] = st
+	}
+	st.limits = limits
+	if err == nil {
+		st.loadedAt = e.now()
+	}
+	st.rest = perSecond(st.rest, limits.RESTRequestsPerSecond)
+	st.mcp = perSecond(st.mcp, limits.MCPRequestsPerSecond)
+	st.embeddings = perMinute(st.embeddings, limits.EmbeddingsPerMinute)
+	return *st
+}
+
+// limitsFor merges a tenant's overrides onto the defaults. Unknown tenants get
+// the defaults; other errors are returned along with the defaults.
+func (e *Enforcer) limitsFor(ctx context.Context, tenantID string) (Limits, error) {
+	limits := e.defaults
+	tenant, err := e.store.GetTenantByID(ctx, tenantID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return limits, nil
+	}
+	if err != nil {
+		return limits, fmt.Errorf("failed to load tenant %s: %w", tenantID, err)
+	}
+	if tenant == nil {
+		return limits, nil
+	}
+
+	q := tenant.Quotas
+	if q.MaxMemories != nil {
+		limits.MaxMemories = *q.MaxMemories
+	}
+	if q.EmbeddingsPerMinute != nil {
+		limits.EmbeddingsPerMinute = *q.EmbeddingsPerMinute
+	}
+	if q.RESTRequestsPerSecond != nil {
+		limits.RESTRequestsPerSecond = *q.RESTRequestsPerSecond
+	}
+	if q.MCPRequestsPerSecond != nil {
+		limits.MCPRequestsPerSecond = *q.MCPRequestsPerSecond
+	}
+	return limits, nil
+}
+
+// perSecond returns a limiter allowing rps requests per second with a burst of
+// one second's worth, reusing l when possible. It returns nil when rps is 0.
+func perSecond(l *rate.Limiter, rps float64) *rate.Limiter {
+	if rps <= 0 {
+		return nil
+	}
+	return retune(l, rate.Limit(rps), int(math.Ceil(rps)))
+}
+
+// perMinute returns a limiter allowing n events per minute, all of which may
+// be spent at once. It returns nil when n is 0.
+func perMinute(l *rate.Limiter, n int) *rate.Limiter {
+	if n <= 0 {
+		return nil
+	}
+	return retune(l, rate.Limit(float64(n)/60), n)
+}
+
+func retune(l *rate.Limiter, limit rate.Limit, burst int) *rate.Limiter {
+	if l == nil {
+		return rate.NewLimiter(limit, burst)
+	}
+	if l.Limit() != limit {
+		l.SetLimit(limit)
+	}
+	if l.Burst() != burst {
+		l.SetBurst(burst)
+	}
+	return l
+}
@@ -0,0 +1,145 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type NoOpLogger struct{}
+
+func (l *NoOpLogger) Error(msg string, args ...any) {}
+
+type fakeStore struct {
+	tenants  map[string]*models.Tenant
+	memories map[string]int
+	err      error
+}
+
+func (f *fakeStore) GetTenantByID(ctx context.Context, id string) (*models.Tenant, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if t, ok := f.tenants[id]; ok {
+		return t, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (f *fakeStore) CountMemories(ctx context.Context, tenantID string) (int, error) {
+	return f.memories[tenantID], nil
+}
+
+func newTestEnforcer(defaults Limits, store *fakeStore) (*Enforcer, *time.Time) {
+	e := NewEnforcer(defaults, store, &NoOpLogger{})
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	e.now = func() time.Time { return now }
+	return e, &now
+}
+
+func TestAllowRequest_RateLimitsPerTenant(t *testing.T) {
+	e, now := newTestEnforcer(Limits{RESTRequestsPerSecond: 2}, &fakeStore{})
+	ctx := context.Background()
+
+	require.NoError(t, e.AllowRequest(ctx, "a", SurfaceREST))
+	require.NoError(t, e.AllowRequest(ctx, "a", SurfaceREST))
+
+	err := e.AllowRequest(ctx, "a", SurfaceREST)
+	var exceeded *ExceededError
+	require.ErrorAs(t, err, &exceeded)
+	assert.ErrorIs(t, err, ErrExceeded)
+	assert.Equal(t, LimitRESTRequests, exceeded.Limit)
+	assert.Greater(t, exceeded.RetryAfter, time.Duration(0))
+
+	// Other tenants and surfaces have their own budget
+	assert.NoError(t, e.AllowRequest(ctx, "b", SurfaceREST))
+	assert.NoError(t, e.AllowRequest(ctx, "a", SurfaceMCP))
+
+	// Tokens refill over time
+	*now = now.Add(time.Second)
+	assert.NoError(t, e.AllowRequest(ctx, "a", SurfaceREST))
+}
+
+func TestAllowEmbedding_TenantOverride(t *testing.T) {
+	one := 1
+	store := &fakeStore{tenants: map[string]*models.Tenant{
+		"small": {ID: "small", Quotas: models.TenantQuotas{EmbeddingsPerMinute: &one}},
+	}}
+	e, _ := newTestEnforcer(Limits{EmbeddingsPerMinute: 100}, store)
+	ctx := context.Background()
+
+	require.NoError(t, e.AllowEmbedding(ctx, "small"))
+	assert.ErrorIs(t, e.AllowEmbedding(ctx, "small"), ErrExceeded)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, e.AllowEmbedding(ctx, "default"))
+	}
+}
+
+func TestOverridesSurviveStoreErrors(t *testing.T) {
+	one := 1
+	store := &fakeStore{
+		tenants:  map[string]*models.Tenant{"small": {ID: "small", Quotas: models.TenantQuotas{MaxMemories: &one}}},
+		memories: map[string]int{"small": 1},
+	}
+	e, now := newTestEnforcer(Limits{MaxMemories: 100}, store)
+	ctx := context.Background()
+
+	require.ErrorIs(t, e.CheckMemories(ctx, "small"), ErrExceeded)
+
+	// A failing lookup keeps the override instead of falling back to the
+	// defaults, and is retried on the next request.
+	store.err = errors.New("connection refused")
+	*now = now.Add(2 * overrideTTL)
+	assert.ErrorIs(t, e.CheckMemories(ctx, "small"), ErrExceeded)
+
+	two := 2
+	store.tenants["small"].Quotas.MaxMemories = &two
+	store.err = nil
+	assert.NoError(t, e.CheckMemories(ctx, "small"))
+}
+
+func TestAllowEmbeddings_ChargesWholeBatch(t *testing.T) {
+	e, now := newTestEnforcer(Limits{EmbeddingsPerMinute: 10}, &fakeStore{})
+	ctx := context.Background()
+
+	require.NoError(t, e.AllowEmbeddings(ctx, "a", 8))
+	assert.ErrorIs(t, e.AllowEmbeddings(ctx, "a", 3), ErrExceeded)
+	require.NoError(t, e.AllowEmbeddings(ctx, "a", 2))
+
+	// A batch larger than the limit can never be admitted.
+	*now = now.Add(time.Hour)
+	assert.ErrorIs(t, e.AllowEmbeddings(ctx, "a", 11), ErrExceeded)
+}
+
+func TestCheckMemories(t *testing.T) {
+	unlimited := 0
+	store := &fakeStore{
+		tenants:  map[string]*models.Tenant{"vip": {ID: "vip", Quotas: models.TenantQuotas{MaxMemories: &unlimited}}},
+		memories: map[string]int{"full": 5, "vip": 500},
+	}
+	e, _ := newTestEnforcer(Limits{MaxMemories: 5}, store)
+	ctx := context.Background()
+
+	assert.ErrorIs(t, e.CheckMemories(ctx, "full"), ErrExceeded)
+	assert.NoError(t, e.CheckMemories(ctx, "empty"))
+	assert.NoError(t, e.CheckMemories(ctx, "vip"))
+
+	store.memories["almost"] = 3
+	assert.NoError(t, e.CheckNewMemories(ctx, "almost", 2))
+	assert.ErrorIs(t, e.CheckNewMemories(ctx, "almost", 3), ErrExceeded)
+}
+
+func TestNilEnforcerAllowsEverything(t *testing.T) {
+	var e *Enforcer
+	ctx := context.Background()
+	assert.NoError(t, e.AllowRequest(ctx, "a", SurfaceREST))
+	assert.NoError(t, e.AllowEmbedding(ctx, "a"))
+	assert.NoError(t, e.CheckMemories(ctx, "a"))
+}
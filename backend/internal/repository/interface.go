@@ -2,7 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"evolutionary-mcp/backend/pkg/models"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when the requested record does not exist.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a write collides with an existing record.
+	ErrConflict = errors.New("conflict")
+	// ErrUnauthorized is returned when the context carries no tenant.
+	ErrUnauthorized = errors.New("unauthorized")
 )
 
 // Memory represents a single memory entry.
@@ -28,30 +39,146 @@ type Repository interface {
 	Search(ctx context.Context, embedding []float32) ([]*Memory, error)
 	// ListMemories lists all memories for a tenant.
 	ListMemories(ctx context.Context, tenantID string) ([]*Memory, error)
-	// Update updates an existing memory.
+	// CountMemories returns how many memories a tenant currently stores.
+	CountMemories(ctx context.Context, tenantID string) (int, error)
+	// Update stores memory as its new version memory.Version, provided the
+	// stored memory is still at the version before; otherwise it returns
+	// ErrConflict.
 	Update(ctx context.Context, memory *Memory) error
+	// GetBatch retrieves the memories with the given IDs. Unknown IDs are
+	// left out, and the order of the result is unspecified.
+	GetBatch(ctx context.Context, ids []string) ([]*Memory, error)
+	// SaveBatch saves several memories in one transaction; either all are
+	// saved or none.
+	SaveBatch(ctx context.Context, memories []*Memory) error
+	// UpdateBatch updates several memories in one transaction; either all are
+	// updated or none. Versions are checked as by Update.
+	UpdateBatch(ctx context.Context, memories []*Memory) error
 	// Ping checks the connection to the storage backend.
 	Ping(ctx context.Context) error
 	// CreateWorkflow creates a new workflow or evolves an existing one (append-only).
 	CreateWorkflow(ctx context.Context, workflow *models.Workflow) error
-	// UpdateWorkflow updates an existing workflow version (non-versioning).
+	// UpdateWorkflow updates an existing workflow version (non-versioning) if
+	// it is still at workflow.Revision, which it then increments; otherwise it
+	// returns ErrConflict.
 	UpdateWorkflow(ctx context.Context, workflow *models.Workflow) error
 	// GetWorkflow retrieves a specific workflow version by ID.
 	GetWorkflow(ctx context.Context, id string) (*models.Workflow, error)
+	// GetWorkflowVersion retrieves a workflow version of a tenant by its stable
+	// workflow ID and version number, or returns ErrNotFound.
+	GetWorkflowVersion(ctx context.Context, tenantID, workflowID string, version int) (*models.Workflow, error)
 	ListWorkflows(ctx context.Context) ([]*models.Workflow, error)
+	// ListWorkflowVersions returns every version of a workflow of a tenant,
+	// newest first.
+	ListWorkflowVersions(ctx context.Context, tenantID, workflowID string) ([]*models.Workflow, error)
+	// RollbackWorkflow copies a version of a workflow into a new draft latest
+	// version, created by createdBy.
+	RollbackWorkflow(ctx context.Context, tenantID, workflowID string, version int, createdBy string) (*models.Workflow, error)
+	// TransitionWorkflow moves a workflow version from transition.FromStatus to
+	// transition.ToStatus and records the transition, in one transaction. It
+	// returns ErrConflict when the version is no longer in FromStatus.
+	TransitionWorkflow(ctx context.Context, transition *models.WorkflowTransition) error
+	// GetWorkflowSubtree returns the workflow version id of tenantID and every
+	// element nested under it, recursively, parents before children and
+	// siblings in position order.
+	GetWorkflowSubtree(ctx context.Context, tenantID, id string) ([]*models.Workflow, error)
+	// MoveWorkflowElements applies moves of draft versions of tenantID in
+	// order in one transaction, renumbering the draft siblings at each
+	// destination. Other versions are never changed.
+	MoveWorkflowElements(ctx context.Context, tenantID string, moves []models.WorkflowMove) error
+	// CloneWorkflowSubtree copies the subtree under the workflow version id of
+	// tenantID into new draft versions of each of its workflows, nested like
+	// the original, and returns the copies in GetWorkflowSubtree order.
+	CloneWorkflowSubtree(ctx context.Context, tenantID, id string, createdBy string) ([]*models.Workflow, error)
+	// ImportWorkflows inserts workflow versions with their IDs, version
+	// numbers and statuses, parents first, and then grounding rules linked to
+	// them, in one transaction. Each version gets a transition by actor into
+	// its status.
+	ImportWorkflows(ctx context.Context, workflows []*models.Workflow, rules []*models.GroundingRule, actor string) error
+	// ListWorkflowTransitions returns the status changes of every version of
+	// a workflow of a tenant, newest first.
+	ListWorkflowTransitions(ctx context.Context, tenantID, workflowID string) ([]*models.WorkflowTransition, error)
+	// CreateWorkflowRun records the start of a run of a workflow version.
+	CreateWorkflowRun(ctx context.Context, run *models.WorkflowRun) error
+	// GetWorkflowRun returns a workflow run, or ErrNotFound.
+	GetWorkflowRun(ctx context.Context, id string) (*models.WorkflowRun, error)
+	// ListWorkflowRuns returns the runs of every version of a workflow of a
+	// tenant, newest first.
+	ListWorkflowRuns(ctx context.Context, tenantID, workflowID string) ([]*models.WorkflowRun, error)
+	// UpdateWorkflowRun stores the progress of a run that is still running,
+	// and links the memories it created to it. It returns ErrConflict when the
+	// run has already completed.
+	UpdateWorkflowRun(ctx context.Context, run *models.WorkflowRun) error
 
 	// GroundingRule operations
 	CreateGroundingRule(ctx context.Context, rule *models.GroundingRule) error
 	GetGroundingRule(ctx context.Context, id string) (*models.GroundingRule, error)
+	// ListGroundingRules lists the rules of a tenant and the global rules,
+	// leaving out archived ones.
 	ListGroundingRules(ctx context.Context, tenantID string) ([]*models.GroundingRule, error)
-	UpdateGroundingRule(ctx context.Context, rule *models.GroundingRule) error
-	DeleteGroundingRule(ctx context.Context, id string) error
+	// ArchiveGroundingRule retires a rule of tenantID and rejects the pending
+	// changes to it. It returns ErrNotFound for rules of other tenants.
+	ArchiveGroundingRule(ctx context.Context, tenantID, id, actor string) error
 	SearchGroundingRules(ctx context.Context, tenantID string, embedding []float32) ([]*models.GroundingRule, error)
 
+	// Grounding rule versions. Changes to a rule are proposed as pending
+	// versions and put into effect by approving them.
+	CreateGroundingRuleVersion(ctx context.Context, version *models.GroundingRuleVersion) error
+	// GetGroundingRuleVersion returns a version of a rule, or ErrNotFound.
+	GetGroundingRuleVersion(ctx context.Context, ruleID string, version int) (*models.GroundingRuleVersion, error)
+	// ListGroundingRuleVersions returns every version of a rule, newest first.
+	ListGroundingRuleVersions(ctx context.Context, ruleID string) ([]*models.GroundingRuleVersion, error)
+	// ApproveGroundingRuleVersion puts a pending version into effect if the
+	// rule is still at the version it was based on, and rejects the other
+	// pending versions as superseded. Otherwise it returns ErrConflict.
+	ApproveGroundingRuleVersion(ctx context.Context, ruleID string, version int, reviewer, comment string) (*models.GroundingRule, error)
+	// RejectGroundingRuleVersion rejects a pending version, or returns
+	// ErrConflict.
+	RejectGroundingRuleVersion(ctx context.Context, ruleID string, version int, reviewer, comment string) (*models.GroundingRuleVersion, error)
+
+	// Usage analytics
+	RecordRecall(ctx context.Context, event *models.RecallEvent) error
+	RecordFeedback(ctx context.Context, event *models.FeedbackEvent) error
+	// RecordFeedbackBatch stores several feedback events in one transaction.
+	RecordFeedbackBatch(ctx context.Context, events []*models.FeedbackEvent) error
+	// ListFeedbackEvents returns the feedback given on a memory of a tenant,
+	// newest first.
+	ListFeedbackEvents(ctx context.Context, tenantID, memoryID string) ([]*models.FeedbackEvent, error)
+	// ListMemoryRecalls returns the latest recalls of a tenant that returned a
+	// memory, newest first, with the versions of the grounding rules that
+	// matched.
+	ListMemoryRecalls(ctx context.Context, tenantID, memoryID string, limit int) ([]*models.MemoryRecall, error)
+	// GetTenantStats aggregates a tenant's memories and the usage events recorded since the given time.
+	GetTenantStats(ctx context.Context, tenantID string, since time.Time) (*models.TenantStats, error)
+	// GetWorkflowVersionPerformance aggregates the memories, feedback and runs
+	// of every version of a workflow, oldest version first. Feedback and runs
+	// are counted since the given time.
+	GetWorkflowVersionPerformance(ctx context.Context, tenantID, workflowID string, since time.Time) ([]models.VersionPerformance, error)
+
 	// Tenant operations
 	GetTenantByDomain(ctx context.Context, domain string) (*models.Tenant, error)
 	GetTenantByID(ctx context.Context, id string) (*models.Tenant, error)
 	CreateTenant(ctx context.Context, tenant *models.Tenant) error
+	ListTenants(ctx context.Context) ([]*models.Tenant, error)
+	// UpdateTenant updates the name, branding, status and quota overrides of a tenant.
+	UpdateTenant(ctx context.Context, tenant *models.Tenant) error
+	// DeleteTenant removes a tenant together with all of its data.
+	DeleteTenant(ctx context.Context, id string) error
+	AddTenantDomain(ctx context.Context, tenantID, domain string) error
+	RemoveTenantDomain(ctx context.Context, tenantID, domain string) error
+	// ListTenantsForMember returns the tenants a user is an explicit member of,
+	// oldest membership first.
+	ListTenantsForMember(ctx context.Context, email string) ([]*models.Tenant, error)
+	ListTenantMembers(ctx context.Context, tenantID string) ([]*models.TenantMember, error)
+	AddTenantMember(ctx context.Context, member *models.TenantMember) error
+	RemoveTenantMember(ctx context.Context, tenantID, email string) error
+
+	// API keys
+	CreateAPIKey(ctx context.Context, key *models.APIKey, hash string) error
+	// GetAPIKeyByHash returns the key with the given hash, including revoked keys.
+	GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error)
+	ListAPIKeys(ctx context.Context, tenantID string) ([]*models.APIKey, error)
+	RevokeAPIKey(ctx context.Context, tenantID, id string) error
 }
 
 // MemoryStore is an interface for storing and retrieving memories.
@@ -62,6 +189,17 @@ type MemoryStore interface {
 	Get(ctx context.Context, id string) (*Memory, error)
 	// Search searches for memories based on a query.
 	Search(ctx context.Context, embedding []float32) ([]*Memory, error)
-	// Update updates an existing memory.
+	// Update stores memory as its new version memory.Version, provided the
+	// stored memory is still at the version before; otherwise it returns
+	// ErrConflict.
 	Update(ctx context.Context, memory *Memory) error
+	// GetBatch retrieves the memories with the given IDs. Unknown IDs are
+	// left out, and the order of the result is unspecified.
+	GetBatch(ctx context.Context, ids []string) ([]*Memory, error)
+	// SaveBatch saves several memories in one transaction; either all are
+	// saved or none.
+	SaveBatch(ctx context.Context, memories []*Memory) error
+	// UpdateBatch updates several memories in one transaction; either all are
+	// updated or none. Versions are checked as by Update.
+	UpdateBatch(ctx context.Context, memories []*Memory) error
 }
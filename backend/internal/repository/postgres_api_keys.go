@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"evolutionary-mcp/backend/pkg/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const apiKeyColumns = "id, tenant_id, name, prefix, scopes, created_at, revoked_at"
+
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	var k models.APIKey
+	if err := row.Scan(&k.ID, &k.TenantID, &k.Name, &k.Prefix, &k.Scopes, &k.CreatedAt, &k.RevokedAt); err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// CreateAPIKey stores a new API key under the hash of its secret.
+func (s *PostgresMemoryStore) CreateAPIKey(ctx context.Context, key *models.APIKey, hash string) error {
+	err := s.db.QueryRow(ctx, `
+		INSERT INTO api_keys (tenant_id, name, prefix, key_hash, scopes)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at`, key.TenantID, key.Name, key.Prefix, hash, key.Scopes).Scan(&key.ID, &key.CreatedAt)
+	if isForeignKeyViolation(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// GetAPIKeyByHash returns the key with the given hash, including revoked keys.
+func (s *PostgresMemoryStore) GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	key, err := scanAPIKey(s.db.QueryRow(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE key_hash = $1", hash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return key, err
+}
+
+// ListAPIKeys lists a tenant's API keys, newest first.
+func (s *PostgresMemoryStore) ListAPIKeys(ctx context.Context, tenantID string) ([]*models.APIKey, error) {
+	rows, err := s.db.Query(ctx, "SELECT "+apiKeyColumns+" FROM api_keys WHERE tenant_id = $1 ORDER BY created_at DESC, id", tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]*models.APIKey, 0)
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey marks a key as revoked. Revoking a key twice is not an error.
+func (s *PostgresMemoryStore) RevokeAPIKey(ctx context.Context, tenantID, id string) error {
+	tag, err := s.db.Exec(ctx, "UPDATE api_keys SET revoked_at = COALESCE(revoked_at, NOW()) WHERE tenant_id = $1 AND id = $2", tenantID, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
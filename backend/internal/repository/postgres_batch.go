@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// GetBatch retrieves the memories with the given IDs.
+func (s *PostgresMemoryStore) GetBatch(ctx context.Context, ids []string) ([]*Memory, error) {
+	s.logger.Debug("Getting memories", "count", len(ids))
+	memories := make([]*Memory, 0, len(ids))
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "SELECT id, tenant_id, content, embedding, confidence, version, provenance, workflow_id FROM memories WHERE id = ANY($1)", ids)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var memory Memory
+			var workflowID *string
+			if err := rows.Scan(&memory.ID, &memory.TenantID, &memory.Content, &memory.Embedding, &memory.Confidence, &memory.Version, &memory.Provenance, &workflowID); err != nil {
+				return err
+			}
+			if workflowID != nil {
+				memory.WorkflowID = *workflowID
+			}
+			memories = append(memories, &memory)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return memories, nil
+}
+
+// SaveBatch saves several memories in one transaction and one round trip.
+func (s *PostgresMemoryStore) SaveBatch(ctx context.Context, memories []*Memory) error {
+	s.logger.Debug("Saving memories", "count", len(memories))
+	batch := &pgx.Batch{}
+	for _, memory := range memories {
+		batch.Queue("INSERT INTO memories (id, tenant_id, content, embedding, confidence, version, provenance, workflow_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+			memory.ID, memory.TenantID, memory.Content, memory.Embedding, memory.Confidence, memory.Version, memory.Provenance, nullableWorkflowID(memory.WorkflowID))
+	}
+
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		return tx.SendBatch(ctx, batch).Close()
+	})
+	if err == nil && s.memoriesStored != nil {
+		for _, memory := range memories {
+			s.memoriesStored.Add(ctx, 1, metric.WithAttributes(attribute.String("workflow_id", memory.WorkflowID)))
+		}
+	}
+	return err
+}
+
+// UpdateBatch updates several memories in one transaction and one round trip.
+// Like Update, each memory must still be at the version before its new one;
+// otherwise none is updated and ErrConflict is returned.
+func (s *PostgresMemoryStore) UpdateBatch(ctx context.Context, memories []*Memory) error {
+	s.logger.Debug("Updating memories", "count", len(memories))
+	batch := &pgx.Batch{}
+	for _, memory := range memories {
+		batch.Queue(memoryUpdateQuery,
+			memory.Content, memory.Embedding, memory.Confidence, memory.Version, memory.Provenance, nullableWorkflowID(memory.WorkflowID), memory.ID).
+			Exec(func(tag pgconn.CommandTag) error {
+				if tag.RowsAffected() == 0 {
+					return memoryConflict(memory)
+				}
+				return nil
+			})
+	}
+
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		return tx.SendBatch(ctx, batch).Close()
+	})
+	if err == nil && s.memoriesUpdated != nil {
+		s.memoriesUpdated.Add(ctx, int64(len(memories)))
+	}
+	return err
+}
+
+// RecordFeedbackBatch stores several feedback events for usage analytics.
+func (s *PostgresMemoryStore) RecordFeedbackBatch(ctx context.Context, events []*models.FeedbackEvent) error {
+	batch := &pgx.Batch{}
+	for _, event := range events {
+		batch.Queue(`
+			INSERT INTO feedback_events (tenant_id, memory_id, confidence, signal, reason, query)
+			VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''))
+			RETURNING created_at`, event.TenantID, event.MemoryID, event.Confidence,
+			event.Signal, event.Reason, event.Query).QueryRow(func(row pgx.Row) error {
+			return row.Scan(&event.CreatedAt)
+		})
+	}
+	return s.withTenant(ctx, func(tx pgx.Tx) error {
+		return tx.SendBatch(ctx, batch).Close()
+	})
+}
+
+// nullableWorkflowID stores memories without a workflow as NULL.
+func nullableWorkflowID(workflowID string) interface{} {
+	if workflowID == "" {
+		return nil
+	}
+	return workflowID
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// groundingRuleVersionColumns are the columns scanGroundingRuleVersion reads.
+const groundingRuleVersionColumns = `id, tenant_id, rule_id, version, COALESCE(base_version, 0), workflow_id, name, content, embedding, is_global,
+	status, COALESCE(proposed_by, ''), COALESCE(reviewed_by, ''), COALESCE(review_comment, ''), created_at, reviewed_at`
+
+func scanGroundingRuleVersion(row pgx.Row) (*models.GroundingRuleVersion, error) {
+	var v models.GroundingRuleVersion
+	err := row.Scan(&v.ID, &v.TenantID, &v.RuleID, &v.Version, &v.BaseVersion, &v.WorkflowID, &v.Name, &v.Content, &v.Embedding, &v.IsGlobal,
+		&v.Status, &v.ProposedBy, &v.ReviewedBy, &v.ReviewComment, &v.CreatedAt, &v.ReviewedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// CreateGroundingRuleVersion records a pending change to a rule as its next
+// version. Concurrent proposals for the same rule return ErrConflict.
+func (s *PostgresMemoryStore) CreateGroundingRuleVersion(ctx context.Context, version *models.GroundingRuleVersion) error {
+	s.logger.Debug("Proposing grounding rule version", "rule_id", version.RuleID, "base_version", version.BaseVersion)
+	version.Status = models.GroundingRuleVersionPending
+
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, `
+			INSERT INTO grounding_rule_versions (tenant_id, rule_id, version, base_version, workflow_id, name, content, embedding, is_global, status, proposed_by, created_at)
+			SELECT $1, $2, COALESCE(MAX(version), 0) + 1, NULLIF($3, 0), $4, $5, $6, $7, $8, 'pending', NULLIF($9, ''), NOW()
+			FROM grounding_rule_versions WHERE rule_id = $2
+			RETURNING id, version, created_at
+		`, version.TenantID, version.RuleID, version.BaseVersion, version.WorkflowID, version.Name, version.Content, version.Embedding,
+			version.IsGlobal, version.ProposedBy).Scan(&version.ID, &version.Version, &version.CreatedAt)
+	})
+	if isUniqueViolation(err) {
+		return fmt.Errorf("another change to grounding rule %s was proposed at the same time: %w", version.RuleID, ErrConflict)
+	}
+	return err
+}
+
+// GetGroundingRuleVersion returns a version of a rule, or ErrNotFound.
+func (s *PostgresMemoryStore) GetGroundingRuleVersion(ctx context.Context, ruleID string, version int) (*models.GroundingRuleVersion, error) {
+	var v *models.GroundingRuleVersion
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		var err error
+		v, err = scanGroundingRuleVersion(tx.QueryRow(ctx, `
+			SELECT `+groundingRuleVersionColumns+`
+			FROM grounding_rule_versions WHERE rule_id = $1 AND version = $2
+		`, ruleID, version))
+		return err
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("version %d of grounding rule %s: %w", version, ruleID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// ListGroundingRuleVersions returns every version of a rule, newest first.
+func (s *PostgresMemoryStore) ListGroundingRuleVersions(ctx context.Context, ruleID string) ([]*models.GroundingRuleVersion, error) {
+	versions := make([]*models.GroundingRuleVersion, 0)
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			SELECT `+groundingRuleVersionColumns+`
+			FROM grounding_rule_versions WHERE rule_id = $1
+			ORDER BY version DESC
+		`, ruleID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			v, err := scanGroundingRuleVersion(rows)
+			if err != nil {
+				return err
+			}
+			versions = append(versions, v)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list grounding rule versions: %w", err)
+	}
+	return versions, nil
+}
+
+// ApproveGroundingRuleVersion puts a pending version into effect: the rule
+// takes its name, content, embedding, scope and number and becomes active.
+// This only happens while the rule is at the version the change was based
+// on; otherwise, or when the version is no longer pending, it returns
+// ErrConflict. Every other pending version of the rule was based on an
+// earlier version and is rejected as superseded.
+func (s *PostgresMemoryStore) ApproveGroundingRuleVersion(ctx context.Context, ruleID string, version int, reviewer, comment string) (*models.GroundingRule, error) {
+	var rule *models.GroundingRule
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		v, err := scanGroundingRuleVersion(tx.QueryRow(ctx, `
+			UPDATE grounding_rule_versions
+			SET status = 'approved', reviewed_by = $3, review_comment = NULLIF($4, ''), reviewed_at = NOW()
+			WHERE rule_id = $1 AND version = $2 AND status = 'pending'
+			RETURNING `+groundingRuleVersionColumns,
+			ruleID, version, reviewer, comment))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("version %d of grounding rule %s is not pending: %w", version, ruleID, ErrConflict)
+		}
+		if err != nil {
+			return err
+		}
+
+		// The first version is already in the rule, waiting for approval.
+		based := v.BaseVersion
+		if based == 0 {
+			based = 1
+		}
+		tag, err := tx.Exec(ctx, `
+			UPDATE grounding_rules
+			SET name = $3, content = $4, embedding = $5, is_global = $6, workflow_id = $7, status = 'active', version = $2, updated_at = NOW()
+			WHERE id = $1 AND version = $8 AND status <> 'archived'
+		`, ruleID, v.Version, v.Name, v.Content, v.Embedding, v.IsGlobal, v.WorkflowID, based)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("grounding rule %s is archived or no longer at version %d, which version %d changes: %w", ruleID, based, version, ErrConflict)
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE grounding_rule_versions
+			SET status = 'rejected', reviewed_by = $3, review_comment = $4, reviewed_at = NOW()
+			WHERE rule_id = $1 AND version <> $2 AND status = 'pending'
+		`, ruleID, version, reviewer, fmt.Sprintf("superseded by version %d", version))
+		if err != nil {
+			return err
+		}
+
+		rule, err = getGroundingRule(ctx, tx, ruleID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// RejectGroundingRuleVersion rejects a pending version, leaving the rule as
+// it is. Rejecting the first version of a rule awaiting approval rejects the
+// rule. It returns ErrConflict when the version is no longer pending.
+func (s *PostgresMemoryStore) RejectGroundingRuleVersion(ctx context.Context, ruleID string, version int, reviewer, comment string) (*models.GroundingRuleVersion, error) {
+	var v *models.GroundingRuleVersion
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		var err error
+		v, err = scanGroundingRuleVersion(tx.QueryRow(ctx, `
+			UPDATE grounding_rule_versions
+			SET status = 'rejected', reviewed_by = $3, review_comment = NULLIF($4, ''), reviewed_at = NOW()
+			WHERE rule_id = $1 AND version = $2 AND status = 'pending'
+			RETURNING `+groundingRuleVersionColumns,
+			ruleID, version, reviewer, comment))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("version %d of grounding rule %s is not pending: %w", version, ruleID, ErrConflict)
+		}
+		if err != nil {
+			return err
+		}
+		if v.Version == 1 {
+			_, err = tx.Exec(ctx, `
+				UPDATE grounding_rules SET status = 'rejected', updated_at = NOW()
+				WHERE id = $1 AND status = 'pending'
+			`, ruleID)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// ArchiveGroundingRule retires a rule instead of deleting it, so it is no
+// longer used at recall time but its versions stay readable. Pending changes
+// to it are rejected. It returns ErrNotFound when the rule does not exist or
+// does not belong to tenantID.
+func (s *PostgresMemoryStore) ArchiveGroundingRule(ctx context.Context, tenantID, id, actor string) error {
+	return s.withTenant(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+			UPDATE grounding_rules SET status = 'archived', updated_at = NOW()
+			WHERE id = $1 AND tenant_id = $2
+		`, id, tenantID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("grounding rule %s: %w", id, ErrNotFound)
+		}
+		_, err = tx.Exec(ctx, `
+			UPDATE grounding_rule_versions
+			SET status = 'rejected', reviewed_by = $2, review_comment = 'rule archived', reviewed_at = NOW()
+			WHERE rule_id = $1 AND status = 'pending'
+		`, id, actor)
+		return err
+	})
+}
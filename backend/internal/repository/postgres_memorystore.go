@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"evolutionary-mcp/backend/internal/contextutil"
 	"evolutionary-mcp/backend/pkg/models"
 	"fmt"
@@ -81,7 +82,10 @@ func (s *PostgresMemoryStore) Save(ctx context.Context, memory *Memory) error {
 		workflowID = nil
 	}
 
-	_, err := s.db.Exec(ctx, "INSERT INTO memories (id, tenant_id, content, embedding, confidence, version, provenance, workflow_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)", memory.ID, memory.TenantID, memory.Content, memory.Embedding, memory.Confidence, memory.Version, memory.Provenance, workflowID)
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, "INSERT INTO memories (id, tenant_id, content, embedding, confidence, version, provenance, workflow_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)", memory.ID, memory.TenantID, memory.Content, memory.Embedding, memory.Confidence, memory.Version, memory.Provenance, workflowID)
+		return err
+	})
 	if err == nil && s.memoriesStored != nil {
 		s.memoriesStored.Add(ctx, 1, metric.WithAttributes(attribute.String("workflow_id", memory.WorkflowID)))
 	}
@@ -93,7 +97,9 @@ func (s *PostgresMemoryStore) Get(ctx context.Context, id string) (*Memory, erro
 	s.logger.Debug("Getting memory", "id", id)
 	var memory Memory
 	var workflowID *string
-	err := s.db.QueryRow(ctx, "SELECT id, tenant_id, content, embedding, confidence, version, provenance, workflow_id FROM memories WHERE id = $1", id).Scan(&memory.ID, &memory.TenantID, &memory.Content, &memory.Embedding, &memory.Confidence, &memory.Version, &memory.Provenance, &workflowID)
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, "SELECT id, tenant_id, content, embedding, confidence, version, provenance, workflow_id FROM memories WHERE id = $1", id).Scan(&memory.ID, &memory.TenantID, &memory.Content, &memory.Embedding, &memory.Confidence, &memory.Version, &memory.Provenance, &workflowID)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -107,28 +113,21 @@ func (s *PostgresMemoryStore) Get(ctx context.Context, id string) (*Memory, erro
 func (s *PostgresMemoryStore) Search(ctx context.Context, embedding []float32) ([]*Memory, error) {
 	tenantID := contextutil.GetTenant(ctx)
 	if tenantID == "" {
-		tenantID = "default"
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
 	}
 	s.logger.Debug("Searching memories", "embedding_dim", len(embedding), "tenant_id", tenantID)
 
-	rows, err := s.db.Query(ctx, "SELECT id, tenant_id, content, embedding, confidence, version, provenance, workflow_id FROM memories WHERE tenant_id = $1 ORDER BY embedding <=> $2 LIMIT 10", tenantID, embedding)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
 	var memories []*Memory
-	for rows.Next() {
-		var memory Memory
-		var workflowID *string
-		err := rows.Scan(&memory.ID, &memory.TenantID, &memory.Content, &memory.Embedding, &memory.Confidence, &memory.Version, &memory.Provenance, &workflowID)
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "SELECT id, tenant_id, content, embedding, confidence, version, provenance, workflow_id FROM memories WHERE tenant_id = $1 ORDER BY embedding <=> $2 LIMIT 10", tenantID, embedding)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		if workflowID != nil {
-			memory.WorkflowID = *workflowID
-		}
-		memories = append(memories, &memory)
+		memories, err = scanMemories(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	if s.memoriesSearched != nil {
@@ -142,10 +141,34 @@ func (s *PostgresMemoryStore) Search(ctx context.Context, embedding []float32) (
 func (s *PostgresMemoryStore) ListMemories(ctx context.Context, tenantID string) ([]*Memory, error) {
 	s.logger.Debug("Listing all memories", "tenant_id", tenantID)
 
-	rows, err := s.db.Query(ctx, "SELECT id, tenant_id, content, embedding, confidence, version, provenance, workflow_id FROM memories WHERE tenant_id = $1 ORDER BY version DESC, id", tenantID)
+	var memories []*Memory
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "SELECT id, tenant_id, content, embedding, confidence, version, provenance, workflow_id FROM memories WHERE tenant_id = $1 ORDER BY version DESC, id", tenantID)
+		if err != nil {
+			return err
+		}
+		memories, err = scanMemories(rows)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+
+	return memories, nil
+}
+
+// CountMemories returns how many memories a tenant currently stores.
+func (s *PostgresMemoryStore) CountMemories(ctx context.Context, tenantID string) (int, error) {
+	var count int
+	ctx = contextutil.WithTenant(ctx, tenantID)
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, "SELECT COUNT(*) FROM memories WHERE tenant_id = $1", tenantID).Scan(&count)
+	})
+	return count, err
+}
+
+// scanMemories reads every row of a memories query and closes rows.
+func scanMemories(rows pgx.Rows) ([]*Memory, error) {
 	defer rows.Close()
 
 	var memories []*Memory
@@ -161,11 +184,11 @@ func (s *PostgresMemoryStore) ListMemories(ctx context.Context, tenantID string)
 		}
 		memories = append(memories, &memory)
 	}
-
-	return memories, nil
+	return memories, rows.Err()
 }
 
-// Update updates an existing memory.
+// Update stores memory as its new version memory.Version. It returns
+// ErrConflict unless the stored memory is still at the version before it.
 func (s *PostgresMemoryStore) Update(ctx context.Context, memory *Memory) error {
 	s.logger.Debug("Updating memory", "id", memory.ID, "new_version", memory.Version)
 	var workflowID interface{} = memory.WorkflowID
@@ -173,43 +196,93 @@ func (s *PostgresMemoryStore) Update(ctx context.Context, memory *Memory) error
 		workflowID = nil
 	}
 
-	_, err := s.db.Exec(ctx, "UPDATE memories SET content = $1, embedding = $2, confidence = $3, version = $4, provenance = $5, workflow_id = $6 WHERE id = $7", memory.Content, memory.Embedding, memory.Confidence, memory.Version, memory.Provenance, workflowID, memory.ID)
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, memoryUpdateQuery, memory.Content, memory.Embedding, memory.Confidence, memory.Version, memory.Provenance, workflowID, memory.ID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return memoryConflict(memory)
+		}
+		return nil
+	})
 	if err == nil && s.memoriesUpdated != nil {
 		s.memoriesUpdated.Add(ctx, 1)
 	}
 	return err
 }
 
+// memoryUpdateQuery moves a memory to version $4, provided it is still at the
+// version before.
+const memoryUpdateQuery = "UPDATE memories SET content = $1, embedding = $2, confidence = $3, version = $4, provenance = $5, workflow_id = $6 WHERE id = $7 AND version = $4 - 1"
+
+func memoryConflict(memory *Memory) error {
+	return fmt.Errorf("memory %s was changed since version %d: %w", memory.ID, memory.Version-1, ErrConflict)
+}
+
 // Ping checks the database connection.
 func (s *PostgresMemoryStore) Ping(ctx context.Context) error {
 	return s.db.Ping(ctx)
 }
 
+// withTenant runs fn in a transaction whose app.tenant_id setting is taken from
+// contextutil.GetTenant. The row-level security policies added in migration 010
+// compare against this setting, so a query that forgets its tenant filter sees
+// only the caller's rows, and a context without a tenant sees none at all.
+func (s *PostgresMemoryStore) withTenant(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT set_config('app.tenant_id', $1, true)", contextutil.GetTenant(ctx)); err != nil {
+		return fmt.Errorf("failed to set tenant scope: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
 // ListWorkflows retrieves all workflows from the database.
 func (s *PostgresMemoryStore) ListWorkflows(ctx context.Context) ([]*models.Workflow, error) {
 	tenantID := contextutil.GetTenant(ctx)
 	if tenantID == "" {
-		tenantID = "default"
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
 	}
 
 	s.logger.Debug("Listing active workflows", "tenant_id", tenantID)
-	rows, err := s.db.Query(ctx, "SELECT id, workflow_id, tenant_id, version, is_latest, name, description, status, parent_id, element_type, input_schema, output_schema, created_by, created_at, updated_at FROM workflows WHERE is_latest = true AND tenant_id = $1", tenantID)
+	var workflows []*models.Workflow
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "SELECT id, workflow_id, tenant_id, version, revision, is_latest, name, description, status, parent_id, element_type, input_schema, output_schema, created_by, created_at, updated_at, position FROM workflows WHERE is_latest = true AND tenant_id = $1", tenantID)
+		if err != nil {
+			return err
+		}
+		workflows, err = scanWorkflows(rows)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
+
+	return workflows, nil
+}
+
+// scanWorkflows reads every row of a workflows query and closes rows.
+func scanWorkflows(rows pgx.Rows) ([]*models.Workflow, error) {
 	defer rows.Close()
 
 	workflows := make([]*models.Workflow, 0)
 	for rows.Next() {
 		var workflow models.Workflow
-		err := rows.Scan(&workflow.ID, &workflow.WorkflowID, &workflow.TenantID, &workflow.Version, &workflow.IsLatest, &workflow.Name, &workflow.Description, &workflow.Status, &workflow.ParentID, &workflow.ElementType, &workflow.InputSchema, &workflow.OutputSchema, &workflow.CreatedBy, &workflow.CreatedAt, &workflow.UpdatedAt)
+		err := rows.Scan(&workflow.ID, &workflow.WorkflowID, &workflow.TenantID, &workflow.Version, &workflow.Revision, &workflow.IsLatest, &workflow.Name, &workflow.Description, &workflow.Status, &workflow.ParentID, &workflow.ElementType, &workflow.InputSchema, &workflow.OutputSchema, &workflow.CreatedBy, &workflow.CreatedAt, &workflow.UpdatedAt, &workflow.Position)
 		if err != nil {
 			return nil, err
 		}
 		workflows = append(workflows, &workflow)
 	}
-
-	return workflows, nil
+	return workflows, rows.Err()
 }
 
 // CreateWorkflow creates a new workflow or evolves an existing one.
@@ -220,18 +293,19 @@ func (s *PostgresMemoryStore) CreateWorkflow(ctx context.Context, workflow *mode
 		workflow.ID = uuid.New().String()
 	}
 
-	tx, err := s.db.Begin(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback(ctx)
+	return s.withTenant(ctx, func(tx pgx.Tx) error {
+		return s.createWorkflow(ctx, tx, workflow)
+	})
+}
 
+// createWorkflow inserts the next version of workflow using tx.
+func (s *PostgresMemoryStore) createWorkflow(ctx context.Context, tx pgx.Tx, workflow *models.Workflow) error {
 	// If WorkflowID is provided, we are evolving an existing workflow for this tenant.
 	// We need to retire the current latest version.
 	var nextVersion = 1
 	if workflow.WorkflowID != "" {
 		// 1. Retire the current latest version
-		_, err := tx.Exec(ctx, "UPDATE workflows SET is_latest = false WHERE workflow_id = $1 AND tenant_id = $2 AND is_latest = true", workflow.WorkflowID, workflow.TenantID)
+		_, err := tx.Exec(ctx, "UPDATE workflows SET is_latest = false, revision = revision + 1 WHERE workflow_id = $1 AND tenant_id = $2 AND is_latest = true", workflow.WorkflowID, workflow.TenantID)
 		if err != nil {
 			return fmt.Errorf("failed to retire old workflow version: %w", err)
 		}
@@ -250,13 +324,14 @@ func (s *PostgresMemoryStore) CreateWorkflow(ctx context.Context, workflow *mode
 
 	s.logger.Debug("Setting new version", "version", nextVersion)
 	workflow.Version = nextVersion
+	workflow.Revision = 1
 	workflow.IsLatest = true
 
 	// 2. Insert the new version
-	_, err = tx.Exec(ctx, `
-		INSERT INTO workflows (id, tenant_id, workflow_id, version, is_latest, name, description, status, parent_id, element_type, input_schema, output_schema, created_by, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, NOW(), NOW())
-	`, workflow.ID, workflow.TenantID, workflow.WorkflowID, workflow.Version, workflow.IsLatest, workflow.Name, workflow.Description, workflow.Status, workflow.ParentID, workflow.ElementType, workflow.InputSchema, workflow.OutputSchema, workflow.CreatedBy)
+	_, err := tx.Exec(ctx, `
+		INSERT INTO workflows (id, tenant_id, workflow_id, version, is_latest, name, description, status, parent_id, element_type, input_schema, output_schema, created_by, position, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW(), NOW())
+	`, workflow.ID, workflow.TenantID, workflow.WorkflowID, workflow.Version, workflow.IsLatest, workflow.Name, workflow.Description, workflow.Status, workflow.ParentID, workflow.ElementType, workflow.InputSchema, workflow.OutputSchema, workflow.CreatedBy, workflow.Position)
 	if err != nil {
 		return fmt.Errorf("failed to insert workflow: %w", err)
 	}
@@ -264,157 +339,520 @@ func (s *PostgresMemoryStore) CreateWorkflow(ctx context.Context, workflow *mode
 	if s.workflowsCreated != nil {
 		s.workflowsCreated.Add(ctx, 1, metric.WithAttributes(attribute.Bool("is_evolution", workflow.Version > 1)))
 	}
-	return tx.Commit(ctx)
+	return nil
 }
 
-// UpdateWorkflow updates an existing workflow version (usually the latest one).
+// UpdateWorkflow updates an existing workflow version (usually the latest one)
+// if it is still at workflow.Revision, and moves workflow to the next
+// revision. It returns ErrConflict when the version was changed in between.
 func (s *PostgresMemoryStore) UpdateWorkflow(ctx context.Context, workflow *models.Workflow) error {
-	s.logger.Debug("Updating workflow", "id", workflow.ID, "workflow_id", workflow.WorkflowID)
-
-	_, err := s.db.Exec(ctx, `
-		UPDATE workflows 
-		SET name = $1, description = $2, status = $3, input_schema = $4, output_schema = $5, updated_at = NOW()
-		WHERE id = $6 AND tenant_id = $7
-	`, workflow.Name, workflow.Description, workflow.Status, workflow.InputSchema, workflow.OutputSchema, workflow.ID, workflow.TenantID)
-	
-	return err
+	s.logger.Debug("Updating workflow", "id", workflow.ID, "workflow_id", workflow.WorkflowID, "revision", workflow.Revision)
+
+	return s.withTenant(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			UPDATE workflows 
+			SET name = $1, description = $2, status = $3, input_schema = $4, output_schema = $5, revision = revision + 1, updated_at = NOW()
+			WHERE id = $6 AND tenant_id = $7 AND revision = $8
+			RETURNING revision
+		`, workflow.Name, workflow.Description, workflow.Status, workflow.InputSchema, workflow.OutputSchema, workflow.ID, workflow.TenantID, workflow.Revision).Scan(&workflow.Revision)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("workflow version %s was changed since revision %d: %w", workflow.ID, workflow.Revision, ErrConflict)
+		}
+		return err
+	})
 }
 
-// GetWorkflow retrieves a specific workflow version by ID.
+// GetWorkflow retrieves a specific workflow version by ID. It returns
+// ErrNotFound when there is no such version.
 func (s *PostgresMemoryStore) GetWorkflow(ctx context.Context, id string) (*models.Workflow, error) {
 	s.logger.Debug("Getting workflow", "id", id)
 	var workflow models.Workflow
-	err := s.db.QueryRow(ctx, `
-		SELECT id, workflow_id, tenant_id, version, is_latest, name, description, status, parent_id, element_type, input_schema, output_schema, created_by, created_at, updated_at 
-		FROM workflows WHERE id = $1
-	`, id).Scan(&workflow.ID, &workflow.WorkflowID, &workflow.TenantID, &workflow.Version, &workflow.IsLatest, &workflow.Name, &workflow.Description, &workflow.Status, &workflow.ParentID, &workflow.ElementType, &workflow.InputSchema, &workflow.OutputSchema, &workflow.CreatedBy, &workflow.CreatedAt, &workflow.UpdatedAt)
-	
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, `
+			SELECT id, workflow_id, tenant_id, version, revision, is_latest, name, description, status, parent_id, element_type, input_schema, output_schema, created_by, created_at, updated_at, position
+			FROM workflows WHERE id = $1
+		`, id).Scan(&workflow.ID, &workflow.WorkflowID, &workflow.TenantID, &workflow.Version, &workflow.Revision, &workflow.IsLatest, &workflow.Name, &workflow.Description, &workflow.Status, &workflow.ParentID, &workflow.ElementType, &workflow.InputSchema, &workflow.OutputSchema, &workflow.CreatedBy, &workflow.CreatedAt, &workflow.UpdatedAt, &workflow.Position)
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("workflow version %s: %w", id, ErrNotFound)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &workflow, nil
 }
 
-// GetTenantByDomain retrieves a tenant by their email domain.
-func (s *PostgresMemoryStore) GetTenantByDomain(ctx context.Context, domain string) (*models.Tenant, error) {
-	var t models.Tenant
-	err := s.db.QueryRow(ctx, "SELECT id, name, domain, logo_svg, brand_title, created_at, updated_at FROM tenants WHERE domain = $1", domain).Scan(&t.ID, &t.Name, &t.Domain, &t.LogoSVG, &t.BrandTitle, &t.CreatedAt, &t.UpdatedAt)
+// GetWorkflowVersion retrieves one version of a workflow by its stable
+// workflow ID and version number. It returns ErrNotFound when there is no
+// such version.
+func (s *PostgresMemoryStore) GetWorkflowVersion(ctx context.Context, tenantID, workflowID string, version int) (*models.Workflow, error) {
+	s.logger.Debug("Getting workflow version", "workflow_id", workflowID, "version", version)
+	var workflow models.Workflow
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, `
+			SELECT id, workflow_id, tenant_id, version, revision, is_latest, name, description, status, parent_id, element_type, input_schema, output_schema, created_by, created_at, updated_at, position
+			FROM workflows WHERE workflow_id = $1 AND version = $2 AND tenant_id = $3
+		`, workflowID, version, tenantID).Scan(&workflow.ID, &workflow.WorkflowID, &workflow.TenantID, &workflow.Version, &workflow.Revision, &workflow.IsLatest, &workflow.Name, &workflow.Description, &workflow.Status, &workflow.ParentID, &workflow.ElementType, &workflow.InputSchema, &workflow.OutputSchema, &workflow.CreatedBy, &workflow.CreatedAt, &workflow.UpdatedAt, &workflow.Position)
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("workflow %s version %d: %w", workflowID, version, ErrNotFound)
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &t, nil
+	return &workflow, nil
+}
+
+// ListWorkflowVersions returns every version of a workflow, newest first.
+func (s *PostgresMemoryStore) ListWorkflowVersions(ctx context.Context, tenantID, workflowID string) ([]*models.Workflow, error) {
+	s.logger.Debug("Listing workflow versions", "workflow_id", workflowID)
+	var workflows []*models.Workflow
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			SELECT id, workflow_id, tenant_id, version, revision, is_latest, name, description, status, parent_id, element_type, input_schema, output_schema, created_by, created_at, updated_at, position
+			FROM workflows WHERE workflow_id = $1 AND tenant_id = $2
+			ORDER BY version DESC
+		`, workflowID, tenantID)
+		if err != nil {
+			return err
+		}
+		workflows, err = scanWorkflows(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return workflows, nil
+}
+
+// RollbackWorkflow copies an older version of a workflow into a new draft
+// version that becomes the latest, and records its creation as a transition.
+// The version itself is left untouched so the history stays append-only; the
+// copy goes live only once it is published with TransitionWorkflow.
+func (s *PostgresMemoryStore) RollbackWorkflow(ctx context.Context, tenantID, workflowID string, version int, createdBy string) (*models.Workflow, error) {
+	s.logger.Debug("Rolling back workflow", "workflow_id", workflowID, "version", version)
+	var workflow models.Workflow
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			SELECT id, workflow_id, tenant_id, version, revision, is_latest, name, description, status, parent_id, element_type, input_schema, output_schema, created_by, created_at, updated_at, position
+			FROM workflows WHERE workflow_id = $1 AND version = $2 AND tenant_id = $3
+		`, workflowID, version, tenantID).Scan(&workflow.ID, &workflow.WorkflowID, &workflow.TenantID, &workflow.Version, &workflow.Revision, &workflow.IsLatest, &workflow.Name, &workflow.Description, &workflow.Status, &workflow.ParentID, &workflow.ElementType, &workflow.InputSchema, &workflow.OutputSchema, &workflow.CreatedBy, &workflow.CreatedAt, &workflow.UpdatedAt, &workflow.Position)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("workflow %s version %d: %w", workflowID, version, ErrNotFound)
+		}
+		if err != nil {
+			return err
+		}
+
+		workflow.ID = uuid.New().String()
+		workflow.Status = models.WorkflowStatusDraft
+		workflow.CreatedBy = createdBy
+		if err := s.createWorkflow(ctx, tx, &workflow); err != nil {
+			return err
+		}
+		if err := insertWorkflowTransition(ctx, tx, &models.WorkflowTransition{
+			TenantID:   workflow.TenantID,
+			VersionID:  workflow.ID,
+			WorkflowID: workflow.WorkflowID,
+			Version:    workflow.Version,
+			ToStatus:   workflow.Status,
+			Actor:      createdBy,
+			Comment:    fmt.Sprintf("copy of version %d for a rollback", version),
+		}); err != nil {
+			return err
+		}
+		return tx.QueryRow(ctx, "SELECT created_at, updated_at FROM workflows WHERE id = $1", workflow.ID).Scan(&workflow.CreatedAt, &workflow.UpdatedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
+// TransitionWorkflow moves a workflow version to a new status and records
+// the transition. The status is only changed if it still is FromStatus, so
+// concurrent transitions cannot skip a step.
+func (s *PostgresMemoryStore) TransitionWorkflow(ctx context.Context, transition *models.WorkflowTransition) error {
+	s.logger.Debug("Transitioning workflow", "id", transition.VersionID, "from", transition.FromStatus, "to", transition.ToStatus)
+	return s.withTenant(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+			UPDATE workflows SET status = $1, revision = revision + 1, updated_at = NOW()
+			WHERE id = $2 AND status = $3
+		`, transition.ToStatus, transition.VersionID, transition.FromStatus)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("workflow version %s is no longer %s: %w", transition.VersionID, transition.FromStatus, ErrConflict)
+		}
+		return insertWorkflowTransition(ctx, tx, transition)
+	})
+}
+
+func insertWorkflowTransition(ctx context.Context, tx pgx.Tx, transition *models.WorkflowTransition) error {
+	return tx.QueryRow(ctx, `
+		INSERT INTO workflow_transitions (tenant_id, version_id, workflow_id, version, from_status, to_status, actor, comment, allow_breaking)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, NULLIF($8, ''), $9)
+		RETURNING id, created_at
+	`, transition.TenantID, transition.VersionID, transition.WorkflowID, transition.Version, transition.FromStatus, transition.ToStatus, transition.Actor, transition.Comment, transition.AllowBreaking).Scan(&transition.ID, &transition.CreatedAt)
+}
+
+// ListWorkflowTransitions returns the status changes of every version of a
+// workflow, newest first.
+func (s *PostgresMemoryStore) ListWorkflowTransitions(ctx context.Context, tenantID, workflowID string) ([]*models.WorkflowTransition, error) {
+	transitions := make([]*models.WorkflowTransition, 0)
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			SELECT id, tenant_id, version_id, workflow_id, version, COALESCE(from_status, ''), to_status, actor, COALESCE(comment, ''), allow_breaking, created_at
+			FROM workflow_transitions WHERE workflow_id = $1 AND tenant_id = $2
+			ORDER BY created_at DESC, id DESC
+		`, workflowID, tenantID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var t models.WorkflowTransition
+			if err := rows.Scan(&t.ID, &t.TenantID, &t.VersionID, &t.WorkflowID, &t.Version, &t.FromStatus, &t.ToStatus, &t.Actor, &t.Comment, &t.AllowBreaking, &t.CreatedAt); err != nil {
+				return err
+			}
+			transitions = append(transitions, &t)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow transitions: %w", err)
+	}
+	return transitions, nil
 }
 
-// GetTenantByID retrieves a tenant by their ID.
-func (s *PostgresMemoryStore) GetTenantByID(ctx context.Context, id string) (*models.Tenant, error) {
-	var t models.Tenant
-	err := s.db.QueryRow(ctx, "SELECT id, name, domain, logo_svg, brand_title, created_at, updated_at FROM tenants WHERE id = $1", id).Scan(&t.ID, &t.Name, &t.Domain, &t.LogoSVG, &t.BrandTitle, &t.CreatedAt, &t.UpdatedAt)
+// workflowSubtreeQuery selects the workflow version $1 of tenant $2 and,
+// recursively, the elements of that tenant nested under it. Under each parent only the newest version of an
+// element counts: revising an element replaces it in the tree, while older
+// versions stay in the trees of the parent versions they were cloned under.
+// path guards against cycles.
+const workflowSubtreeQuery = `
+	WITH RECURSIVE tree AS (
+		SELECT w.*, 0 AS depth, ARRAY[w.id] AS path
+		FROM workflows w WHERE w.id = $1 AND w.tenant_id = $2
+		UNION ALL
+		SELECT c.*, tree.depth + 1, tree.path || c.id
+		FROM workflows c JOIN tree ON c.parent_id = tree.id
+		WHERE c.tenant_id = $2 AND NOT c.id = ANY(tree.path)
+		AND NOT EXISTS (
+			SELECT 1 FROM workflows n
+			WHERE n.tenant_id = $2 AND n.parent_id = c.parent_id AND n.workflow_id = c.workflow_id AND n.version > c.version
+		)
+	)
+	SELECT id, workflow_id, tenant_id, version, revision, is_latest, name, description, status, parent_id, element_type, input_schema, output_schema, created_by, created_at, updated_at, position
+	FROM tree
+	ORDER BY depth, position, name
+`
+
+// GetWorkflowSubtree returns the workflow version id of tenantID and every
+// element nested under it, parents before children and siblings in position
+// order.
+func (s *PostgresMemoryStore) GetWorkflowSubtree(ctx context.Context, tenantID, id string) ([]*models.Workflow, error) {
+	s.logger.Debug("Getting workflow subtree", "id", id)
+	var workflows []*models.Workflow
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, workflowSubtreeQuery, id, tenantID)
+		if err != nil {
+			return err
+		}
+		workflows, err = scanWorkflows(rows)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &t, nil
+	if len(workflows) == 0 {
+		return nil, fmt.Errorf("workflow version %s: %w", id, ErrNotFound)
+	}
+	return workflows, nil
+}
+
+// MoveWorkflowElements applies moves of draft versions of tenantID in order
+// in one transaction. Each moved version is placed at its position among the
+// draft siblings under its new parent, whose positions are renumbered from 0;
+// at the top level only the latest versions count as siblings. Versions in
+// any other status are never changed. A move that would nest a version under
+// itself is a conflict and rolls back every move.
+func (s *PostgresMemoryStore) MoveWorkflowElements(ctx context.Context, tenantID string, moves []models.WorkflowMove) error {
+	s.logger.Debug("Moving workflow elements", "count", len(moves))
+	return s.withTenant(ctx, func(tx pgx.Tx) error {
+		for _, move := range moves {
+			if err := moveWorkflowElement(ctx, tx, tenantID, move); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
-// CreateTenant creates a new tenant.
-func (s *PostgresMemoryStore) CreateTenant(ctx context.Context, tenant *models.Tenant) error {
-	return s.db.QueryRow(ctx, `
-		INSERT INTO tenants (name, domain, logo_svg, brand_title, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, NOW(), NOW())
-		RETURNING id, created_at, updated_at`, tenant.Name, tenant.Domain, tenant.LogoSVG, tenant.BrandTitle).Scan(&tenant.ID, &tenant.CreatedAt, &tenant.UpdatedAt)
+func moveWorkflowElement(ctx context.Context, tx pgx.Tx, tenantID string, move models.WorkflowMove) error {
+	var status string
+	err := tx.QueryRow(ctx, "SELECT status FROM workflows WHERE id = $1 AND tenant_id = $2", move.ID, tenantID).Scan(&status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("workflow version %s: %w", move.ID, ErrNotFound)
+	}
+	if err != nil {
+		return err
+	}
+	if status != models.WorkflowStatusDraft {
+		return fmt.Errorf("workflow version %s is %s and cannot be moved: %w", move.ID, status, ErrConflict)
+	}
+
+	if move.ParentID != nil {
+		var cycle bool
+		err := tx.QueryRow(ctx, `
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM workflows WHERE id = $1 AND tenant_id = $3
+				UNION
+				SELECT c.id FROM workflows c JOIN subtree ON c.parent_id = subtree.id
+				WHERE c.tenant_id = $3
+			)
+			SELECT EXISTS (SELECT 1 FROM subtree WHERE id = $2)
+		`, move.ID, *move.ParentID, tenantID).Scan(&cycle)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return fmt.Errorf("cannot move workflow version %s under %s, which is nested under it: %w", move.ID, *move.ParentID, ErrConflict)
+		}
+		var exists bool
+		if err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM workflows WHERE id = $1 AND tenant_id = $2)", *move.ParentID, tenantID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("parent workflow version %s: %w", *move.ParentID, ErrNotFound)
+		}
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT id FROM workflows
+		WHERE tenant_id = $3 AND parent_id IS NOT DISTINCT FROM $1 AND id <> $2
+		AND status = 'draft' AND ($1::uuid IS NOT NULL OR is_latest)
+		ORDER BY position, name
+	`, move.ParentID, move.ID, tenantID)
+	if err != nil {
+		return err
+	}
+	siblings, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return err
+	}
+
+	position := min(max(move.Position, 0), len(siblings))
+	order := make([]string, 0, len(siblings)+1)
+	order = append(order, siblings[:position]...)
+	order = append(order, move.ID)
+	order = append(order, siblings[position:]...)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE workflows SET parent_id = $1, revision = revision + 1, updated_at = NOW()
+		WHERE id = $2 AND tenant_id = $3 AND status = 'draft'
+	`, move.ParentID, move.ID, tenantID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE workflows SET position = ordered.ord - 1, revision = revision + 1
+		FROM unnest($1::uuid[]) WITH ORDINALITY AS ordered(id, ord)
+		WHERE workflows.id = ordered.id AND workflows.tenant_id = $2 AND workflows.status = 'draft'
+		AND workflows.position <> ordered.ord - 1
+	`, order, tenantID)
+	return err
 }
 
-// CreateGroundingRule creates a new grounding rule.
+// CloneWorkflowSubtree copies the subtree under the workflow version id into
+// new draft versions, created by createdBy. The copy of the root keeps its
+// parent; every other copy is nested under the copy of its parent at the same
+// position. The originals are left untouched.
+func (s *PostgresMemoryStore) CloneWorkflowSubtree(ctx context.Context, tenantID, id string, createdBy string) ([]*models.Workflow, error) {
+	s.logger.Debug("Cloning workflow subtree", "id", id)
+	var clones []*models.Workflow
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, workflowSubtreeQuery, id, tenantID)
+		if err != nil {
+			return err
+		}
+		originals, err := scanWorkflows(rows)
+		if err != nil {
+			return err
+		}
+		if len(originals) == 0 {
+			return fmt.Errorf("workflow version %s: %w", id, ErrNotFound)
+		}
+
+		// Parents come before their children, so every parent is copied
+		// before it is needed.
+		copied := make(map[string]string, len(originals))
+		for i, original := range originals {
+			clone := *original
+			clone.ID = uuid.New().String()
+			clone.Status = models.WorkflowStatusDraft
+			clone.CreatedBy = createdBy
+			if i > 0 {
+				parentID := copied[*original.ParentID]
+				clone.ParentID = &parentID
+			}
+			if err := s.createWorkflow(ctx, tx, &clone); err != nil {
+				return err
+			}
+			copied[original.ID] = clone.ID
+		}
+
+		rows, err = tx.Query(ctx, workflowSubtreeQuery, copied[originals[0].ID], tenantID)
+		if err != nil {
+			return err
+		}
+		clones, err = scanWorkflows(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return clones, nil
+}
+
+// groundingRuleColumns are the columns scanGroundingRules reads.
+const groundingRuleColumns = `id, tenant_id, workflow_id, name, content, embedding, is_global, status, COALESCE(proposed_by, ''), version,
+	(SELECT COUNT(*) FROM grounding_rule_versions v WHERE v.rule_id = grounding_rules.id AND v.status = 'pending'),
+	created_at, updated_at`
+
+// CreateGroundingRule creates a new grounding rule, with its first version.
+// The version is approved unless the rule is pending.
 func (s *PostgresMemoryStore) CreateGroundingRule(ctx context.Context, rule *models.GroundingRule) error {
 	s.logger.Debug("Creating grounding rule", "name", rule.Name, "tenant_id", rule.TenantID)
 	if rule.ID == "" {
 		rule.ID = uuid.New().String()
 	}
+	if rule.Status == "" {
+		rule.Status = models.GroundingRuleStatusActive
+	}
+	rule.Version = 1
+
+	return s.withTenant(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			INSERT INTO grounding_rules (id, tenant_id, workflow_id, name, content, embedding, is_global, status, proposed_by, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), NOW(), NOW())
+			RETURNING created_at, updated_at
+		`, rule.ID, rule.TenantID, rule.WorkflowID, rule.Name, rule.Content, rule.Embedding, rule.IsGlobal, rule.Status, rule.ProposedBy).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		return insertFirstGroundingRuleVersion(ctx, tx, rule)
+	})
+}
 
-	_, err := s.db.Exec(ctx, `
-		INSERT INTO grounding_rules (id, tenant_id, workflow_id, name, content, embedding, is_global, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
-	`, rule.ID, rule.TenantID, rule.WorkflowID, rule.Name, rule.Content, rule.Embedding, rule.IsGlobal)
-	
+// insertFirstGroundingRuleVersion records version 1 of a new rule.
+func insertFirstGroundingRuleVersion(ctx context.Context, tx pgx.Tx, rule *models.GroundingRule) error {
+	status := models.GroundingRuleVersionApproved
+	switch rule.Status {
+	case models.GroundingRuleStatusPending:
+		status = models.GroundingRuleVersionPending
+	case models.GroundingRuleStatusRejected:
+		status = models.GroundingRuleVersionRejected
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO grounding_rule_versions (tenant_id, rule_id, version, workflow_id, name, content, embedding, is_global, status, proposed_by, created_at, reviewed_at)
+		VALUES ($1, $2, 1, $3, $4, $5, $6, $7, $8, NULLIF($9, ''), NOW(), CASE WHEN $8 = 'pending' THEN NULL ELSE NOW() END)
+	`, rule.TenantID, rule.ID, rule.WorkflowID, rule.Name, rule.Content, rule.Embedding, rule.IsGlobal, status, rule.ProposedBy)
+	if status == models.GroundingRuleVersionPending {
+		rule.PendingVersions = 1
+	}
 	return err
 }
 
-// GetGroundingRule retrieves a grounding rule by ID.
+// GetGroundingRule retrieves a grounding rule by ID, including archived ones.
 func (s *PostgresMemoryStore) GetGroundingRule(ctx context.Context, id string) (*models.GroundingRule, error) {
-	var rule models.GroundingRule
-	err := s.db.QueryRow(ctx, `
-		SELECT id, tenant_id, workflow_id, name, content, embedding, is_global, created_at, updated_at 
-		FROM grounding_rules WHERE id = $1
-	`, id).Scan(&rule.ID, &rule.TenantID, &rule.WorkflowID, &rule.Name, &rule.Content, &rule.Embedding, &rule.IsGlobal, &rule.CreatedAt, &rule.UpdatedAt)
-	
+	var rule *models.GroundingRule
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		var err error
+		rule, err = getGroundingRule(ctx, tx, id)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &rule, nil
+	return rule, nil
 }
 
-// ListGroundingRules lists all rules for a tenant.
-func (s *PostgresMemoryStore) ListGroundingRules(ctx context.Context, tenantID string) ([]*models.GroundingRule, error) {
-	rows, err := s.db.Query(ctx, `
-		SELECT id, tenant_id, workflow_id, name, content, embedding, is_global, created_at, updated_at 
-		FROM grounding_rules WHERE tenant_id = $1 OR is_global = true
-		ORDER BY updated_at DESC
-	`, tenantID)
+// getGroundingRule reads a grounding rule inside tx.
+func getGroundingRule(ctx context.Context, tx pgx.Tx, id string) (*models.GroundingRule, error) {
+	rows, err := tx.Query(ctx, `SELECT `+groundingRuleColumns+` FROM grounding_rules WHERE id = $1`, id)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	rules, err := scanGroundingRules(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+	return rules[0], nil
+}
 
-	rules := make([]*models.GroundingRule, 0)
-	for rows.Next() {
-		var rule models.GroundingRule
-		err := rows.Scan(&rule.ID, &rule.TenantID, &rule.WorkflowID, &rule.Name, &rule.Content, &rule.Embedding, &rule.IsGlobal, &rule.CreatedAt, &rule.UpdatedAt)
+// ListGroundingRules lists the rules of a tenant and the global rules.
+// Archived rules are left out.
+func (s *PostgresMemoryStore) ListGroundingRules(ctx context.Context, tenantID string) ([]*models.GroundingRule, error) {
+	var rules []*models.GroundingRule
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			SELECT `+groundingRuleColumns+`
+			FROM grounding_rules WHERE (tenant_id = $1 OR is_global = true) AND status <> 'archived'
+			ORDER BY updated_at DESC
+		`, tenantID)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		rules = append(rules, &rule)
+		rules, err = scanGroundingRules(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 	return rules, nil
 }
 
-// UpdateGroundingRule updates an existing rule.
-func (s *PostgresMemoryStore) UpdateGroundingRule(ctx context.Context, rule *models.GroundingRule) error {
-	_, err := s.db.Exec(ctx, `
-		UPDATE grounding_rules 
-		SET name = $1, content = $2, embedding = $3, is_global = $4, updated_at = NOW()
-		WHERE id = $5 AND tenant_id = $6
-	`, rule.Name, rule.Content, rule.Embedding, rule.IsGlobal, rule.ID, rule.TenantID)
-	
-	return err
-}
-
-// DeleteGroundingRule deletes a rule.
-func (s *PostgresMemoryStore) DeleteGroundingRule(ctx context.Context, id string) error {
-	_, err := s.db.Exec(ctx, "DELETE FROM grounding_rules WHERE id = $1", id)
-	return err
-}
-
-// SearchGroundingRules performs semantic search over rules.
-func (s *PostgresMemoryStore) SearchGroundingRules(ctx context.Context, tenantID string, embedding []float32) ([]*models.GroundingRule, error) {
-	rows, err := s.db.Query(ctx, `
-		SELECT id, tenant_id, workflow_id, name, content, embedding, is_global, created_at, updated_at 
-		FROM grounding_rules 
-		WHERE (tenant_id = $1 OR is_global = true)
-		ORDER BY embedding <=> $2 
-		LIMIT 5
-	`, tenantID, embedding)
-	if err != nil {
-		return nil, err
-	}
+// scanGroundingRules reads every row of a grounding_rules query selecting
+// groundingRuleColumns and closes rows.
+func scanGroundingRules(rows pgx.Rows) ([]*models.GroundingRule, error) {
 	defer rows.Close()
 
 	rules := make([]*models.GroundingRule, 0)
 	for rows.Next() {
 		var rule models.GroundingRule
-		err := rows.Scan(&rule.ID, &rule.TenantID, &rule.WorkflowID, &rule.Name, &rule.Content, &rule.Embedding, &rule.IsGlobal, &rule.CreatedAt, &rule.UpdatedAt)
+		err := rows.Scan(&rule.ID, &rule.TenantID, &rule.WorkflowID, &rule.Name, &rule.Content, &rule.Embedding, &rule.IsGlobal, &rule.Status, &rule.ProposedBy, &rule.Version, &rule.PendingVersions, &rule.CreatedAt, &rule.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
 		rules = append(rules, &rule)
 	}
+	return rules, rows.Err()
+}
+
+// SearchGroundingRules performs semantic search over active rules.
+func (s *PostgresMemoryStore) SearchGroundingRules(ctx context.Context, tenantID string, embedding []float32) ([]*models.GroundingRule, error) {
+	var rules []*models.GroundingRule
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			SELECT `+groundingRuleColumns+`
+			FROM grounding_rules 
+			WHERE (tenant_id = $1 OR is_global = true) AND status = 'active'
+			ORDER BY embedding <=> $2 
+			LIMIT 5
+		`, tenantID, embedding)
+		if err != nil {
+			return err
+		}
+		rules, err = scanGroundingRules(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 	return rules, nil
 }
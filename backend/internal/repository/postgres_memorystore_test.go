@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
+	"evolutionary-mcp/backend/internal/contextutil"
 	"evolutionary-mcp/backend/pkg/models"
 
 	"github.com/google/uuid"
@@ -84,20 +86,40 @@ func TestPostgresMemoryStore(t *testing.T) {
 		domain TEXT UNIQUE NOT NULL,
 		logo_svg TEXT,
 		brand_title TEXT,
+		status TEXT NOT NULL DEFAULT 'active',
+		quota_max_memories INT,
+		quota_embeddings_per_minute INT,
+		quota_rest_requests_per_second DOUBLE PRECISION,
+		quota_mcp_requests_per_second DOUBLE PRECISION,
 		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 	);
+
+	CREATE TABLE IF NOT EXISTS tenant_domains (
+		domain TEXT PRIMARY KEY,
+		tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TABLE IF NOT EXISTS tenant_members (
+		tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+		email TEXT NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (tenant_id, email)
+	);
 	
 	CREATE TABLE IF NOT EXISTS workflows (
 		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 		tenant_id TEXT NOT NULL DEFAULT 'default',
 		workflow_id UUID NOT NULL,
 		version INT NOT NULL DEFAULT 1,
+		revision INT NOT NULL DEFAULT 1,
 		is_latest BOOLEAN NOT NULL DEFAULT TRUE,
 		name TEXT NOT NULL,
 		description TEXT,
 		status TEXT NOT NULL DEFAULT 'draft',
 		parent_id UUID,
+		position INT NOT NULL DEFAULT 0,
 		element_type TEXT NOT NULL DEFAULT 'workflow',
 		input_schema JSONB,
 		output_schema JSONB,
@@ -108,6 +130,38 @@ func TestPostgresMemoryStore(t *testing.T) {
 	CREATE UNIQUE INDEX IF NOT EXISTS idx_workflows_version ON workflows (tenant_id, workflow_id, version);
 	CREATE UNIQUE INDEX IF NOT EXISTS idx_workflows_latest_active ON workflows (tenant_id, workflow_id) WHERE is_latest = TRUE;
 
+	CREATE TABLE IF NOT EXISTS workflow_transitions (
+		id BIGSERIAL PRIMARY KEY,
+		tenant_id TEXT NOT NULL,
+		version_id UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+		workflow_id UUID NOT NULL,
+		version INT NOT NULL,
+		from_status TEXT,
+		to_status TEXT NOT NULL,
+		actor TEXT NOT NULL,
+		comment TEXT,
+		allow_breaking BOOLEAN NOT NULL DEFAULT FALSE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TABLE IF NOT EXISTS workflow_runs (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		tenant_id TEXT NOT NULL,
+		version_id UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+		workflow_id UUID NOT NULL,
+		version INT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'running',
+		inputs JSONB,
+		outputs JSONB,
+		error TEXT,
+		recalled_memory_ids UUID[] NOT NULL DEFAULT '{}',
+		created_memory_ids UUID[] NOT NULL DEFAULT '{}',
+		started_by TEXT NOT NULL,
+		started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		completed_at TIMESTAMPTZ
+	);
+
 	CREATE TABLE IF NOT EXISTS memories (
 		id UUID PRIMARY KEY,
 		tenant_id TEXT NOT NULL DEFAULT 'default',
@@ -127,9 +181,53 @@ func TestPostgresMemoryStore(t *testing.T) {
 		content TEXT NOT NULL,
 		embedding VECTOR(384),
 		is_global BOOLEAN NOT NULL DEFAULT FALSE,
+		status TEXT NOT NULL DEFAULT 'active',
+		proposed_by TEXT,
+		version INT NOT NULL DEFAULT 1,
 		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 		updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 	);
+
+	CREATE TABLE IF NOT EXISTS grounding_rule_versions (
+		id BIGSERIAL PRIMARY KEY,
+		tenant_id UUID NOT NULL REFERENCES tenants(id),
+		rule_id UUID NOT NULL REFERENCES grounding_rules(id) ON DELETE CASCADE,
+		version INT NOT NULL,
+		base_version INT,
+		workflow_id UUID REFERENCES workflows(id),
+		name TEXT NOT NULL,
+		content TEXT NOT NULL,
+		embedding VECTOR(384),
+		is_global BOOLEAN NOT NULL DEFAULT FALSE,
+		status TEXT NOT NULL DEFAULT 'pending',
+		proposed_by TEXT,
+		reviewed_by TEXT,
+		review_comment TEXT,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		reviewed_at TIMESTAMPTZ,
+		UNIQUE (rule_id, version)
+	);
+
+	CREATE TABLE IF NOT EXISTS recall_events (
+		id BIGSERIAL PRIMARY KEY,
+		tenant_id TEXT NOT NULL,
+		latency_ms DOUBLE PRECISION NOT NULL,
+		memory_ids UUID[] NOT NULL DEFAULT '{}',
+		grounding_rule_ids UUID[] NOT NULL DEFAULT '{}',
+		grounding_rule_versions INT[] NOT NULL DEFAULT '{}',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TABLE IF NOT EXISTS feedback_events (
+		id BIGSERIAL PRIMARY KEY,
+		tenant_id TEXT NOT NULL,
+		memory_id UUID NOT NULL,
+		confidence DOUBLE PRECISION NOT NULL,
+		signal TEXT,
+		reason TEXT,
+		query TEXT,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	);
 	`
 	_, err = pool.Exec(ctx, schema)
 	if err != nil {
@@ -171,11 +269,75 @@ func TestPostgresMemoryStore(t *testing.T) {
 			retrieved, err := store.GetGroundingRule(ctx, rule.ID)
 			assert.NoError(t, err)
 			assert.Equal(t, rule.Name, retrieved.Name)
+			assert.Equal(t, models.GroundingRuleStatusActive, retrieved.Status)
 
-			// Update
-			rule.Name = "Updated Rule"
-			err = store.UpdateGroundingRule(ctx, rule)
-			assert.NoError(t, err)
+			// Proposed rules keep their status and author
+			proposal := &models.GroundingRule{
+				Name:       "Proposed Rule",
+				Content:    "Suggested by an agent",
+				TenantID:   tenant.ID,
+				Status:     models.GroundingRuleStatusPending,
+				ProposedBy: "apikey:123",
+			}
+			require.NoError(t, store.CreateGroundingRule(ctx, proposal))
+			retrieved, err = store.GetGroundingRule(ctx, proposal.ID)
+			require.NoError(t, err)
+			assert.Equal(t, models.GroundingRuleStatusPending, retrieved.Status)
+			assert.Equal(t, "apikey:123", retrieved.ProposedBy)
+
+			// Changes are proposed as pending versions and leave the rule as it is
+			change := &models.GroundingRuleVersion{TenantID: tenant.ID, RuleID: rule.ID, BaseVersion: 1, Name: "Updated Rule", Content: rule.Content, ProposedBy: "editor@example.com"}
+			require.NoError(t, store.CreateGroundingRuleVersion(ctx, change))
+			assert.Equal(t, 2, change.Version)
+			assert.Equal(t, models.GroundingRuleVersionPending, change.Status)
+			competing := &models.GroundingRuleVersion{TenantID: tenant.ID, RuleID: rule.ID, BaseVersion: 1, Name: "Competing Rule", Content: rule.Content}
+			require.NoError(t, store.CreateGroundingRuleVersion(ctx, competing))
+			assert.Equal(t, 3, competing.Version)
+			retrieved, err = store.GetGroundingRule(ctx, rule.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "Test Rule", retrieved.Name)
+			assert.Equal(t, 1, retrieved.Version)
+			assert.Equal(t, 2, retrieved.PendingVersions)
+
+			// Approving a version puts it into effect and supersedes the others
+			approved, err := store.ApproveGroundingRuleVersion(ctx, rule.ID, 2, "curator@example.com", "clearer")
+			require.NoError(t, err)
+			assert.Equal(t, "Updated Rule", approved.Name)
+			assert.Equal(t, 2, approved.Version)
+			assert.Equal(t, models.GroundingRuleStatusActive, approved.Status)
+			assert.Zero(t, approved.PendingVersions)
+			superseded, err := store.GetGroundingRuleVersion(ctx, rule.ID, 3)
+			require.NoError(t, err)
+			assert.Equal(t, models.GroundingRuleVersionRejected, superseded.Status)
+			assert.Equal(t, "superseded by version 2", superseded.ReviewComment)
+			_, err = store.ApproveGroundingRuleVersion(ctx, rule.ID, 3, "curator@example.com", "")
+			assert.ErrorIs(t, err, ErrConflict)
+
+			// A change based on an older version conflicts
+			stale := &models.GroundingRuleVersion{TenantID: tenant.ID, RuleID: rule.ID, BaseVersion: 1, Name: "Stale Rule", Content: rule.Content}
+			require.NoError(t, store.CreateGroundingRuleVersion(ctx, stale))
+			_, err = store.ApproveGroundingRuleVersion(ctx, rule.ID, stale.Version, "curator@example.com", "")
+			assert.ErrorIs(t, err, ErrConflict)
+			retrieved, err = store.GetGroundingRule(ctx, rule.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "Updated Rule", retrieved.Name)
+
+			versions, err := store.ListGroundingRuleVersions(ctx, rule.ID)
+			require.NoError(t, err)
+			require.Len(t, versions, 4)
+			assert.Equal(t, []int{4, 3, 2, 1}, []int{versions[0].Version, versions[1].Version, versions[2].Version, versions[3].Version})
+			assert.Equal(t, "curator@example.com", versions[2].ReviewedBy)
+			assert.Equal(t, "editor@example.com", versions[2].ProposedBy)
+			assert.Equal(t, 1, versions[2].BaseVersion)
+			assert.Equal(t, models.GroundingRuleVersionApproved, versions[3].Status)
+
+			// Rejecting the first version of a proposed rule rejects the rule
+			rejected, err := store.RejectGroundingRuleVersion(ctx, proposal.ID, 1, "curator@example.com", "too vague")
+			require.NoError(t, err)
+			assert.Equal(t, "too vague", rejected.ReviewComment)
+			retrieved, err = store.GetGroundingRule(ctx, proposal.ID)
+			require.NoError(t, err)
+			assert.Equal(t, models.GroundingRuleStatusRejected, retrieved.Status)
 
 			// List
 			list, err := store.ListGroundingRules(ctx, tenant.ID)
@@ -183,9 +345,248 @@ func TestPostgresMemoryStore(t *testing.T) {
 			assert.NotEmpty(t, list)
 			assert.Equal(t, "Updated Rule", list[0].Name)
 
-			// Delete
-			err = store.DeleteGroundingRule(ctx, rule.ID)
-			assert.NoError(t, err)
+			// Archive instead of delete
+			assert.ErrorIs(t, store.ArchiveGroundingRule(ctx, uuid.New().String(), rule.ID, "curator@example.com"), ErrNotFound)
+			require.NoError(t, store.ArchiveGroundingRule(ctx, tenant.ID, rule.ID, "curator@example.com"))
+			retrieved, err = store.GetGroundingRule(ctx, rule.ID)
+			require.NoError(t, err)
+			assert.Equal(t, models.GroundingRuleStatusArchived, retrieved.Status)
+			assert.Zero(t, retrieved.PendingVersions)
+			list, err = store.ListGroundingRules(ctx, tenant.ID)
+			require.NoError(t, err)
+			for _, r := range list {
+				assert.NotEqual(t, rule.ID, r.ID)
+			}
+		})
+	})
+
+	t.Run("GroundingRules: versions in effect at recall", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			tenant := &models.Tenant{Name: "Recall Tenant", Domain: "recall.com"}
+			require.NoError(t, store.CreateTenant(ctx, tenant))
+			tenantCtx := contextutil.WithTenant(ctx, tenant.ID)
+			rule := &models.GroundingRule{Name: "Cite", Content: "Cite sources", TenantID: tenant.ID}
+			require.NoError(t, store.CreateGroundingRule(ctx, rule))
+			memoryID := uuid.New().String()
+
+			require.NoError(t, store.RecordRecall(tenantCtx, &models.RecallEvent{
+				TenantID: tenant.ID, LatencyMs: 5, MemoryIDs: []string{memoryID},
+				GroundingRuleIDs: []string{rule.ID}, GroundingRuleVersions: []int{1},
+			}))
+			change := &models.GroundingRuleVersion{TenantID: tenant.ID, RuleID: rule.ID, BaseVersion: 1, Name: "Cite", Content: "Cite primary sources"}
+			require.NoError(t, store.CreateGroundingRuleVersion(ctx, change))
+			_, err := store.ApproveGroundingRuleVersion(ctx, rule.ID, change.Version, "curator@example.com", "")
+			require.NoError(t, err)
+			require.NoError(t, store.RecordRecall(tenantCtx, &models.RecallEvent{
+				TenantID: tenant.ID, LatencyMs: 7, MemoryIDs: []string{memoryID},
+				GroundingRuleIDs: []string{rule.ID}, GroundingRuleVersions: []int{2},
+			}))
+			require.NoError(t, store.RecordRecall(tenantCtx, &models.RecallEvent{TenantID: tenant.ID, LatencyMs: 3, MemoryIDs: []string{uuid.New().String()}}))
+
+			recalls, err := store.ListMemoryRecalls(tenantCtx, tenant.ID, memoryID, 10)
+			require.NoError(t, err)
+			require.Len(t, recalls, 2)
+			require.Len(t, recalls[0].GroundingRules, 1)
+			assert.Equal(t, 2, recalls[0].GroundingRules[0].Version)
+			assert.Equal(t, "Cite primary sources", recalls[0].GroundingRules[0].Content)
+			require.Len(t, recalls[1].GroundingRules, 1)
+			assert.Equal(t, 1, recalls[1].GroundingRules[0].Version)
+			assert.Equal(t, "Cite sources", recalls[1].GroundingRules[0].Content)
+
+			recalls, err = store.ListMemoryRecalls(tenantCtx, tenant.ID, memoryID, 1)
+			require.NoError(t, err)
+			assert.Len(t, recalls, 1)
+
+			recalls, err = store.ListMemoryRecalls(ctx, uuid.New().String(), memoryID, 10)
+			require.NoError(t, err)
+			assert.Empty(t, recalls)
+		})
+	})
+
+	t.Run("Tenants: domains and members", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			acme := &models.Tenant{Name: "Acme", Domain: "acme.com", Domains: []string{"acme.io"}}
+			require.NoError(t, store.CreateTenant(ctx, acme))
+			other := &models.Tenant{Name: "Other", Domain: "other.com"}
+			require.NoError(t, store.CreateTenant(ctx, other))
+
+			// Any registered domain resolves to the tenant
+			byAlias, err := store.GetTenantByDomain(ctx, "acme.io")
+			require.NoError(t, err)
+			assert.Equal(t, acme.ID, byAlias.ID)
+			assert.ElementsMatch(t, []string{"acme.com", "acme.io"}, byAlias.Domains)
+
+			// Domains are unique across tenants
+			err = store.AddTenantDomain(ctx, other.ID, "acme.io")
+			assert.ErrorIs(t, err, ErrConflict)
+
+			// Explicit membership, oldest first
+			require.NoError(t, store.AddTenantMember(ctx, &models.TenantMember{TenantID: other.ID, Email: "contractor@gmail.com"}))
+			require.NoError(t, store.AddTenantMember(ctx, &models.TenantMember{TenantID: acme.ID, Email: "contractor@gmail.com"}))
+			err = store.AddTenantMember(ctx, &models.TenantMember{TenantID: acme.ID, Email: "contractor@gmail.com"})
+			assert.ErrorIs(t, err, ErrConflict)
+
+			tenants, err := store.ListTenantsForMember(ctx, "contractor@gmail.com")
+			require.NoError(t, err)
+			require.Len(t, tenants, 2)
+
+			members, err := store.ListTenantMembers(ctx, acme.ID)
+			require.NoError(t, err)
+			require.Len(t, members, 1)
+			assert.Equal(t, "contractor@gmail.com", members[0].Email)
+
+			require.NoError(t, store.RemoveTenantMember(ctx, acme.ID, "contractor@gmail.com"))
+			assert.ErrorIs(t, store.RemoveTenantMember(ctx, acme.ID, "contractor@gmail.com"), ErrNotFound)
+		})
+	})
+
+	t.Run("Tenants: delete", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			doomed := &models.Tenant{Name: "Doomed", Domain: "doomed.com"}
+			require.NoError(t, store.CreateTenant(ctx, doomed))
+			kept := &models.Tenant{Name: "Kept", Domain: "kept.com"}
+			require.NoError(t, store.CreateTenant(ctx, kept))
+
+			for _, tenant := range []*models.Tenant{doomed, kept} {
+				tenantCtx := contextutil.WithTenant(ctx, tenant.ID)
+				memory := &Memory{ID: uuid.New().String(), TenantID: tenant.ID, Content: "fact", Confidence: 1, Version: 1}
+				require.NoError(t, store.Save(tenantCtx, memory))
+				require.NoError(t, store.CreateGroundingRule(tenantCtx, &models.GroundingRule{Name: "Rule", Content: "Rule", TenantID: tenant.ID}))
+				require.NoError(t, store.RecordRecall(tenantCtx, &models.RecallEvent{TenantID: tenant.ID, LatencyMs: 1, MemoryIDs: []string{memory.ID}}))
+				require.NoError(t, store.RecordFeedback(tenantCtx, &models.FeedbackEvent{TenantID: tenant.ID, MemoryID: memory.ID, Confidence: 0.5}))
+			}
+
+			require.NoError(t, store.DeleteTenant(ctx, doomed.ID))
+			assert.ErrorIs(t, store.DeleteTenant(ctx, doomed.ID), ErrNotFound)
+
+			for _, table := range []string{"memories", "grounding_rules", "recall_events", "feedback_events"} {
+				var doomedRows, keptRows int
+				require.NoError(t, store.db.QueryRow(ctx, "SELECT COUNT(*) FROM "+table+" WHERE tenant_id::text = $1", doomed.ID).Scan(&doomedRows))
+				require.NoError(t, store.db.QueryRow(ctx, "SELECT COUNT(*) FROM "+table+" WHERE tenant_id::text = $1", kept.ID).Scan(&keptRows))
+				assert.Zero(t, doomedRows, table)
+				assert.Equal(t, 1, keptRows, table)
+			}
+		})
+	})
+
+	t.Run("RowLevelSecurity: fails closed across tenants", func(t *testing.T) {
+		tx, err := pool.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx)
+
+		// The container user is a superuser and bypasses RLS, so run the store
+		// as an ordinary role with the policy from migration 010 applied.
+		_, err = tx.Exec(ctx, `
+			ALTER TABLE memories ENABLE ROW LEVEL SECURITY;
+			ALTER TABLE memories FORCE ROW LEVEL SECURITY;
+			CREATE POLICY tenant_isolation ON memories
+				USING (tenant_id = current_setting('app.tenant_id', true))
+				WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+			CREATE ROLE rls_tester;
+			GRANT SELECT, INSERT, UPDATE, DELETE ON memories TO rls_tester;
+			SET LOCAL ROLE rls_tester;
+		`)
+		require.NoError(t, err)
+
+		store := NewPostgresMemoryStore(TxWrapper{tx}, &TestLogger{t})
+		ctxA := contextutil.WithTenant(ctx, "tenant-a")
+		ctxB := contextutil.WithTenant(ctx, "tenant-b")
+
+		memory := &Memory{ID: uuid.New().String(), TenantID: "tenant-a", Content: "secret", Confidence: 1, Version: 1}
+		require.NoError(t, store.Save(ctxA, memory))
+
+		_, err = store.Get(ctxA, memory.ID)
+		assert.NoError(t, err)
+
+		// Get has no tenant filter of its own; the policy must hide the row.
+		_, err = store.Get(ctxB, memory.ID)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		_, err = store.Get(ctx, memory.ID)
+		assert.ErrorIs(t, err, pgx.ErrNoRows)
+
+		// Writing a row for another tenant is rejected by WITH CHECK.
+		other := &Memory{ID: uuid.New().String(), TenantID: "tenant-a", Content: "spoofed", Confidence: 1, Version: 1}
+		assert.Error(t, store.Save(ctxB, other))
+	})
+
+	t.Run("Memories: batch save, get and update", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			tenantCtx := contextutil.WithTenant(ctx, "batch-tenant")
+			memories := []*Memory{
+				{ID: uuid.New().String(), TenantID: "batch-tenant", Content: "first", Confidence: 1, Version: 1},
+				{ID: uuid.New().String(), TenantID: "batch-tenant", Content: "second", Confidence: 1, Version: 1},
+			}
+			require.NoError(t, store.SaveBatch(tenantCtx, memories))
+
+			got, err := store.GetBatch(tenantCtx, []string{memories[0].ID, memories[1].ID, uuid.New().String()})
+			require.NoError(t, err)
+			assert.Len(t, got, 2)
+
+			memories[0].Confidence, memories[0].Version = 0.2, 2
+			memories[1].Confidence, memories[1].Version = 0.7, 2
+			require.NoError(t, store.UpdateBatch(tenantCtx, memories))
+			updated, err := store.Get(tenantCtx, memories[1].ID)
+			require.NoError(t, err)
+			assert.Equal(t, 0.7, updated.Confidence)
+			assert.Equal(t, 2, updated.Version)
+
+			// An update based on an older version conflicts and none is applied.
+			stale := *memories[1]
+			memories[0].Confidence, memories[0].Version = 0.9, 3
+			assert.ErrorIs(t, store.UpdateBatch(tenantCtx, []*Memory{memories[0], &stale}), ErrConflict)
+			assert.ErrorIs(t, store.Update(tenantCtx, &stale), ErrConflict)
+			updated, err = store.Get(tenantCtx, memories[0].ID)
+			require.NoError(t, err)
+			assert.Equal(t, 0.2, updated.Confidence)
+			memories[0].Confidence, memories[0].Version = 0.2, 2
+
+			events := []*models.FeedbackEvent{
+				{TenantID: "batch-tenant", MemoryID: memories[0].ID, Confidence: 0.2},
+				{TenantID: "batch-tenant", MemoryID: memories[1].ID, Confidence: 0.7},
+			}
+			require.NoError(t, store.RecordFeedbackBatch(tenantCtx, events))
+			assert.False(t, events[1].CreatedAt.IsZero())
+
+			// A failing insert rolls back the whole batch.
+			duplicate := []*Memory{
+				{ID: uuid.New().String(), TenantID: "batch-tenant", Content: "third", Confidence: 1, Version: 1},
+				memories[0],
+			}
+			assert.Error(t, store.SaveBatch(tenantCtx, duplicate))
+			_, err = store.Get(tenantCtx, duplicate[0].ID)
+			assert.ErrorIs(t, err, pgx.ErrNoRows)
+		})
+	})
+
+	t.Run("Feedback: signals and reasons", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			tenantCtx := contextutil.WithTenant(ctx, "feedback-tenant")
+			memory := &Memory{ID: uuid.New().String(), TenantID: "feedback-tenant", Content: "uses tabs", Confidence: 1, Version: 1}
+			require.NoError(t, store.Save(tenantCtx, memory))
+
+			require.NoError(t, store.RecordFeedback(tenantCtx, &models.FeedbackEvent{TenantID: "feedback-tenant", MemoryID: memory.ID, Confidence: 0.9}))
+			require.NoError(t, store.RecordFeedback(tenantCtx, &models.FeedbackEvent{
+				TenantID:   "feedback-tenant",
+				MemoryID:   memory.ID,
+				Confidence: 0.6,
+				Signal:     models.FeedbackSignalOutdated,
+				Reason:     "switched to spaces",
+				Query:      "indentation style",
+			}))
+
+			events, err := store.ListFeedbackEvents(tenantCtx, "feedback-tenant", memory.ID)
+			require.NoError(t, err)
+			require.Len(t, events, 2)
+			assert.Equal(t, models.FeedbackSignalOutdated, events[0].Signal)
+			assert.Equal(t, "switched to spaces", events[0].Reason)
+			assert.Equal(t, "indentation style", events[0].Query)
+			assert.Empty(t, events[1].Signal)
+			assert.Equal(t, 0.9, events[1].Confidence)
+
+			events, err = store.ListFeedbackEvents(ctx, "another-tenant", memory.ID)
+			require.NoError(t, err)
+			assert.Empty(t, events)
 		})
 	})
 
@@ -215,4 +616,381 @@ func TestPostgresMemoryStore(t *testing.T) {
 			assert.Equal(t, parent.ID, *retrieved.ParentID)
 		})
 	})
+
+	t.Run("Workflows: get by version", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			workflowID := uuid.New().String()
+			for _, name := range []string{"Draft", "Revised"} {
+				require.NoError(t, store.CreateWorkflow(ctx, &models.Workflow{
+					WorkflowID:  workflowID,
+					TenantID:    "tenant-1",
+					Name:        name,
+					ElementType: "workflow",
+				}))
+			}
+
+			first, err := store.GetWorkflowVersion(ctx, "tenant-1", workflowID, 1)
+			require.NoError(t, err)
+			assert.Equal(t, "Draft", first.Name)
+			assert.False(t, first.IsLatest)
+
+			_, err = store.GetWorkflowVersion(ctx, "tenant-1", workflowID, 3)
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			// Other tenants do not see the workflow, even where RLS is bypassed.
+			_, err = store.GetWorkflowVersion(ctx, "tenant-2", workflowID, 1)
+			assert.ErrorIs(t, err, ErrNotFound)
+			others, err := store.ListWorkflowVersions(ctx, "tenant-2", workflowID)
+			require.NoError(t, err)
+			assert.Empty(t, others)
+		})
+	})
+
+	t.Run("Workflows: revisions", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			workflow := &models.Workflow{WorkflowID: uuid.New().String(), TenantID: "tenant-1", Name: "Draft", ElementType: "workflow"}
+			require.NoError(t, store.CreateWorkflow(ctx, workflow))
+			assert.Equal(t, 1, workflow.Revision)
+
+			stale := *workflow
+			workflow.Name = "Edited"
+			require.NoError(t, store.UpdateWorkflow(ctx, workflow))
+			assert.Equal(t, 2, workflow.Revision)
+
+			stale.Name = "Overwritten"
+			assert.ErrorIs(t, store.UpdateWorkflow(ctx, &stale), ErrConflict)
+			got, err := store.GetWorkflow(ctx, workflow.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "Edited", got.Name)
+			assert.Equal(t, 2, got.Revision)
+		})
+	})
+
+	t.Run("Workflows: history and rollback", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			workflowID := uuid.New().String()
+			for _, name := range []string{"Original", "Broken"} {
+				require.NoError(t, store.CreateWorkflow(ctx, &models.Workflow{
+					WorkflowID:  workflowID,
+					TenantID:    "tenant-1",
+					Name:        name,
+					Status:      models.WorkflowStatusDraft,
+					ElementType: "workflow",
+					InputSchema: map[string]interface{}{"type": "object"},
+				}))
+			}
+
+			restored, err := store.RollbackWorkflow(ctx, "tenant-1", workflowID, 1, "curator@example.com")
+			require.NoError(t, err)
+			assert.Equal(t, 3, restored.Version)
+			assert.True(t, restored.IsLatest)
+			assert.Equal(t, "Original", restored.Name)
+			assert.Equal(t, models.WorkflowStatusDraft, restored.Status)
+			assert.Equal(t, "curator@example.com", restored.CreatedBy)
+			assert.Equal(t, map[string]interface{}{"type": "object"}, restored.InputSchema)
+
+			versions, err := store.ListWorkflowVersions(ctx, "tenant-1", workflowID)
+			require.NoError(t, err)
+			require.Len(t, versions, 3)
+			assert.Equal(t, []int{3, 2, 1}, []int{versions[0].Version, versions[1].Version, versions[2].Version})
+			assert.False(t, versions[1].IsLatest)
+			assert.Equal(t, models.WorkflowStatusDraft, versions[2].Status)
+
+			_, err = store.RollbackWorkflow(ctx, "tenant-1", workflowID, 9, "curator@example.com")
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			transitions, err := store.ListWorkflowTransitions(ctx, "tenant-1", workflowID)
+			require.NoError(t, err)
+			require.Len(t, transitions, 1)
+			assert.Empty(t, transitions[0].FromStatus)
+			assert.Equal(t, models.WorkflowStatusDraft, transitions[0].ToStatus)
+			assert.Equal(t, "copy of version 1 for a rollback", transitions[0].Comment)
+		})
+	})
+
+	t.Run("Workflows: transitions", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			workflow := &models.Workflow{
+				WorkflowID:  uuid.New().String(),
+				TenantID:    "tenant-1",
+				Name:        "Lifecycle",
+				Status:      models.WorkflowStatusDraft,
+				ElementType: "workflow",
+			}
+			require.NoError(t, store.CreateWorkflow(ctx, workflow))
+
+			transition := &models.WorkflowTransition{
+				TenantID:   "tenant-1",
+				VersionID:  workflow.ID,
+				WorkflowID: workflow.WorkflowID,
+				Version:    workflow.Version,
+				FromStatus: models.WorkflowStatusDraft,
+				ToStatus:   models.WorkflowStatusReview,
+				Actor:      "author@example.com",
+			}
+			require.NoError(t, store.TransitionWorkflow(ctx, transition))
+			assert.NotZero(t, transition.ID)
+
+			stored, err := store.GetWorkflow(ctx, workflow.ID)
+			require.NoError(t, err)
+			assert.Equal(t, models.WorkflowStatusReview, stored.Status)
+
+			// The version is no longer a draft.
+			assert.ErrorIs(t, store.TransitionWorkflow(ctx, &models.WorkflowTransition{
+				TenantID:   "tenant-1",
+				VersionID:  workflow.ID,
+				WorkflowID: workflow.WorkflowID,
+				Version:    workflow.Version,
+				FromStatus: models.WorkflowStatusDraft,
+				ToStatus:   models.WorkflowStatusArchived,
+				Actor:      "someone@example.com",
+			}), ErrConflict)
+
+			transitions, err := store.ListWorkflowTransitions(ctx, "tenant-1", workflow.WorkflowID)
+			require.NoError(t, err)
+			require.Len(t, transitions, 1)
+			assert.Equal(t, "author@example.com", transitions[0].Actor)
+			assert.False(t, transitions[0].AllowBreaking)
+		})
+	})
+
+	t.Run("Workflows: import", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			tenant := &models.Tenant{Name: "Import Tenant", Domain: "import.com"}
+			require.NoError(t, store.CreateTenant(ctx, tenant))
+			workflowID, elementID := uuid.New().String(), uuid.New().String()
+			v1, v2 := uuid.New().String(), uuid.New().String()
+			workflows := []*models.Workflow{
+				{ID: v1, WorkflowID: workflowID, Version: 1, TenantID: tenant.ID, Name: "Flow", Status: models.WorkflowStatusPublished, ElementType: "workflow"},
+				{ID: uuid.New().String(), WorkflowID: elementID, Version: 1, IsLatest: true, TenantID: tenant.ID, Name: "Step", Status: models.WorkflowStatusPublished, ElementType: "element", ParentID: &v1, Position: 0},
+				{ID: v2, WorkflowID: workflowID, Version: 2, IsLatest: true, TenantID: tenant.ID, Name: "Flow", Status: models.WorkflowStatusDraft, ElementType: "workflow"},
+			}
+			rules := []*models.GroundingRule{
+				{ID: uuid.New().String(), TenantID: tenant.ID, WorkflowID: &workflows[1].ID, Name: "Cite", Content: "Cite sources", Status: models.GroundingRuleStatusPending, ProposedBy: "release@example.com"},
+			}
+			require.NoError(t, store.ImportWorkflows(ctx, workflows, rules, "release@example.com"))
+			assert.Equal(t, 1, workflows[0].Revision)
+			assert.Equal(t, 1, rules[0].Version)
+
+			versions, err := store.ListWorkflowVersions(ctx, tenant.ID, workflowID)
+			require.NoError(t, err)
+			require.Len(t, versions, 2)
+			assert.True(t, versions[0].IsLatest)
+			assert.Equal(t, models.WorkflowStatusPublished, versions[1].Status)
+
+			subtree, err := store.GetWorkflowSubtree(ctx, tenant.ID, v1)
+			require.NoError(t, err)
+			require.Len(t, subtree, 2)
+			assert.Equal(t, "Step", subtree[1].Name)
+
+			rule, err := store.GetGroundingRule(ctx, rules[0].ID)
+			require.NoError(t, err)
+			assert.Equal(t, workflows[1].ID, *rule.WorkflowID)
+			assert.Equal(t, models.GroundingRuleStatusPending, rule.Status)
+			assert.Equal(t, "release@example.com", rule.ProposedBy)
+			assert.Equal(t, 1, rule.PendingVersions)
+
+			transitions, err := store.ListWorkflowTransitions(ctx, tenant.ID, workflowID)
+			require.NoError(t, err)
+			require.Len(t, transitions, 2)
+			assert.Equal(t, "release@example.com", transitions[0].Actor)
+			assert.Empty(t, transitions[0].FromStatus)
+
+			// A failing version leaves nothing behind.
+			again := []*models.Workflow{
+				{ID: uuid.New().String(), WorkflowID: uuid.New().String(), Version: 1, TenantID: tenant.ID, Name: "Partial", Status: models.WorkflowStatusDraft, ElementType: "workflow"},
+				{ID: v1, WorkflowID: uuid.New().String(), Version: 1, TenantID: tenant.ID, Name: "Duplicate", Status: models.WorkflowStatusDraft, ElementType: "workflow"},
+			}
+			require.Error(t, store.ImportWorkflows(ctx, again, nil, "release@example.com"))
+			_, err = store.GetWorkflow(ctx, again[0].ID)
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	})
+
+	t.Run("Workflows: runs", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			workflow := &models.Workflow{
+				WorkflowID:  uuid.New().String(),
+				TenantID:    "tenant-1",
+				Name:        "Runnable",
+				Status:      models.WorkflowStatusDraft,
+				ElementType: "workflow",
+			}
+			require.NoError(t, store.CreateWorkflow(ctx, workflow))
+			memory := &Memory{ID: uuid.New().String(), TenantID: "tenant-1", Content: "learned in a run", Confidence: 1, Version: 1}
+			require.NoError(t, store.Save(ctx, memory))
+			foreign := &Memory{ID: uuid.New().String(), TenantID: "tenant-2", Content: "not yours", Confidence: 1, Version: 1}
+			require.NoError(t, store.Save(contextutil.WithTenant(ctx, "tenant-2"), foreign))
+
+			run := &models.WorkflowRun{
+				TenantID:   "tenant-1",
+				VersionID:  workflow.ID,
+				WorkflowID: workflow.WorkflowID,
+				Version:    workflow.Version,
+				Inputs:     "a plain string",
+				StartedBy:  "agent@example.com",
+			}
+			require.NoError(t, store.CreateWorkflowRun(ctx, run))
+			assert.NotEmpty(t, run.ID)
+			assert.Equal(t, models.WorkflowRunStatusRunning, run.Status)
+
+			run.Status = models.WorkflowRunStatusSucceeded
+			run.Outputs = map[string]interface{}{"ok": true}
+			run.CreatedMemoryIDs = []string{memory.ID, foreign.ID}
+			require.NoError(t, store.UpdateWorkflowRun(ctx, run))
+			require.NotNil(t, run.DurationMs)
+
+			stored, err := store.GetWorkflowRun(ctx, run.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "a plain string", stored.Inputs)
+			assert.Equal(t, map[string]interface{}{"ok": true}, stored.Outputs)
+			assert.Equal(t, []string{memory.ID, foreign.ID}, stored.CreatedMemoryIDs)
+			assert.Empty(t, stored.RecalledMemoryIDs)
+			assert.NotNil(t, stored.CompletedAt)
+
+			linked, err := store.Get(ctx, memory.ID)
+			require.NoError(t, err)
+			assert.Equal(t, run.ID, linked.Provenance["workflow_run_id"])
+			assert.Equal(t, workflow.ID, linked.WorkflowID)
+			assert.Equal(t, 2, linked.Version)
+
+			// Memories of other tenants are left alone.
+			untouched, err := store.Get(contextutil.WithTenant(ctx, "tenant-2"), foreign.ID)
+			require.NoError(t, err)
+			assert.Nil(t, untouched.Provenance["workflow_run_id"])
+			assert.Equal(t, 1, untouched.Version)
+
+			// Completed runs no longer change.
+			run.Status = models.WorkflowRunStatusFailed
+			assert.ErrorIs(t, store.UpdateWorkflowRun(ctx, run), ErrConflict)
+
+			runs, err := store.ListWorkflowRuns(ctx, workflow.TenantID, workflow.WorkflowID)
+			require.NoError(t, err)
+			require.Len(t, runs, 1)
+			assert.Equal(t, models.WorkflowRunStatusSucceeded, runs[0].Status)
+			runs, err = store.ListWorkflowRuns(ctx, "another-tenant", workflow.WorkflowID)
+			require.NoError(t, err)
+			assert.Empty(t, runs)
+
+			_, err = store.GetWorkflowRun(ctx, uuid.New().String())
+			assert.ErrorIs(t, err, ErrNotFound)
+		})
+	})
+
+	t.Run("Workflows: version performance", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			first := &models.Workflow{WorkflowID: uuid.New().String(), TenantID: "tenant-1", Name: "Measured", Status: models.WorkflowStatusDraft, ElementType: "workflow"}
+			require.NoError(t, store.CreateWorkflow(ctx, first))
+			second := &models.Workflow{WorkflowID: first.WorkflowID, TenantID: "tenant-1", Name: "Measured", Status: models.WorkflowStatusDraft, ElementType: "workflow"}
+			require.NoError(t, store.CreateWorkflow(ctx, second))
+
+			memory := &Memory{ID: uuid.New().String(), TenantID: "tenant-1", Content: "works", Confidence: 0.8, Version: 1, WorkflowID: first.ID}
+			require.NoError(t, store.Save(ctx, memory))
+			require.NoError(t, store.RecordFeedback(ctx, &models.FeedbackEvent{TenantID: "tenant-1", MemoryID: memory.ID, Confidence: 0.8, Signal: models.FeedbackSignalHelpful}))
+
+			run := &models.WorkflowRun{TenantID: "tenant-1", VersionID: second.ID, WorkflowID: second.WorkflowID, Version: second.Version, StartedBy: "agent@example.com"}
+			require.NoError(t, store.CreateWorkflowRun(ctx, run))
+			run.Status = models.WorkflowRunStatusSucceeded
+			require.NoError(t, store.UpdateWorkflowRun(ctx, run))
+
+			versions, err := store.GetWorkflowVersionPerformance(ctx, "tenant-1", first.WorkflowID, time.Now().Add(-time.Hour))
+			require.NoError(t, err)
+			require.Len(t, versions, 2)
+			assert.Equal(t, 1, versions[0].Memories)
+			assert.InDelta(t, 0.8, versions[0].AverageConfidence, 1e-9)
+			assert.Equal(t, 1, versions[0].PositiveFeedback)
+			assert.Zero(t, versions[0].Runs)
+			assert.Equal(t, 1, versions[1].SucceededRuns)
+			assert.Zero(t, versions[1].Memories)
+		})
+	})
+
+	t.Run("Workflows: tree, moves and clones", func(t *testing.T) {
+		withTx(t, func(store *PostgresMemoryStore) {
+			create := func(name string, parentID *string) *models.Workflow {
+				w := &models.Workflow{
+					WorkflowID:  uuid.New().String(),
+					TenantID:    "tenant-1",
+					Name:        name,
+					Status:      models.WorkflowStatusDraft,
+					ParentID:    parentID,
+					ElementType: "element",
+				}
+				require.NoError(t, store.CreateWorkflow(ctx, w))
+				return w
+			}
+			names := func(workflows []*models.Workflow) []string {
+				result := make([]string, len(workflows))
+				for i, w := range workflows {
+					result[i] = w.Name
+				}
+				return result
+			}
+
+			root := create("Root", nil)
+			first := create("First", &root.ID)
+			second := create("Second", &root.ID)
+			create("Detail", &first.ID)
+
+			subtree, err := store.GetWorkflowSubtree(ctx, "tenant-1", root.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"Root", "First", "Second", "Detail"}, names(subtree))
+
+			// Put Second before First, then nest First under Second.
+			require.NoError(t, store.MoveWorkflowElements(ctx, "tenant-1", []models.WorkflowMove{
+				{ID: second.ID, ParentID: &root.ID, Position: 0},
+				{ID: first.ID, ParentID: &second.ID, Position: 5},
+			}))
+			subtree, err = store.GetWorkflowSubtree(ctx, "tenant-1", root.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"Root", "Second", "First", "Detail"}, names(subtree))
+			assert.Equal(t, second.ID, *subtree[2].ParentID)
+
+			// Nesting a version under its own descendant is rejected.
+			err = store.MoveWorkflowElements(ctx, "tenant-1", []models.WorkflowMove{{ID: root.ID, ParentID: &first.ID}})
+			assert.ErrorIs(t, err, ErrConflict)
+
+			clones, err := store.CloneWorkflowSubtree(ctx, "tenant-1", root.ID, "editor@example.com")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"Root", "Second", "First", "Detail"}, names(clones))
+			assert.Equal(t, root.WorkflowID, clones[0].WorkflowID)
+			assert.Equal(t, 2, clones[0].Version)
+			assert.Equal(t, clones[0].ID, *clones[1].ParentID)
+			for _, clone := range clones {
+				assert.True(t, clone.IsLatest)
+				assert.Equal(t, "editor@example.com", clone.CreatedBy)
+			}
+
+			// The original tree keeps its own versions.
+			subtree, err = store.GetWorkflowSubtree(ctx, "tenant-1", root.ID)
+			require.NoError(t, err)
+			assert.Equal(t, second.ID, subtree[1].ID)
+
+			_, err = store.GetWorkflowSubtree(ctx, "tenant-1", uuid.New().String())
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			// Other tenants neither see nor move the tree.
+			_, err = store.GetWorkflowSubtree(ctx, "tenant-2", root.ID)
+			assert.ErrorIs(t, err, ErrNotFound)
+			err = store.MoveWorkflowElements(ctx, "tenant-2", []models.WorkflowMove{{ID: second.ID, ParentID: &root.ID}})
+			assert.ErrorIs(t, err, ErrNotFound)
+
+			// Moving a draft to the top level leaves published versions as
+			// they are.
+			published := create("Published", nil)
+			require.NoError(t, store.TransitionWorkflow(ctx, &models.WorkflowTransition{
+				TenantID: "tenant-1", VersionID: published.ID, WorkflowID: published.WorkflowID, Version: 1,
+				FromStatus: models.WorkflowStatusDraft, ToStatus: models.WorkflowStatusPublished, Actor: "curator@example.com",
+			}))
+			before, err := store.GetWorkflow(ctx, published.ID)
+			require.NoError(t, err)
+			require.NoError(t, store.MoveWorkflowElements(ctx, "tenant-1", []models.WorkflowMove{{ID: clones[1].ID, Position: 0}}))
+			after, err := store.GetWorkflow(ctx, published.ID)
+			require.NoError(t, err)
+			assert.Equal(t, before.Revision, after.Revision)
+			assert.Equal(t, before.Position, after.Position)
+			err = store.MoveWorkflowElements(ctx, "tenant-1", []models.WorkflowMove{{ID: published.ID, Position: 1}})
+			assert.ErrorIs(t, err, ErrConflict)
+		})
+	})
 }
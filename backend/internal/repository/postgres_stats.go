@@ -0,0 +1,390 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/pkg/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// statsTopN bounds the ranked lists in TenantStats.
+const statsTopN = 10
+
+// RecordRecall stores a recall event for usage analytics.
+func (s *PostgresMemoryStore) RecordRecall(ctx context.Context, event *models.RecallEvent) error {
+	return s.withTenant(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, `
+			INSERT INTO recall_events (tenant_id, latency_ms, memory_ids, grounding_rule_ids, grounding_rule_versions)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING created_at`, event.TenantID, event.LatencyMs, nonNil(event.MemoryIDs), nonNil(event.GroundingRuleIDs),
+			nonNil(event.GroundingRuleVersions)).Scan(&event.CreatedAt)
+	})
+}
+
+// ListMemoryRecalls returns the latest recalls that returned a memory, newest
+// first, with the grounding rules that matched each as they read in the
+// version then in effect.
+func (s *PostgresMemoryStore) ListMemoryRecalls(ctx context.Context, tenantID, memoryID string, limit int) ([]*models.MemoryRecall, error) {
+	recalls := make([]*models.MemoryRecall, 0)
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			SELECT e.id, e.created_at, e.latency_ms, r.rule_id, COALESCE(r.version, 0), COALESCE(v.name, ''), COALESCE(v.content, '')
+			FROM (
+				SELECT id, created_at, latency_ms, grounding_rule_ids, grounding_rule_versions
+				FROM recall_events
+				WHERE tenant_id = $1 AND memory_ids @> ARRAY[$2::uuid]
+				ORDER BY created_at DESC, id DESC
+				LIMIT $3
+			) e
+			LEFT JOIN LATERAL unnest(e.grounding_rule_ids, e.grounding_rule_versions) WITH ORDINALITY AS r(rule_id, version, position) ON true
+			LEFT JOIN grounding_rule_versions v ON v.rule_id = r.rule_id AND v.version = r.version
+			ORDER BY e.created_at DESC, e.id DESC, r.position`, tenantID, memoryID, limit)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		// Rows come one per matching rule, grouped by recall.
+		var recall *models.MemoryRecall
+		var recallID int64
+		for rows.Next() {
+			var id int64
+			var recalledAt time.Time
+			var latencyMs float64
+			var ruleID *string
+			var rule models.RecalledGroundingRule
+			if err := rows.Scan(&id, &recalledAt, &latencyMs, &ruleID, &rule.Version, &rule.Name, &rule.Content); err != nil {
+				return err
+			}
+			if recall == nil || id != recallID {
+				recall = &models.MemoryRecall{RecalledAt: recalledAt, LatencyMs: latencyMs, GroundingRules: make([]models.RecalledGroundingRule, 0)}
+				recallID = id
+				recalls = append(recalls, recall)
+			}
+			if ruleID != nil {
+				rule.RuleID = *ruleID
+				recall.GroundingRules = append(recall.GroundingRules, rule)
+			}
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memory recalls: %w", err)
+	}
+	return recalls, nil
+}
+
+// RecordFeedback stores a feedback event for usage analytics.
+func (s *PostgresMemoryStore) RecordFeedback(ctx context.Context, event *models.FeedbackEvent) error {
+	return s.withTenant(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, `
+			INSERT INTO feedback_events (tenant_id, memory_id, confidence, signal, reason, query)
+			VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''))
+			RETURNING created_at`, event.TenantID, event.MemoryID, event.Confidence,
+			event.Signal, event.Reason, event.Query).Scan(&event.CreatedAt)
+	})
+}
+
+// ListFeedbackEvents returns the feedback given on a memory of a tenant,
+// newest first.
+func (s *PostgresMemoryStore) ListFeedbackEvents(ctx context.Context, tenantID, memoryID string) ([]*models.FeedbackEvent, error) {
+	var events []*models.FeedbackEvent
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			SELECT tenant_id, memory_id, confidence, COALESCE(signal, ''), COALESCE(reason, ''), COALESCE(query, ''), created_at
+			FROM feedback_events
+			WHERE tenant_id = $1 AND memory_id = $2
+			ORDER BY created_at DESC, id DESC`, tenantID, memoryID)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var event models.FeedbackEvent
+			if err := rows.Scan(&event.TenantID, &event.MemoryID, &event.Confidence, &event.Signal, &event.Reason, &event.Query, &event.CreatedAt); err != nil {
+				return err
+			}
+			events = append(events, &event)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feedback events: %w", err)
+	}
+	return events, nil
+}
+
+// GetTenantStats aggregates a tenant's memories and the usage events recorded
+// since the given time.
+func (s *PostgresMemoryStore) GetTenantStats(ctx context.Context, tenantID string, since time.Time) (*models.TenantStats, error) {
+	ctx = contextutil.WithTenant(ctx, tenantID)
+	stats := &models.TenantStats{
+		TenantID:            tenantID,
+		ConfidenceBands:     make([]models.ConfidenceBand, 0),
+		FeedbackOverTime:    make([]models.DailyCount, 0),
+		TopRecalledMemories: make([]models.RecalledMemory, 0),
+		WorkflowUsage:       make([]models.WorkflowUsage, 0),
+		Grounding:           models.GroundingStats{Rules: make([]models.GroundingRuleHits, 0)},
+	}
+
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		for _, step := range []func(context.Context, pgx.Tx, string, time.Time, *models.TenantStats) error{
+			confidenceBands,
+			feedbackOverTime,
+			topRecalledMemories,
+			recallLatency,
+			groundingHits,
+			workflowUsage,
+		} {
+			if err := step(ctx, tx, tenantID, since, stats); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute tenant stats: %w", err)
+	}
+	return stats, nil
+}
+
+// confidenceBands buckets memories into quarters of the [0, 1] confidence range.
+func confidenceBands(ctx context.Context, tx pgx.Tx, tenantID string, _ time.Time, stats *models.TenantStats) error {
+	counts := make([]int, 4)
+	rows, err := tx.Query(ctx, `
+		SELECT LEAST(GREATEST(width_bucket(confidence, 0, 1, 4), 1), 4) AS band, COUNT(*)
+		FROM memories WHERE tenant_id = $1
+		GROUP BY band`, tenantID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var band, count int
+		if err := rows.Scan(&band, &count); err != nil {
+			return err
+		}
+		counts[band-1] = count
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i, count := range counts {
+		stats.ConfidenceBands = append(stats.ConfidenceBands, models.ConfidenceBand{
+			Min:   float64(i) * 0.25,
+			Max:   float64(i+1) * 0.25,
+			Count: count,
+		})
+		stats.TotalMemories += count
+	}
+	return nil
+}
+
+// feedbackOverTime counts feedback events per UTC day, including empty days.
+func feedbackOverTime(ctx context.Context, tx pgx.Tx, tenantID string, since time.Time, stats *models.TenantStats) error {
+	rows, err := tx.Query(ctx, `
+		SELECT d.day, COUNT(f.id)
+		FROM generate_series(date_trunc('day', $2::timestamptz AT TIME ZONE 'UTC'), date_trunc('day', NOW() AT TIME ZONE 'UTC'), interval '1 day') AS d(day)
+		LEFT JOIN feedback_events f
+			ON f.tenant_id = $1 AND date_trunc('day', f.created_at AT TIME ZONE 'UTC') = d.day
+		GROUP BY d.day
+		ORDER BY d.day`, tenantID, since)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var dc models.DailyCount
+		if err := rows.Scan(&dc.Day, &dc.Count); err != nil {
+			return err
+		}
+		dc.Day = dc.Day.UTC()
+		stats.FeedbackOverTime = append(stats.FeedbackOverTime, dc)
+	}
+	return rows.Err()
+}
+
+// topRecalledMemories ranks memories by how often recalls returned them.
+func topRecalledMemories(ctx context.Context, tx pgx.Tx, tenantID string, since time.Time, stats *models.TenantStats) error {
+	rows, err := tx.Query(ctx, `
+		SELECT m.id, LEFT(m.content, 200), m.confidence, r.recalls
+		FROM (
+			SELECT memory_id, COUNT(*) AS recalls
+			FROM recall_events, unnest(memory_ids) AS memory_id
+			WHERE tenant_id = $1 AND created_at >= $2
+			GROUP BY memory_id
+		) r
+		JOIN memories m ON m.id = r.memory_id
+		ORDER BY r.recalls DESC, m.id
+		LIMIT $3`, tenantID, since, statsTopN)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var rm models.RecalledMemory
+		if err := rows.Scan(&rm.MemoryID, &rm.Content, &rm.Confidence, &rm.Recalls); err != nil {
+			return err
+		}
+		stats.TopRecalledMemories = append(stats.TopRecalledMemories, rm)
+	}
+	return rows.Err()
+}
+
+// recallLatency computes latency percentiles over the window's recalls.
+func recallLatency(ctx context.Context, tx pgx.Tx, tenantID string, since time.Time, stats *models.TenantStats) error {
+	l := &stats.RecallLatency
+	return tx.QueryRow(ctx, `
+		SELECT COUNT(*),
+			COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY latency_ms), 0),
+			COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY latency_ms), 0),
+			COALESCE(percentile_cont(0.99) WITHIN GROUP (ORDER BY latency_ms), 0),
+			COALESCE(MAX(latency_ms), 0)
+		FROM recall_events
+		WHERE tenant_id = $1 AND created_at >= $2`, tenantID, since).Scan(&l.Count, &l.P50, &l.P90, &l.P99, &l.Max)
+}
+
+// groundingHits reports how many recalls matched at least one grounding rule
+// and how often each rule matched.
+func groundingHits(ctx context.Context, tx pgx.Tx, tenantID string, since time.Time, stats *models.TenantStats) error {
+	g := &stats.Grounding
+	err := tx.QueryRow(ctx, `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE cardinality(grounding_rule_ids) > 0)
+		FROM recall_events
+		WHERE tenant_id = $1 AND created_at >= $2`, tenantID, since).Scan(&g.Recalls, &g.RecallsWithRules)
+	if err != nil {
+		return err
+	}
+	if g.Recalls == 0 {
+		return nil
+	}
+	g.HitRate = float64(g.RecallsWithRules) / float64(g.Recalls)
+
+	rows, err := tx.Query(ctx, `
+		SELECT g.id, g.name, r.hits
+		FROM (
+			SELECT rule_id, COUNT(*) AS hits
+			FROM recall_events, unnest(grounding_rule_ids) AS rule_id
+			WHERE tenant_id = $1 AND created_at >= $2
+			GROUP BY rule_id
+		) r
+		JOIN grounding_rules g ON g.id = r.rule_id
+		ORDER BY r.hits DESC, g.id
+		LIMIT $3`, tenantID, since, statsTopN)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var h models.GroundingRuleHits
+		if err := rows.Scan(&h.RuleID, &h.Name, &h.Hits); err != nil {
+			return err
+		}
+		h.HitRate = float64(h.Hits) / float64(g.Recalls)
+		g.Rules = append(g.Rules, h)
+	}
+	return rows.Err()
+}
+
+// workflowUsage counts memories per workflow and how often they were recalled.
+func workflowUsage(ctx context.Context, tx pgx.Tx, tenantID string, since time.Time, stats *models.TenantStats) error {
+	rows, err := tx.Query(ctx, `
+		SELECT w.workflow_id, (array_agg(w.name ORDER BY w.version DESC))[1],
+			COUNT(m.id), COALESCE(SUM(r.recalls), 0)
+		FROM memories m
+		JOIN workflows w ON w.id = m.workflow_id
+		LEFT JOIN (
+			SELECT memory_id, COUNT(*) AS recalls
+			FROM recall_events, unnest(memory_ids) AS memory_id
+			WHERE tenant_id = $1 AND created_at >= $2
+			GROUP BY memory_id
+		) r ON r.memory_id = m.id
+		WHERE m.tenant_id = $1
+		GROUP BY w.workflow_id
+		ORDER BY 4 DESC, 3 DESC, w.workflow_id
+		LIMIT $3`, tenantID, since, statsTopN)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var wu models.WorkflowUsage
+		if err := rows.Scan(&wu.WorkflowID, &wu.Name, &wu.Memories, &wu.Recalls); err != nil {
+			return err
+		}
+		stats.WorkflowUsage = append(stats.WorkflowUsage, wu)
+	}
+	return rows.Err()
+}
+
+// GetWorkflowVersionPerformance aggregates the memories, feedback and runs of
+// every version of a workflow, oldest version first. Feedback and runs are
+// counted since the given time; memory confidence is current.
+func (s *PostgresMemoryStore) GetWorkflowVersionPerformance(ctx context.Context, tenantID, workflowID string, since time.Time) ([]models.VersionPerformance, error) {
+	ctx = contextutil.WithTenant(ctx, tenantID)
+	versions := make([]models.VersionPerformance, 0)
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			SELECT w.id, w.version, w.status,
+				m.memories, COALESCE(m.average, 0), COALESCE(m.stddev, 0),
+				f.feedback, f.positive, f.negative,
+				r.runs, r.succeeded, r.failed, r.cancelled, COALESCE(r.median_ms, 0)
+			FROM workflows w
+			CROSS JOIN LATERAL (
+				SELECT COUNT(*) AS memories, AVG(confidence) AS average, STDDEV_SAMP(confidence) AS stddev
+				FROM memories WHERE tenant_id = $1 AND workflow_id = w.id
+			) m
+			CROSS JOIN LATERAL (
+				SELECT COUNT(*) AS feedback,
+					COUNT(*) FILTER (WHERE f.signal = 'helpful') AS positive,
+					COUNT(*) FILTER (WHERE f.signal IN ('not_helpful', 'incorrect', 'outdated')) AS negative
+				FROM feedback_events f JOIN memories fm ON fm.id = f.memory_id
+				WHERE f.tenant_id = $1 AND fm.workflow_id = w.id AND f.created_at >= $3
+			) f
+			CROSS JOIN LATERAL (
+				SELECT COUNT(*) AS runs,
+					COUNT(*) FILTER (WHERE status = 'succeeded') AS succeeded,
+					COUNT(*) FILTER (WHERE status = 'failed') AS failed,
+					COUNT(*) FILTER (WHERE status = 'cancelled') AS cancelled,
+					percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM completed_at - started_at) * 1000)
+						FILTER (WHERE status = 'succeeded') AS median_ms
+				FROM workflow_runs WHERE tenant_id = $1 AND version_id = w.id AND started_at >= $3
+			) r
+			WHERE w.tenant_id = $1 AND w.workflow_id = $2
+			ORDER BY w.version`, tenantID, workflowID, since)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var v models.VersionPerformance
+			if err := rows.Scan(&v.VersionID, &v.Version, &v.Status,
+				&v.Memories, &v.AverageConfidence, &v.ConfidenceStdDev,
+				&v.Feedback, &v.PositiveFeedback, &v.NegativeFeedback,
+				&v.Runs, &v.SucceededRuns, &v.FailedRuns, &v.CancelledRuns, &v.MedianRunDurationMs); err != nil {
+				return err
+			}
+			versions = append(versions, v)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute workflow performance: %w", err)
+	}
+	return versions, nil
+}
+
+// nonNil turns a nil slice into an empty one so it is stored as '{}' rather
+// than NULL.
+func nonNil[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/pkg/models"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// tenantColumns selects a tenant row in the order expected by scanTenant.
+const tenantColumns = `t.id, t.name, t.domain,
+	ARRAY(SELECT d.domain FROM tenant_domains d WHERE d.tenant_id = t.id ORDER BY d.domain),
+	COALESCE(t.logo_svg, ''), COALESCE(t.brand_title, ''), t.status,
+	t.quota_max_memories, t.quota_embeddings_per_minute, t.quota_rest_requests_per_second, t.quota_mcp_requests_per_second,
+	t.created_at, t.updated_at`
+
+func scanTenant(row pgx.Row) (*models.Tenant, error) {
+	var t models.Tenant
+	q := &t.Quotas
+	if err := row.Scan(&t.ID, &t.Name, &t.Domain, &t.Domains, &t.LogoSVG, &t.BrandTitle, &t.Status,
+		&q.MaxMemories, &q.EmbeddingsPerMinute, &q.RESTRequestsPerSecond, &q.MCPRequestsPerSecond,
+		&t.CreatedAt, &t.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// isUniqueViolation reports whether err is a Postgres unique constraint violation.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// isForeignKeyViolation reports whether err is a Postgres foreign key violation.
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
+
+// GetTenantByDomain retrieves a tenant by any of their email domains.
+func (s *PostgresMemoryStore) GetTenantByDomain(ctx context.Context, domain string) (*models.Tenant, error) {
+	return scanTenant(s.db.QueryRow(ctx, "SELECT "+tenantColumns+" FROM tenants t JOIN tenant_domains td ON td.tenant_id = t.id WHERE td.domain = $1", domain))
+}
+
+// GetTenantByID retrieves a tenant by their ID.
+func (s *PostgresMemoryStore) GetTenantByID(ctx context.Context, id string) (*models.Tenant, error) {
+	return scanTenant(s.db.QueryRow(ctx, "SELECT "+tenantColumns+" FROM tenants t WHERE t.id = $1", id))
+}
+
+// ListTenantsForMember returns the tenants a user is an explicit member of,
+// oldest membership first.
+func (s *PostgresMemoryStore) ListTenantsForMember(ctx context.Context, email string) ([]*models.Tenant, error) {
+	rows, err := s.db.Query(ctx, "SELECT "+tenantColumns+" FROM tenants t JOIN tenant_members m ON m.tenant_id = t.id WHERE m.email = $1 ORDER BY m.created_at, t.id", email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenants := make([]*models.Tenant, 0)
+	for rows.Next() {
+		t, err := scanTenant(rows)
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, t)
+	}
+	return tenants, rows.Err()
+}
+
+// ListTenants lists every tenant ordered by name.
+func (s *PostgresMemoryStore) ListTenants(ctx context.Context) ([]*models.Tenant, error) {
+	rows, err := s.db.Query(ctx, "SELECT "+tenantColumns+" FROM tenants t ORDER BY t.name, t.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tenants := make([]*models.Tenant, 0)
+	for rows.Next() {
+		t, err := scanTenant(rows)
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, t)
+	}
+	return tenants, rows.Err()
+}
+
+// CreateTenant creates a new tenant and registers its primary domain along
+// with any additional entries in tenant.Domains.
+func (s *PostgresMemoryStore) CreateTenant(ctx context.Context, tenant *models.Tenant) error {
+	if tenant.Status == "" {
+		tenant.Status = models.TenantStatusActive
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO tenants (name, domain, logo_svg, brand_title, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING id, created_at, updated_at`, tenant.Name, tenant.Domain, tenant.LogoSVG, tenant.BrandTitle, tenant.Status).Scan(&tenant.ID, &tenant.CreatedAt, &tenant.UpdatedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("domain %q is already registered: %w", tenant.Domain, ErrConflict)
+		}
+		return err
+	}
+
+	domains := []string{tenant.Domain}
+	for _, d := range tenant.Domains {
+		if d != tenant.Domain {
+			domains = append(domains, d)
+		}
+	}
+	for _, d := range domains {
+		if _, err := tx.Exec(ctx, "INSERT INTO tenant_domains (domain, tenant_id) VALUES ($1, $2)", d, tenant.ID); err != nil {
+			if isUniqueViolation(err) {
+				return fmt.Errorf("domain %q is already registered: %w", d, ErrConflict)
+			}
+			return fmt.Errorf("failed to register domain %q: %w", d, err)
+		}
+	}
+	tenant.Domains = domains
+
+	return tx.Commit(ctx)
+}
+
+// UpdateTenant updates the name, branding, status and quota overrides of a tenant.
+func (s *PostgresMemoryStore) UpdateTenant(ctx context.Context, tenant *models.Tenant) error {
+	s.logger.Debug("Updating tenant", "id", tenant.ID, "status", tenant.Status)
+	q := tenant.Quotas
+	err := s.db.QueryRow(ctx, `
+		UPDATE tenants
+		SET name = $1, logo_svg = $2, brand_title = $3, status = $4,
+			quota_max_memories = $5, quota_embeddings_per_minute = $6,
+			quota_rest_requests_per_second = $7, quota_mcp_requests_per_second = $8,
+			updated_at = NOW()
+		WHERE id = $9
+		RETURNING updated_at`, tenant.Name, tenant.LogoSVG, tenant.BrandTitle, tenant.Status,
+		q.MaxMemories, q.EmbeddingsPerMinute, q.RESTRequestsPerSecond, q.MCPRequestsPerSecond,
+		tenant.ID).Scan(&tenant.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// DeleteTenant removes a tenant and every memory, workflow and grounding rule it
+// owns, along with its recorded recall and feedback events. The deletes run
+// under the tenant's own row-level security scope.
+func (s *PostgresMemoryStore) DeleteTenant(ctx context.Context, id string) error {
+	s.logger.Info("Deleting tenant", "id", id)
+	ctx = contextutil.WithTenant(ctx, id)
+	return s.withTenant(ctx, func(tx pgx.Tx) error {
+		for _, stmt := range []string{
+			"DELETE FROM grounding_rules WHERE tenant_id::text = $1",
+			"DELETE FROM memories WHERE tenant_id = $1",
+			"DELETE FROM workflows WHERE tenant_id = $1",
+			"DELETE FROM recall_events WHERE tenant_id = $1",
+			"DELETE FROM feedback_events WHERE tenant_id = $1",
+		} {
+			if _, err := tx.Exec(ctx, stmt, id); err != nil {
+				return fmt.Errorf("failed to delete tenant data: %w", err)
+			}
+		}
+
+		tag, err := tx.Exec(ctx, "DELETE FROM tenants WHERE id = $1", id)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+// AddTenantDomain maps an additional email domain to a tenant.
+func (s *PostgresMemoryStore) AddTenantDomain(ctx context.Context, tenantID, domain string) error {
+	_, err := s.db.Exec(ctx, "INSERT INTO tenant_domains (domain, tenant_id) VALUES ($1, $2)", domain, tenantID)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("domain %q is already registered: %w", domain, ErrConflict)
+	}
+	if isForeignKeyViolation(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// RemoveTenantDomain unmaps an email domain from a tenant.
+func (s *PostgresMemoryStore) RemoveTenantDomain(ctx context.Context, tenantID, domain string) error {
+	tag, err := s.db.Exec(ctx, "DELETE FROM tenant_domains WHERE domain = $1 AND tenant_id = $2", domain, tenantID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListTenantMembers lists the explicit members of a tenant ordered by email.
+func (s *PostgresMemoryStore) ListTenantMembers(ctx context.Context, tenantID string) ([]*models.TenantMember, error) {
+	rows, err := s.db.Query(ctx, "SELECT tenant_id, email, created_at FROM tenant_members WHERE tenant_id = $1 ORDER BY email", tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := make([]*models.TenantMember, 0)
+	for rows.Next() {
+		var m models.TenantMember
+		if err := rows.Scan(&m.TenantID, &m.Email, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, &m)
+	}
+	return members, rows.Err()
+}
+
+// AddTenantMember grants a user explicit access to a tenant.
+func (s *PostgresMemoryStore) AddTenantMember(ctx context.Context, member *models.TenantMember) error {
+	err := s.db.QueryRow(ctx, `
+		INSERT INTO tenant_members (tenant_id, email, created_at)
+		VALUES ($1, $2, NOW())
+		RETURNING created_at`, member.TenantID, member.Email).Scan(&member.CreatedAt)
+	if isUniqueViolation(err) {
+		return fmt.Errorf("%s is already a member: %w", member.Email, ErrConflict)
+	}
+	if isForeignKeyViolation(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// RemoveTenantMember revokes a user's explicit access to a tenant.
+func (s *PostgresMemoryStore) RemoveTenantMember(ctx context.Context, tenantID, email string) error {
+	tag, err := s.db.Exec(ctx, "DELETE FROM tenant_members WHERE tenant_id = $1 AND email = $2", tenantID, email)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
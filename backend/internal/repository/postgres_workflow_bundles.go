@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// importedComment is recorded on the transition that creates an imported
+// version.
+const importedComment = "imported from a workflow bundle"
+
+// ImportWorkflows inserts workflow versions as they are, with their IDs,
+// version numbers and statuses, and then the grounding rules linked to them
+// at their first version, in one transaction. Parents must come before their
+// children. Each version gets a transition into its status by actor, so the
+// history shows where it came from.
+func (s *PostgresMemoryStore) ImportWorkflows(ctx context.Context, workflows []*models.Workflow, rules []*models.GroundingRule, actor string) error {
+	s.logger.Debug("Importing workflows", "versions", len(workflows), "grounding_rules", len(rules))
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		for _, workflow := range workflows {
+			err := tx.QueryRow(ctx, `
+				INSERT INTO workflows (id, tenant_id, workflow_id, version, is_latest, name, description, status, parent_id, element_type, input_schema, output_schema, created_by, position, created_at, updated_at)
+				VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NOW(), NOW())
+				RETURNING revision, created_at, updated_at
+			`, workflow.ID, workflow.TenantID, workflow.WorkflowID, workflow.Version, workflow.IsLatest, workflow.Name, workflow.Description, workflow.Status,
+				workflow.ParentID, workflow.ElementType, workflow.InputSchema, workflow.OutputSchema, workflow.CreatedBy, workflow.Position,
+			).Scan(&workflow.Revision, &workflow.CreatedAt, &workflow.UpdatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to insert version %d of workflow %s: %w", workflow.Version, workflow.WorkflowID, err)
+			}
+			err = insertWorkflowTransition(ctx, tx, &models.WorkflowTransition{
+				TenantID:   workflow.TenantID,
+				VersionID:  workflow.ID,
+				WorkflowID: workflow.WorkflowID,
+				Version:    workflow.Version,
+				ToStatus:   workflow.Status,
+				Actor:      actor,
+				Comment:    importedComment,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for _, rule := range rules {
+			rule.Version = 1
+			err := tx.QueryRow(ctx, `
+				INSERT INTO grounding_rules (id, tenant_id, workflow_id, name, content, embedding, is_global, status, proposed_by, created_at, updated_at)
+				VALUES ($1, $2, $3, $4, $5, $6, false, $7, NULLIF($8, ''), NOW(), NOW())
+				RETURNING created_at, updated_at
+			`, rule.ID, rule.TenantID, rule.WorkflowID, rule.Name, rule.Content, rule.Embedding, rule.Status, rule.ProposedBy).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+			if err != nil {
+				return fmt.Errorf("failed to insert grounding rule %q: %w", rule.Name, err)
+			}
+			if err := insertFirstGroundingRuleVersion(ctx, tx, rule); err != nil {
+				return fmt.Errorf("failed to insert version 1 of grounding rule %q: %w", rule.Name, err)
+			}
+		}
+		return nil
+	})
+	if err == nil && s.workflowsCreated != nil {
+		for _, workflow := range workflows {
+			s.workflowsCreated.Add(ctx, 1, metric.WithAttributes(attribute.Bool("is_evolution", workflow.Version > 1)))
+		}
+	}
+	return err
+}
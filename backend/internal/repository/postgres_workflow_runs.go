@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const workflowRunColumns = `id, tenant_id, version_id, workflow_id, version, status, inputs, outputs, COALESCE(error, ''),
+	recalled_memory_ids::text[], created_memory_ids::text[], started_by, started_at, updated_at, completed_at`
+
+// CreateWorkflowRun records the start of a workflow run and fills in its ID,
+// status and timestamps.
+func (s *PostgresMemoryStore) CreateWorkflowRun(ctx context.Context, run *models.WorkflowRun) error {
+	s.logger.Debug("Starting workflow run", "workflow_id", run.WorkflowID, "version", run.Version)
+	inputs, err := jsonValue(run.Inputs)
+	if err != nil {
+		return err
+	}
+	return s.withTenant(ctx, func(tx pgx.Tx) error {
+		return tx.QueryRow(ctx, `
+			INSERT INTO workflow_runs (tenant_id, version_id, workflow_id, version, inputs, started_by)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, status, started_at, updated_at
+		`, run.TenantID, run.VersionID, run.WorkflowID, run.Version, inputs, run.StartedBy).Scan(&run.ID, &run.Status, &run.StartedAt, &run.UpdatedAt)
+	})
+}
+
+// GetWorkflowRun returns a workflow run by ID.
+func (s *PostgresMemoryStore) GetWorkflowRun(ctx context.Context, id string) (*models.WorkflowRun, error) {
+	var runs []*models.WorkflowRun
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "SELECT "+workflowRunColumns+" FROM workflow_runs WHERE id = $1", id)
+		if err != nil {
+			return err
+		}
+		runs, err = scanWorkflowRuns(rows)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow run: %w", err)
+	}
+	if len(runs) == 0 {
+		return nil, fmt.Errorf("workflow run %s: %w", id, ErrNotFound)
+	}
+	return runs[0], nil
+}
+
+// ListWorkflowRuns returns the runs of every version of a workflow, newest
+// first.
+func (s *PostgresMemoryStore) ListWorkflowRuns(ctx context.Context, tenantID, workflowID string) ([]*models.WorkflowRun, error) {
+	var runs []*models.WorkflowRun
+	err := s.withTenant(ctx, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, "SELECT "+workflowRunColumns+" FROM workflow_runs WHERE workflow_id = $1 AND tenant_id = $2 ORDER BY started_at DESC, id", workflowID, tenantID)
+		if err != nil {
+			return err
+		}
+		runs, err = scanWorkflowRuns(rows)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow runs: %w", err)
+	}
+	return runs, nil
+}
+
+// UpdateWorkflowRun stores the status, outputs, error and memories of a run
+// that is still running, completing it when its status is no longer running.
+// The memories created during the run get the run and its workflow version in
+// their provenance, in the same transaction, and a new version so concurrent
+// feedback based on the old one conflicts.
+func (s *PostgresMemoryStore) UpdateWorkflowRun(ctx context.Context, run *models.WorkflowRun) error {
+	s.logger.Debug("Updating workflow run", "id", run.ID, "status", run.Status)
+	outputs, err := jsonValue(run.Outputs)
+	if err != nil {
+		return err
+	}
+	return s.withTenant(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, `
+			UPDATE workflow_runs SET status = $1, outputs = $2, error = NULLIF($3, ''),
+				recalled_memory_ids = COALESCE($4::uuid[], '{}'), created_memory_ids = COALESCE($5::uuid[], '{}'), updated_at = NOW(),
+				completed_at = CASE WHEN $1 = 'running' THEN NULL ELSE NOW() END
+			WHERE id = $6 AND status = 'running'
+			RETURNING updated_at, completed_at
+		`, run.Status, outputs, run.Error, run.RecalledMemoryIDs, run.CreatedMemoryIDs, run.ID).Scan(&run.UpdatedAt, &run.CompletedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("workflow run %s is no longer running: %w", run.ID, ErrConflict)
+		}
+		if err != nil {
+			return err
+		}
+		setRunDuration(run)
+
+		_, err = tx.Exec(ctx, `
+			UPDATE memories
+			SET provenance = COALESCE(provenance, '{}'::jsonb) || jsonb_build_object('workflow_run_id', $1::text),
+				workflow_id = COALESCE(workflow_id, $2::uuid),
+				version = version + 1
+			WHERE tenant_id = $3 AND id = ANY($4::uuid[])
+		`, run.ID, run.VersionID, run.TenantID, run.CreatedMemoryIDs)
+		return err
+	})
+}
+
+func scanWorkflowRuns(rows pgx.Rows) ([]*models.WorkflowRun, error) {
+	defer rows.Close()
+	runs := make([]*models.WorkflowRun, 0)
+	for rows.Next() {
+		var run models.WorkflowRun
+		var inputs, outputs []byte
+		if err := rows.Scan(&run.ID, &run.TenantID, &run.VersionID, &run.WorkflowID, &run.Version, &run.Status, &inputs, &outputs, &run.Error,
+			&run.RecalledMemoryIDs, &run.CreatedMemoryIDs, &run.StartedBy, &run.StartedAt, &run.UpdatedAt, &run.CompletedAt); err != nil {
+			return nil, err
+		}
+		if inputs != nil {
+			if err := json.Unmarshal(inputs, &run.Inputs); err != nil {
+				return nil, err
+			}
+		}
+		if outputs != nil {
+			if err := json.Unmarshal(outputs, &run.Outputs); err != nil {
+				return nil, err
+			}
+		}
+		setRunDuration(&run)
+		runs = append(runs, &run)
+	}
+	return runs, rows.Err()
+}
+
+func setRunDuration(run *models.WorkflowRun) {
+	if run.CompletedAt == nil {
+		run.DurationMs = nil
+		return
+	}
+	duration := run.CompletedAt.Sub(run.StartedAt).Milliseconds()
+	run.DurationMs = &duration
+}
+
+// jsonValue encodes a free-form JSON value for a JSONB column, storing nil as
+// NULL. pgx would otherwise send strings as raw JSON text.
+func jsonValue(v interface{}) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
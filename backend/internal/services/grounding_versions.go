@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// maxMemoryRecalls bounds the recalls ListRecalls returns.
+const maxMemoryRecalls = 100
+
+// ProposeGroundingRuleChange records a change to a rule of the current tenant
+// as a pending version, based on the version in effect. The rule does not
+// change until a curator approves the version. With a non-zero baseVersion
+// the change is only proposed if that version is still in effect. The version
+// keeps the scope of the rule unless allowScopeChange lets change.IsGlobal
+// make it global or local; callers only allow that for admins.
+func (s *MemoryService) ProposeGroundingRuleChange(ctx context.Context, ruleID string, change *models.GroundingRule, baseVersion int, allowScopeChange bool) (*models.GroundingRuleVersion, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	if change.Name == "" || change.Content == "" {
+		return nil, fmt.Errorf("%w: name and content are required", ErrInvalidInput)
+	}
+	rule, err := s.ownGroundingRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Status == models.GroundingRuleStatusArchived {
+		return nil, fmt.Errorf("grounding rule %s is archived: %w", ruleID, repository.ErrConflict)
+	}
+	if baseVersion != 0 && baseVersion != rule.Version {
+		return nil, fmt.Errorf("%w: grounding rule %s is at version %d, not %d", ErrPreconditionFailed, ruleID, rule.Version, baseVersion)
+	}
+	if change.WorkflowID != nil {
+		if _, err := s.store.GetWorkflow(ctx, *change.WorkflowID); err != nil {
+			return nil, fmt.Errorf("%w: unknown workflow %s", ErrInvalidInput, *change.WorkflowID)
+		}
+	}
+
+	if err := s.quotas.AllowEmbedding(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	embedding, err := s.mlClient.GetEmbedding(ctx, change.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate embedding: %w", err)
+	}
+
+	isGlobal := rule.IsGlobal
+	if allowScopeChange {
+		isGlobal = change.IsGlobal
+	}
+	version := &models.GroundingRuleVersion{
+		TenantID:    tenantID,
+		RuleID:      ruleID,
+		BaseVersion: rule.Version,
+		WorkflowID:  change.WorkflowID,
+		Name:        change.Name,
+		Content:     change.Content,
+		Embedding:   embedding,
+		IsGlobal:    isGlobal,
+		ProposedBy:  contextutil.GetUser(ctx),
+	}
+	if err := s.store.CreateGroundingRuleVersion(ctx, version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// ApproveGroundingRuleVersion puts a pending version of a rule of the current
+// tenant into effect, with the caller as reviewer. It fails with
+// ErrForbidden when the caller proposed the version, and with
+// repository.ErrConflict when the version is no longer pending or the rule
+// has moved on from the version the change was based on.
+func (s *MemoryService) ApproveGroundingRuleVersion(ctx context.Context, ruleID string, version int, comment string) (*models.GroundingRule, error) {
+	if _, err := s.ownGroundingRule(ctx, ruleID); err != nil {
+		return nil, err
+	}
+	if err := s.checkReviewer(ctx, ruleID, version); err != nil {
+		return nil, err
+	}
+
+	rule, err := s.store.ApproveGroundingRuleVersion(ctx, ruleID, version, contextutil.GetUser(ctx), comment)
+	if err != nil {
+		return nil, err
+	}
+	s.publishGroundingRule(events.Updated, rule)
+	return rule, nil
+}
+
+// RejectGroundingRuleVersion rejects a pending version of a rule of the
+// current tenant, with the caller as reviewer. It fails with ErrForbidden
+// when the caller proposed the version.
+func (s *MemoryService) RejectGroundingRuleVersion(ctx context.Context, ruleID string, version int, comment string) (*models.GroundingRuleVersion, error) {
+	rule, err := s.ownGroundingRule(ctx, ruleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkReviewer(ctx, ruleID, version); err != nil {
+		return nil, err
+	}
+
+	rejected, err := s.store.RejectGroundingRuleVersion(ctx, ruleID, version, contextutil.GetUser(ctx), comment)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Status == models.GroundingRuleStatusPending && rejected.Version == 1 {
+		rule.Status = models.GroundingRuleStatusRejected
+		s.publishGroundingRule(events.Updated, rule)
+	}
+	return rejected, nil
+}
+
+// ListGroundingRuleVersions returns every version of a rule of the current
+// tenant, or of a global rule, newest first.
+func (s *MemoryService) ListGroundingRuleVersions(ctx context.Context, ruleID string) ([]*models.GroundingRuleVersion, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+
+	rule, err := s.store.GetGroundingRule(ctx, ruleID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && rule.TenantID != tenantID && !rule.IsGlobal) {
+		return nil, fmt.Errorf("grounding rule %s: %w", ruleID, repository.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ListGroundingRuleVersions(ctx, ruleID)
+}
+
+// ArchiveGroundingRule retires a rule of the current tenant. It is no longer
+// used at recall time, but its versions and the recalls it matched stay
+// readable.
+func (s *MemoryService) ArchiveGroundingRule(ctx context.Context, ruleID string) error {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	rule, err := s.ownGroundingRule(ctx, ruleID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.store.ArchiveGroundingRule(ctx, tenantID, ruleID, contextutil.GetUser(ctx)); err != nil {
+		return err
+	}
+	s.publishGroundingRule(events.Deleted, rule)
+	return nil
+}
+
+// ListRecalls returns the latest recalls that returned a memory of the
+// current tenant, newest first, with the version of each grounding rule that
+// matched as it was in effect then.
+func (s *MemoryService) ListRecalls(ctx context.Context, id string) ([]*models.MemoryRecall, error) {
+	memory, err := s.GetMemory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.store.ListMemoryRecalls(ctx, memory.TenantID, id, maxMemoryRecalls)
+}
+
+// ownGroundingRule returns a rule of the current tenant. Global rules of
+// other tenants are visible but not theirs to change, so they are reported
+// as not found.
+func (s *MemoryService) ownGroundingRule(ctx context.Context, ruleID string) (*models.GroundingRule, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+
+	rule, err := s.store.GetGroundingRule(ctx, ruleID)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && rule.TenantID != tenantID) {
+		return nil, fmt.Errorf("grounding rule %s: %w", ruleID, repository.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// checkReviewer makes sure a version of a rule exists and that the caller
+// did not propose it: changes are reviewed by someone else.
+func (s *MemoryService) checkReviewer(ctx context.Context, ruleID string, version int) error {
+	proposed, err := s.store.GetGroundingRuleVersion(ctx, ruleID, version)
+	if err != nil {
+		return err
+	}
+	if reviewer := contextutil.GetUser(ctx); reviewer != "" && reviewer == proposed.ProposedBy {
+		return fmt.Errorf("%w: version %d of grounding rule %s was proposed by %s, who cannot review it", ErrForbidden, version, ruleID, reviewer)
+	}
+	return nil
+}
+
+// publishGroundingRule reports a changed rule. Changes to global rules
+// concern every tenant.
+func (s *MemoryService) publishGroundingRule(kind events.Kind, rule *models.GroundingRule) {
+	if s.events != nil {
+		s.events.Publish(events.Event{Kind: kind, URI: models.GroundingRuleURI(rule.ID), TenantID: rule.TenantID, Global: rule.IsGlobal})
+	}
+}
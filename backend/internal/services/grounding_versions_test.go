@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryService_ProposeGroundingRuleChange(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	mockML := new(MockMLClient)
+	svc := NewMemoryService(mockStore, mockML)
+	ctx := contextutil.WithUser(contextutil.WithTenant(context.Background(), "test-tenant"), "editor@example.com")
+
+	mockStore.On("GetGroundingRule", ctx, "r1").Return(&models.GroundingRule{ID: "r1", TenantID: "test-tenant", Version: 2, Status: models.GroundingRuleStatusActive}, nil)
+	mockStore.On("GetGroundingRule", ctx, "shared").Return(&models.GroundingRule{ID: "shared", TenantID: "other", IsGlobal: true, Version: 1}, nil)
+	mockStore.On("GetGroundingRule", ctx, "old").Return(&models.GroundingRule{ID: "old", TenantID: "test-tenant", Version: 1, Status: models.GroundingRuleStatusArchived}, nil)
+	mockML.On("GetEmbedding", ctx, "Cite primary sources").Return([]float32{0.1, 0.2}, nil)
+	mockStore.On("CreateGroundingRuleVersion", ctx, mock.MatchedBy(func(v *models.GroundingRuleVersion) bool {
+		return v.RuleID == "r1" && v.TenantID == "test-tenant" && v.BaseVersion == 2 && v.ProposedBy == "editor@example.com" && len(v.Embedding) == 2
+	})).Return(nil)
+
+	change := &models.GroundingRule{Name: "Cite", Content: "Cite primary sources"}
+	version, err := svc.ProposeGroundingRuleChange(ctx, "r1", change, 2, false)
+	require.NoError(t, err)
+	assert.Equal(t, "Cite primary sources", version.Content)
+
+	_, err = svc.ProposeGroundingRuleChange(ctx, "r1", change, 1, false)
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+	_, err = svc.ProposeGroundingRuleChange(ctx, "shared", change, 0, false)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = svc.ProposeGroundingRuleChange(ctx, "old", change, 0, false)
+	assert.ErrorIs(t, err, repository.ErrConflict)
+	_, err = svc.ProposeGroundingRuleChange(ctx, "r1", &models.GroundingRule{Name: "Cite"}, 0, false)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockStore.AssertExpectations(t)
+	mockStore.AssertNumberOfCalls(t, "CreateGroundingRuleVersion", 1)
+}
+
+func TestMemoryService_ProposeGroundingRuleChange_KeepsScopeUnlessAllowed(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	mockML := new(MockMLClient)
+	svc := NewMemoryService(mockStore, mockML)
+	ctx := contextutil.WithUser(contextutil.WithTenant(context.Background(), "test-tenant"), "editor@example.com")
+
+	mockStore.On("GetGroundingRule", mock.Anything, "r1").Return(&models.GroundingRule{ID: "r1", TenantID: "test-tenant", Version: 1, Status: models.GroundingRuleStatusActive}, nil)
+	mockML.On("GetEmbedding", mock.Anything, "Cite primary sources").Return([]float32{0.1, 0.2}, nil)
+	var proposed []*models.GroundingRuleVersion
+	mockStore.On("CreateGroundingRuleVersion", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { proposed = append(proposed, args.Get(1).(*models.GroundingRuleVersion)) }).
+		Return(nil)
+
+	change := &models.GroundingRule{Name: "Cite", Content: "Cite primary sources", IsGlobal: true}
+	_, err := svc.ProposeGroundingRuleChange(ctx, "r1", change, 0, false)
+	require.NoError(t, err)
+	_, err = svc.ProposeGroundingRuleChange(ctx, "r1", change, 0, true)
+	require.NoError(t, err)
+
+	require.Len(t, proposed, 2)
+	assert.False(t, proposed[0].IsGlobal)
+	assert.True(t, proposed[1].IsGlobal)
+}
+
+func TestMemoryService_ReviewGroundingRuleVersion(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	publisher := &recordingPublisher{}
+	svc := NewMemoryService(mockStore, new(MockMLClient)).WithEvents(publisher)
+	ctx := contextutil.WithUser(contextutil.WithTenant(context.Background(), "test-tenant"), "curator@example.com")
+
+	mockStore.On("GetGroundingRule", ctx, "r1").Return(&models.GroundingRule{ID: "r1", TenantID: "test-tenant", Version: 1, Status: models.GroundingRuleStatusActive}, nil)
+	mockStore.On("GetGroundingRuleVersion", ctx, "r1", 2).Return(&models.GroundingRuleVersion{RuleID: "r1", Version: 2, BaseVersion: 1}, nil)
+	mockStore.On("GetGroundingRuleVersion", ctx, "r1", 9).Return(nil, repository.ErrNotFound)
+	mockStore.On("ApproveGroundingRuleVersion", ctx, "r1", 2, "curator@example.com", "clearer").
+		Return(&models.GroundingRule{ID: "r1", TenantID: "test-tenant", Version: 2, Status: models.GroundingRuleStatusActive}, nil).Once()
+	mockStore.On("ApproveGroundingRuleVersion", ctx, "r1", 2, "curator@example.com", "").
+		Return(nil, repository.ErrConflict)
+
+	rule, err := svc.ApproveGroundingRuleVersion(ctx, "r1", 2, "clearer")
+	require.NoError(t, err)
+	assert.Equal(t, 2, rule.Version)
+	assert.Equal(t, []events.Event{{Kind: events.Updated, URI: "grounding://r1", TenantID: "test-tenant"}}, publisher.events)
+
+	_, err = svc.ApproveGroundingRuleVersion(ctx, "r1", 2, "")
+	assert.ErrorIs(t, err, repository.ErrConflict)
+	_, err = svc.ApproveGroundingRuleVersion(ctx, "r1", 9, "")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	// Rejecting the first version of a proposed rule rejects the rule.
+	mockStore.On("GetGroundingRule", ctx, "p1").Return(&models.GroundingRule{ID: "p1", TenantID: "test-tenant", Version: 1, Status: models.GroundingRuleStatusPending}, nil)
+	mockStore.On("GetGroundingRuleVersion", ctx, "p1", 1).Return(&models.GroundingRuleVersion{RuleID: "p1", Version: 1}, nil)
+	mockStore.On("RejectGroundingRuleVersion", ctx, "p1", 1, "curator@example.com", "too vague").
+		Return(&models.GroundingRuleVersion{RuleID: "p1", Version: 1, Status: models.GroundingRuleVersionRejected}, nil)
+
+	rejected, err := svc.RejectGroundingRuleVersion(ctx, "p1", 1, "too vague")
+	require.NoError(t, err)
+	assert.Equal(t, models.GroundingRuleVersionRejected, rejected.Status)
+	assert.Len(t, publisher.events, 2)
+	assert.Equal(t, "grounding://p1", publisher.events[1].URI)
+
+	// Nobody reviews their own change.
+	mockStore.On("GetGroundingRuleVersion", ctx, "r1", 3).Return(&models.GroundingRuleVersion{RuleID: "r1", Version: 3, BaseVersion: 2, ProposedBy: "curator@example.com"}, nil)
+	_, err = svc.ApproveGroundingRuleVersion(ctx, "r1", 3, "")
+	assert.ErrorIs(t, err, ErrForbidden)
+	_, err = svc.RejectGroundingRuleVersion(ctx, "r1", 3, "")
+	assert.ErrorIs(t, err, ErrForbidden)
+	mockStore.AssertNumberOfCalls(t, "RejectGroundingRuleVersion", 1)
+}
+
+func TestMemoryService_ArchiveGroundingRule(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	publisher := &recordingPublisher{}
+	svc := NewMemoryService(mockStore, new(MockMLClient)).WithEvents(publisher)
+	ctx := contextutil.WithUser(contextutil.WithTenant(context.Background(), "test-tenant"), "curator@example.com")
+
+	mockStore.On("GetGroundingRule", ctx, "r1").Return(&models.GroundingRule{ID: "r1", TenantID: "test-tenant", IsGlobal: true}, nil)
+	mockStore.On("ArchiveGroundingRule", ctx, "test-tenant", "r1", "curator@example.com").Return(nil)
+
+	require.NoError(t, svc.ArchiveGroundingRule(ctx, "r1"))
+	mockStore.AssertExpectations(t)
+	assert.Equal(t, []events.Event{{Kind: events.Deleted, URI: "grounding://r1", TenantID: "test-tenant", Global: true}}, publisher.events)
+}
+
+func TestMemoryService_Recall_RecordsRuleVersions(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	mockML := new(MockMLClient)
+	svc := NewMemoryService(mockStore, mockML)
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+
+	embedding := []float32{1, 0}
+	mockStore.groundingRules = []*models.GroundingRule{
+		{ID: "match", Version: 3, Embedding: []float32{1, 0}},
+		{ID: "unrelated", Version: 1, Embedding: []float32{0, 1}},
+	}
+	mockML.On("GetEmbedding", ctx, "refunds").Return(embedding, nil)
+	mockStore.On("Search", ctx, embedding).Return([]*repository.Memory{{ID: "m1", TenantID: "test-tenant"}}, nil)
+	var recorded *models.RecallEvent
+	mockStore.On("RecordRecall", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		recorded = args.Get(1).(*models.RecallEvent)
+	}).Return(nil)
+
+	_, err := svc.Recall(ctx, "refunds")
+	require.NoError(t, err)
+	require.NotNil(t, recorded)
+	assert.Equal(t, []string{"match"}, recorded.GroundingRuleIDs)
+	assert.Equal(t, []int{3}, recorded.GroundingRuleVersions)
+}
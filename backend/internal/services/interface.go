@@ -1,9 +1,22 @@
 package services
 
-import "context"
+import (
+	"context"
+
+	"evolutionary-mcp/backend/internal/events"
+)
 
 // MLClient is an interface for communicating with the ML sidecar.
 type MLClient interface {
 	// GetEmbedding returns the embedding for a given text.
 	GetEmbedding(ctx context.Context, text string) ([]float32, error)
+	// GetEmbeddings returns the embeddings for several texts, in order.
+	GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// EventPublisher is told when a memory, grounding rule or workflow version
+// is created, changed or deleted, so that connected MCP clients can be
+// notified. *events.Bus implements it.
+type EventPublisher interface {
+	Publish(event events.Event)
 }
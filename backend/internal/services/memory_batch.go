@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/google/uuid"
+)
+
+// MaxBatchSize is the most items a batch may hold.
+const MaxBatchSize = 100
+
+// BatchResult is the outcome of one item of a batch, in input order. Exactly
+// one of Memory and Err is set.
+type BatchResult struct {
+	Memory *repository.Memory
+	Err    error
+}
+
+// FeedbackItem is one rating in a feedback batch.
+type FeedbackItem struct {
+	ID         string
+	Confidence float64
+}
+
+// RememberBatch creates a memory for every non-empty content. All contents are
+// embedded in one call to the ML sidecar and saved in one transaction. Invalid
+// items fail on their own; an error is returned only when the whole batch
+// failed. source is recorded in the provenance of every memory, e.g. SourceREST.
+func (s *MemoryService) RememberBatch(ctx context.Context, contents []string, source string) ([]BatchResult, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	if err := checkBatchSize(len(contents)); err != nil {
+		return nil, err
+	}
+
+	results := make([]BatchResult, len(contents))
+	var texts []string
+	var indexes []int
+	for i, content := range contents {
+		if content == "" {
+			results[i].Err = fmt.Errorf("%w: content is required", ErrInvalidInput)
+			continue
+		}
+		texts = append(texts, content)
+		indexes = append(indexes, i)
+	}
+	if len(texts) == 0 {
+		return results, nil
+	}
+
+	if err := s.quotas.CheckNewMemories(ctx, tenantID, len(texts)); err != nil {
+		return nil, err
+	}
+	if err := s.quotas.AllowEmbeddings(ctx, tenantID, len(texts)); err != nil {
+		return nil, err
+	}
+	embeddings, err := s.mlClient.GetEmbeddings(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate embeddings: %w", err)
+	}
+
+	memories := make([]*repository.Memory, len(texts))
+	for j, text := range texts {
+		memories[j] = &repository.Memory{
+			ID:         uuid.New().String(),
+			TenantID:   tenantID,
+			Content:    text,
+			Embedding:  embeddings[j],
+			Confidence: 1.0,
+			Version:    1,
+			Provenance: map[string]interface{}{
+				"source": source,
+			},
+		}
+	}
+	if err := s.store.SaveBatch(ctx, memories); err != nil {
+		return nil, err
+	}
+
+	for j, memory := range memories {
+		results[indexes[j]].Memory = memory
+		s.publish(events.Created, models.MemoryURI(memory.ID), tenantID)
+	}
+	return results, nil
+}
+
+// GiveFeedbackBatch sets the confidence of several memories of the current
+// tenant in one transaction. Unknown memories, memories of other tenants,
+// repeated IDs and confidences outside [0, 1] fail on their own; an error is
+// returned only when the whole batch failed. A batch that loses a race with
+// other feedback is applied again, as ApplyFeedback does.
+func (s *MemoryService) GiveFeedbackBatch(ctx context.Context, items []FeedbackItem) ([]BatchResult, error) {
+	for attempt := 1; ; attempt++ {
+		results, err := s.giveFeedbackBatch(ctx, items)
+		if !errors.Is(err, repository.ErrConflict) || attempt == maxFeedbackAttempts {
+			return results, err
+		}
+	}
+}
+
+func (s *MemoryService) giveFeedbackBatch(ctx context.Context, items []FeedbackItem) ([]BatchResult, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	if err := checkBatchSize(len(items)); err != nil {
+		return nil, err
+	}
+
+	// IDs that are not UUIDs cannot match a memory, and would fail the query.
+	ids := make([]string, 0, len(items))
+	queried := make(map[string]bool, len(items))
+	for _, item := range items {
+		if _, err := uuid.Parse(item.ID); err == nil && !queried[item.ID] {
+			ids = append(ids, item.ID)
+			queried[item.ID] = true
+		}
+	}
+	stored, err := s.store.GetBatch(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*repository.Memory, len(stored))
+	for _, memory := range stored {
+		byID[memory.ID] = memory
+	}
+
+	results := make([]BatchResult, len(items))
+	seen := make(map[string]bool, len(items))
+	var updates []*repository.Memory
+	var feedback []*models.FeedbackEvent
+	for i, item := range items {
+		memory, ok := byID[item.ID]
+		switch {
+		case item.Confidence < 0 || item.Confidence > 1:
+			results[i].Err = fmt.Errorf("%w: confidence must be between 0 and 1", ErrInvalidInput)
+		case seen[item.ID]:
+			results[i].Err = fmt.Errorf("%w: memory %s appears more than once", ErrInvalidInput, item.ID)
+		case !ok:
+			results[i].Err = fmt.Errorf("%w: memory %s", repository.ErrNotFound, item.ID)
+		case memory.TenantID != tenantID:
+			results[i].Err = fmt.Errorf("%w: memory belongs to another tenant", ErrUnauthorized)
+		default:
+			memory.Confidence = item.Confidence
+			memory.Version++
+			results[i].Memory = memory
+			updates = append(updates, memory)
+			feedback = append(feedback, &models.FeedbackEvent{
+				TenantID:   tenantID,
+				MemoryID:   memory.ID,
+				Confidence: item.Confidence,
+			})
+		}
+		seen[item.ID] = true
+	}
+	if len(updates) == 0 {
+		return results, nil
+	}
+
+	if err := s.store.UpdateBatch(ctx, updates); err != nil {
+		return nil, err
+	}
+	for _, memory := range updates {
+		s.publish(events.Updated, models.MemoryURI(memory.ID), tenantID)
+	}
+
+	// Analytics are best effort and never fail the feedback itself.
+	_ = s.store.RecordFeedbackBatch(ctx, feedback)
+	return results, nil
+}
+
+func checkBatchSize(n int) error {
+	if n == 0 {
+		return fmt.Errorf("%w: batch is empty", ErrInvalidInput)
+	}
+	if n > MaxBatchSize {
+		return fmt.Errorf("%w: batch holds %d items, at most %d are allowed", ErrInvalidInput, n, MaxBatchSize)
+	}
+	return nil
+}
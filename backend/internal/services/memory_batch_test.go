@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/quota"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryService_RememberBatch(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	mockML := new(MockMLClient)
+	publisher := &recordingPublisher{}
+	svc := NewMemoryService(mockStore, mockML).WithEvents(publisher)
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+
+	mockML.On("GetEmbeddings", ctx, []string{"first", "second"}).Return([][]float32{{1}, {2}}, nil).Once()
+	mockStore.On("SaveBatch", ctx, mock.MatchedBy(func(memories []*repository.Memory) bool {
+		return len(memories) == 2 && memories[0].Content == "first" && memories[1].Embedding[0] == 2 &&
+			memories[1].TenantID == "test-tenant" && memories[0].Provenance["source"] == SourceREST
+	})).Return(nil).Once()
+
+	results, err := svc.RememberBatch(ctx, []string{"first", "", "second"}, SourceREST)
+
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	assert.Equal(t, "first", results[0].Memory.Content)
+	assert.ErrorIs(t, results[1].Err, ErrInvalidInput)
+	assert.Nil(t, results[1].Memory)
+	assert.Equal(t, "second", results[2].Memory.Content)
+	assert.Len(t, publisher.events, 2)
+	mockML.AssertExpectations(t)
+	mockStore.AssertExpectations(t)
+
+	_, err = svc.RememberBatch(ctx, nil, SourceREST)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	_, err = svc.RememberBatch(ctx, make([]string, MaxBatchSize+1), SourceREST)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestMemoryService_RememberBatch_QuotaCoversWholeBatch(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	mockML := new(MockMLClient)
+	quotas := quota.NewEnforcer(quota.Limits{MaxMemories: 3}, mockStore, &NoOpLogger{})
+	svc := NewMemoryService(mockStore, mockML).WithQuotas(quotas)
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+
+	mockStore.On("GetTenantByID", ctx, "test-tenant").Return(nil, repository.ErrNotFound)
+	mockStore.On("CountMemories", ctx, "test-tenant").Return(2, nil)
+
+	_, err := svc.RememberBatch(ctx, []string{"one", "two"}, SourceMCP)
+
+	assert.ErrorIs(t, err, quota.ErrExceeded)
+	mockML.AssertNotCalled(t, "GetEmbeddings", mock.Anything, mock.Anything)
+	mockStore.AssertNotCalled(t, "SaveBatch", mock.Anything, mock.Anything)
+}
+
+func TestMemoryService_GiveFeedbackBatch(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewMemoryService(mockStore, new(MockMLClient))
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+
+	ours, theirs, missing := uuid.New().String(), uuid.New().String(), uuid.New().String()
+	mockStore.On("GetBatch", ctx, []string{ours, theirs, missing}).Return([]*repository.Memory{
+		{ID: ours, TenantID: "test-tenant", Confidence: 0.5, Version: 1},
+		{ID: theirs, TenantID: "other-tenant", Confidence: 0.5, Version: 1},
+	}, nil)
+	mockStore.On("UpdateBatch", ctx, mock.MatchedBy(func(memories []*repository.Memory) bool {
+		return len(memories) == 1 && memories[0].ID == ours && memories[0].Confidence == 0.9 && memories[0].Version == 2
+	})).Return(nil).Once()
+	mockStore.On("RecordFeedbackBatch", ctx, mock.MatchedBy(func(events []*models.FeedbackEvent) bool {
+		return len(events) == 1 && events[0].MemoryID == ours
+	})).Return(nil).Once()
+
+	results, err := svc.GiveFeedbackBatch(ctx, []FeedbackItem{
+		{ID: ours, Confidence: 0.9},
+		{ID: theirs, Confidence: 0.1},
+		{ID: missing, Confidence: 0.1},
+		{ID: "not-a-uuid", Confidence: 0.1},
+		{ID: ours, Confidence: 0.2},
+		{ID: ours, Confidence: 1.5},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 6)
+	assert.Equal(t, 2, results[0].Memory.Version)
+	assert.ErrorIs(t, results[1].Err, ErrUnauthorized)
+	assert.ErrorIs(t, results[2].Err, repository.ErrNotFound)
+	assert.ErrorIs(t, results[3].Err, repository.ErrNotFound)
+	assert.ErrorIs(t, results[4].Err, ErrInvalidInput)
+	assert.ErrorIs(t, results[5].Err, ErrInvalidInput)
+	mockStore.AssertExpectations(t)
+}
@@ -2,10 +2,15 @@ package services
 
 import (
 	"context"
+	"errors"
 	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/quota"
 	"evolutionary-mcp/backend/internal/repository"
 	"evolutionary-mcp/backend/pkg/models"
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -14,6 +19,8 @@ import (
 type MemoryService struct {
 	store    repository.Repository
 	mlClient MLClient
+	quotas   *quota.Enforcer
+	events   EventPublisher
 }
 
 // NewMemoryService creates a new MemoryService.
@@ -24,11 +31,43 @@ func NewMemoryService(store repository.Repository, mlClient MLClient) *MemorySer
 	}
 }
 
+// WithQuotas makes the service enforce per-tenant memory and embedding quotas.
+func (s *MemoryService) WithQuotas(quotas *quota.Enforcer) *MemoryService {
+	s.quotas = quotas
+	return s
+}
+
+// WithEvents makes the service publish changes to memories and grounding
+// rules to publisher.
+func (s *MemoryService) WithEvents(publisher EventPublisher) *MemoryService {
+	s.events = publisher
+	return s
+}
+
+func (s *MemoryService) publish(kind events.Kind, uri, tenantID string) {
+	if s.events != nil {
+		s.events.Publish(events.Event{Kind: kind, URI: uri, TenantID: tenantID})
+	}
+}
+
+// Provenance sources recorded on the memories created through each interface.
+const (
+	SourceMCP  = "mcp-tool"
+	SourceREST = "rest-api"
+)
+
 // Remember creates a new memory with semantic embedding and tenant isolation.
 func (s *MemoryService) Remember(ctx context.Context, content string) (*repository.Memory, error) {
 	tenantID := contextutil.GetTenant(ctx)
 	if tenantID == "" {
-		return nil, fmt.Errorf("unauthorized: tenant_id missing from context")
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+
+	if err := s.quotas.CheckMemories(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	if err := s.quotas.AllowEmbedding(ctx, tenantID); err != nil {
+		return nil, err
 	}
 
 	embedding, err := s.mlClient.GetEmbedding(ctx, content)
@@ -44,38 +83,77 @@ func (s *MemoryService) Remember(ctx context.Context, content string) (*reposito
 		Confidence: 1.0,
 		Version:    1,
 		Provenance: map[string]interface{}{
-			"source": "mcp-tool",
+			"source": SourceMCP,
 		},
 	}
 
 	if err := s.store.Save(ctx, memory); err != nil {
 		return nil, err
 	}
+	s.publish(events.Created, models.MemoryURI(memory.ID), tenantID)
 
 	return memory, nil
 }
 
+// groundingMatchThreshold is the minimum cosine similarity between a recall
+// query and a grounding rule for the rule to count as a hit in usage analytics.
+const groundingMatchThreshold = 0.5
+
 // Recall retrieves memories similar to the query within the tenant's scope.
 func (s *MemoryService) Recall(ctx context.Context, query string) ([]*repository.Memory, error) {
 	tenantID := contextutil.GetTenant(ctx)
 	if tenantID == "" {
-		return nil, fmt.Errorf("unauthorized: tenant_id missing from context")
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+
+	if err := s.quotas.AllowEmbedding(ctx, tenantID); err != nil {
+		return nil, err
 	}
 
+	start := time.Now()
 	embedding, err := s.mlClient.GetEmbedding(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate embedding: %w", err)
 	}
 
 	// Repository Search already extracts tenantID from context using GetTenant()
-	return s.store.Search(ctx, embedding)
+	memories, err := s.store.Search(ctx, embedding)
+	if err != nil {
+		return nil, err
+	}
+
+	s.recordRecall(ctx, tenantID, embedding, memories, time.Since(start))
+	return memories, nil
+}
+
+// recordRecall stores a recall event for usage analytics. Analytics are best
+// effort and never fail the recall itself.
+func (s *MemoryService) recordRecall(ctx context.Context, tenantID string, embedding []float32, memories []*repository.Memory, latency time.Duration) {
+	event := &models.RecallEvent{
+		TenantID:  tenantID,
+		LatencyMs: float64(latency.Microseconds()) / 1000,
+		MemoryIDs: make([]string, 0, len(memories)),
+	}
+	for _, m := range memories {
+		event.MemoryIDs = append(event.MemoryIDs, m.ID)
+	}
+
+	rules, _ := s.store.SearchGroundingRules(ctx, tenantID, embedding)
+	for _, r := range rules {
+		if cosineSimilarity(embedding, r.Embedding) >= groundingMatchThreshold {
+			event.GroundingRuleIDs = append(event.GroundingRuleIDs, r.ID)
+			event.GroundingRuleVersions = append(event.GroundingRuleVersions, r.Version)
+		}
+	}
+
+	_ = s.store.RecordRecall(ctx, event)
 }
 
 // ListMemories returns all memories for the tenant.
 func (s *MemoryService) ListMemories(ctx context.Context) ([]*repository.Memory, error) {
 	tenantID := contextutil.GetTenant(ctx)
 	if tenantID == "" {
-		return nil, fmt.Errorf("unauthorized: tenant_id missing from context")
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
 	}
 
 	return s.store.ListMemories(ctx, tenantID)
@@ -83,28 +161,267 @@ func (s *MemoryService) ListMemories(ctx context.Context) ([]*repository.Memory,
 
 // GiveFeedback updates the confidence of a memory based on user/AI feedback.
 func (s *MemoryService) GiveFeedback(ctx context.Context, id string, confidence float64) error {
+	_, err := s.ApplyFeedback(ctx, id, Feedback{Confidence: &confidence})
+	return err
+}
+
+// Feedback is an opinion on a memory: either an absolute confidence or a
+// relative signal, optionally with why it was given and the query that
+// surfaced the memory. A non-zero Version is the version of the memory the
+// opinion was formed on.
+type Feedback struct {
+	Confidence *float64
+	Signal     models.FeedbackSignal
+	Reason     string
+	Query      string
+	Version    int
+}
+
+// maxFeedbackTextLength bounds the reason and query stored with feedback.
+const maxFeedbackTextLength = 4000
+
+// maxFeedbackAttempts is how often feedback is applied before giving up when
+// concurrent feedback keeps changing the memory first.
+const maxFeedbackAttempts = 3
+
+// signalAdjustments is how far each relative signal moves the confidence of a
+// memory. Wrong memories drop faster than unhelpful ones.
+var signalAdjustments = map[models.FeedbackSignal]float64{
+	models.FeedbackSignalHelpful:    0.1,
+	models.FeedbackSignalNotHelpful: -0.1,
+	models.FeedbackSignalOutdated:   -0.3,
+	models.FeedbackSignalIncorrect:  -0.5,
+}
+
+func (f Feedback) validate() error {
+	switch {
+	case f.Confidence == nil && f.Signal == "":
+		return fmt.Errorf("%w: either a confidence or a signal is required", ErrInvalidInput)
+	case f.Confidence != nil && f.Signal != "":
+		return fmt.Errorf("%w: give either a confidence or a signal, not both", ErrInvalidInput)
+	case f.Confidence != nil && (*f.Confidence < 0 || *f.Confidence > 1):
+		return fmt.Errorf("%w: confidence must be between 0 and 1", ErrInvalidInput)
+	case len(f.Reason) > maxFeedbackTextLength || len(f.Query) > maxFeedbackTextLength:
+		return fmt.Errorf("%w: reason and query are limited to %d bytes", ErrInvalidInput, maxFeedbackTextLength)
+	}
+	if _, ok := signalAdjustments[f.Signal]; f.Signal != "" && !ok {
+		return fmt.Errorf("%w: unknown signal %q", ErrInvalidInput, f.Signal)
+	}
+	return nil
+}
+
+// ApplyFeedback evolves a memory of the current tenant and records the
+// feedback, with its reason and query, for curators to review. A signal moves
+// the confidence relative to its current value, within [0, 1]. Feedback that
+// loses a race with other feedback is applied again to the memory as it is
+// now, unless it names the Version it was formed on: then it fails with
+// ErrPreconditionFailed if the memory has moved on before, and with
+// repository.ErrConflict if it moves on while applying it.
+func (s *MemoryService) ApplyFeedback(ctx context.Context, id string, feedback Feedback) (*repository.Memory, error) {
+	if err := feedback.validate(); err != nil {
+		return nil, err
+	}
+
+	tenantID := contextutil.GetTenant(ctx)
+	var memory *repository.Memory
+	var err error
+	for attempt := 1; ; attempt++ {
+		memory, err = s.applyFeedback(ctx, id, feedback)
+		if !errors.Is(err, repository.ErrConflict) || feedback.Version != 0 || attempt == maxFeedbackAttempts {
+			break
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.publish(events.Updated, models.MemoryURI(memory.ID), tenantID)
+
+	// Analytics are best effort and never fail the feedback itself.
+	_ = s.store.RecordFeedback(ctx, &models.FeedbackEvent{
+		TenantID:   tenantID,
+		MemoryID:   memory.ID,
+		Confidence: memory.Confidence,
+		Signal:     feedback.Signal,
+		Reason:     feedback.Reason,
+		Query:      feedback.Query,
+	})
+	return memory, nil
+}
+
+func (s *MemoryService) applyFeedback(ctx context.Context, id string, feedback Feedback) (*repository.Memory, error) {
 	memory, err := s.store.Get(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if memory.TenantID != contextutil.GetTenant(ctx) {
+		return nil, fmt.Errorf("%w: memory belongs to another tenant", ErrUnauthorized)
+	}
+	if feedback.Version != 0 && feedback.Version != memory.Version {
+		return nil, fmt.Errorf("%w: memory %s is at version %d, not %d", ErrPreconditionFailed, id, memory.Version, feedback.Version)
+	}
+
+	if feedback.Confidence != nil {
+		memory.Confidence = *feedback.Confidence
+	} else {
+		memory.Confidence = math.Min(1, math.Max(0, memory.Confidence+signalAdjustments[feedback.Signal]))
 	}
+	memory.Version++
+
+	if err := s.store.Update(ctx, memory); err != nil {
+		return nil, err
+	}
+	return memory, nil
+}
 
+// ListFeedback returns the feedback given on a memory of the current tenant,
+// newest first.
+func (s *MemoryService) ListFeedback(ctx context.Context, id string) ([]*models.FeedbackEvent, error) {
+	memory, err := s.GetMemory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	feedback, err := s.store.ListFeedbackEvents(ctx, memory.TenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if feedback == nil {
+		feedback = make([]*models.FeedbackEvent, 0)
+	}
+	return feedback, nil
+}
+
+// GetMemory returns a memory of the current tenant.
+func (s *MemoryService) GetMemory(ctx context.Context, id string) (*repository.Memory, error) {
 	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+
+	memory, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 	if memory.TenantID != tenantID {
-		return fmt.Errorf("unauthorized: memory belongs to another tenant")
+		return nil, fmt.Errorf("%w: memory belongs to another tenant", ErrUnauthorized)
 	}
+	return memory, nil
+}
 
-	memory.Confidence = confidence
-	memory.Version++
+// GetGroundingRule returns a grounding rule of the current tenant or a global one.
+func (s *MemoryService) GetGroundingRule(ctx context.Context, id string) (*models.GroundingRule, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+
+	rule, err := s.store.GetGroundingRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rule.TenantID != tenantID && !rule.IsGlobal {
+		return nil, fmt.Errorf("%w: grounding rule belongs to another tenant", ErrUnauthorized)
+	}
+	return rule, nil
+}
+
+// SearchGroundingRules returns the tenant's and global grounding rules most
+// similar to the query.
+func (s *MemoryService) SearchGroundingRules(ctx context.Context, query string) ([]*models.GroundingRule, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+
+	if err := s.quotas.AllowEmbedding(ctx, tenantID); err != nil {
+		return nil, err
+	}
 
-	return s.store.Update(ctx, memory)
+	embedding, err := s.mlClient.GetEmbedding(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate embedding: %w", err)
+	}
+
+	return s.store.SearchGroundingRules(ctx, tenantID, embedding)
 }
 
-// GetGroundingRules returns the foundational rules for the current tenant.
+// GetGroundingRules returns the active foundational rules for the current
+// tenant. Proposals awaiting approval are left out.
 func (s *MemoryService) GetGroundingRules(ctx context.Context) ([]*models.GroundingRule, error) {
 	tenantID := contextutil.GetTenant(ctx)
 	if tenantID == "" {
-		return nil, fmt.Errorf("unauthorized: tenant_id missing from context")
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+
+	rules, err := s.store.ListGroundingRules(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	active := make([]*models.GroundingRule, 0, len(rules))
+	for _, r := range rules {
+		if r.Status == "" || r.Status == models.GroundingRuleStatusActive {
+			active = append(active, r)
+		}
+	}
+	return active, nil
+}
+
+// ProposeGroundingRule records a new rule, suggested by an agent or a
+// curator, with the caller as proposer. It stays pending, and is not used at
+// recall time, until a curator approves it. The rule is global if
+// rule.IsGlobal is set; callers only allow that for admins.
+func (s *MemoryService) ProposeGroundingRule(ctx context.Context, rule *models.GroundingRule) (*models.GroundingRule, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	if rule.Name == "" || rule.Content == "" {
+		return nil, fmt.Errorf("%w: name and content are required", ErrInvalidInput)
+	}
+	if rule.WorkflowID != nil {
+		if _, err := s.store.GetWorkflow(ctx, *rule.WorkflowID); err != nil {
+			return nil, fmt.Errorf("%w: unknown workflow %s", ErrInvalidInput, *rule.WorkflowID)
+		}
 	}
 
-	return s.store.ListGroundingRules(ctx, tenantID)
+	if err := s.quotas.AllowEmbedding(ctx, tenantID); err != nil {
+		return nil, err
+	}
+	embedding, err := s.mlClient.GetEmbedding(ctx, rule.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate embedding: %w", err)
+	}
+
+	proposal := &models.GroundingRule{
+		TenantID:   tenantID,
+		WorkflowID: rule.WorkflowID,
+		Name:       rule.Name,
+		Content:    rule.Content,
+		Embedding:  embedding,
+		IsGlobal:   rule.IsGlobal,
+		Status:     models.GroundingRuleStatusPending,
+		ProposedBy: contextutil.GetUser(ctx),
+	}
+	if err := s.store.CreateGroundingRule(ctx, proposal); err != nil {
+		return nil, err
+	}
+	s.publish(events.Created, models.GroundingRuleURI(proposal.ID), tenantID)
+	return proposal, nil
+}
+
+// cosineSimilarity returns the cosine of the angle between a and b, or 0 when
+// either is empty or their dimensions differ.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
 }
@@ -2,18 +2,24 @@ package services
 
 import (
 	"context"
+	"math"
 	"testing"
+	"time"
 
 	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/quota"
 	"evolutionary-mcp/backend/internal/repository"
 	"evolutionary-mcp/backend/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockMemoryStore satisfies repository.Repository
 type MockMemoryStore struct {
 	mock.Mock
+	groundingRules []*models.GroundingRule
 }
 
 func (m *MockMemoryStore) Save(ctx context.Context, memory *repository.Memory) error {
@@ -42,51 +48,302 @@ func (m *MockMemoryStore) Update(ctx context.Context, memory *repository.Memory)
 	return args.Error(0)
 }
 
+func (m *MockMemoryStore) GetBatch(ctx context.Context, ids []string) ([]*repository.Memory, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*repository.Memory), args.Error(1)
+}
+
+func (m *MockMemoryStore) SaveBatch(ctx context.Context, memories []*repository.Memory) error {
+	args := m.Called(ctx, memories)
+	return args.Error(0)
+}
+
+func (m *MockMemoryStore) UpdateBatch(ctx context.Context, memories []*repository.Memory) error {
+	args := m.Called(ctx, memories)
+	return args.Error(0)
+}
+
 func (m *MockMemoryStore) Ping(ctx context.Context) error { return nil }
 func (m *MockMemoryStore) CreateWorkflow(ctx context.Context, workflow *models.Workflow) error {
-	return nil
+	args := m.Called(ctx, workflow)
+	return args.Error(0)
 }
 func (m *MockMemoryStore) UpdateWorkflow(ctx context.Context, workflow *models.Workflow) error {
-	return nil
+	args := m.Called(ctx, workflow)
+	return args.Error(0)
 }
 func (m *MockMemoryStore) GetWorkflow(ctx context.Context, id string) (*models.Workflow, error) {
-	return nil, nil
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Workflow), args.Error(1)
+}
+func (m *MockMemoryStore) GetWorkflowVersion(ctx context.Context, tenantID, workflowID string, version int) (*models.Workflow, error) {
+	args := m.Called(ctx, tenantID, workflowID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Workflow), args.Error(1)
 }
 func (m *MockMemoryStore) ListWorkflows(ctx context.Context) ([]*models.Workflow, error) {
-	return nil, nil
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Workflow), args.Error(1)
+}
+func (m *MockMemoryStore) ListWorkflowVersions(ctx context.Context, tenantID, workflowID string) ([]*models.Workflow, error) {
+	args := m.Called(ctx, tenantID, workflowID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Workflow), args.Error(1)
+}
+func (m *MockMemoryStore) RollbackWorkflow(ctx context.Context, tenantID, workflowID string, version int, createdBy string) (*models.Workflow, error) {
+	args := m.Called(ctx, tenantID, workflowID, version, createdBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Workflow), args.Error(1)
+}
+func (m *MockMemoryStore) TransitionWorkflow(ctx context.Context, transition *models.WorkflowTransition) error {
+	args := m.Called(ctx, transition)
+	return args.Error(0)
+}
+func (m *MockMemoryStore) ImportWorkflows(ctx context.Context, workflows []*models.Workflow, rules []*models.GroundingRule, actor string) error {
+	return m.Called(ctx, workflows, rules, actor).Error(0)
+}
+func (m *MockMemoryStore) ListWorkflowTransitions(ctx context.Context, tenantID, workflowID string) ([]*models.WorkflowTransition, error) {
+	args := m.Called(ctx, tenantID, workflowID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WorkflowTransition), args.Error(1)
+}
+func (m *MockMemoryStore) CreateWorkflowRun(ctx context.Context, run *models.WorkflowRun) error {
+	return m.Called(ctx, run).Error(0)
+}
+func (m *MockMemoryStore) GetWorkflowRun(ctx context.Context, id string) (*models.WorkflowRun, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.WorkflowRun), args.Error(1)
+}
+func (m *MockMemoryStore) ListWorkflowRuns(ctx context.Context, tenantID, workflowID string) ([]*models.WorkflowRun, error) {
+	args := m.Called(ctx, tenantID, workflowID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.WorkflowRun), args.Error(1)
+}
+func (m *MockMemoryStore) UpdateWorkflowRun(ctx context.Context, run *models.WorkflowRun) error {
+	return m.Called(ctx, run).Error(0)
+}
+func (m *MockMemoryStore) GetWorkflowSubtree(ctx context.Context, tenantID, id string) ([]*models.Workflow, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Workflow), args.Error(1)
+}
+func (m *MockMemoryStore) MoveWorkflowElements(ctx context.Context, tenantID string, moves []models.WorkflowMove) error {
+	args := m.Called(ctx, tenantID, moves)
+	return args.Error(0)
+}
+func (m *MockMemoryStore) CloneWorkflowSubtree(ctx context.Context, tenantID, id string, createdBy string) ([]*models.Workflow, error) {
+	args := m.Called(ctx, tenantID, id, createdBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Workflow), args.Error(1)
 }
 func (m *MockMemoryStore) GetTenantByDomain(ctx context.Context, domain string) (*models.Tenant, error) {
-	return nil, nil
+	args := m.Called(ctx, domain)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tenant), args.Error(1)
 }
 func (m *MockMemoryStore) GetTenantByID(ctx context.Context, id string) (*models.Tenant, error) {
-	return nil, nil
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tenant), args.Error(1)
 }
 func (m *MockMemoryStore) CreateTenant(ctx context.Context, tenant *models.Tenant) error {
+	args := m.Called(ctx, tenant)
+	return args.Error(0)
+}
+func (m *MockMemoryStore) ListTenants(ctx context.Context) ([]*models.Tenant, error) {
+	return nil, nil
+}
+func (m *MockMemoryStore) UpdateTenant(ctx context.Context, tenant *models.Tenant) error {
+	args := m.Called(ctx, tenant)
+	return args.Error(0)
+}
+func (m *MockMemoryStore) DeleteTenant(ctx context.Context, id string) error {
 	return nil
 }
+func (m *MockMemoryStore) AddTenantDomain(ctx context.Context, tenantID, domain string) error {
+	args := m.Called(ctx, tenantID, domain)
+	return args.Error(0)
+}
+func (m *MockMemoryStore) RemoveTenantDomain(ctx context.Context, tenantID, domain string) error {
+	args := m.Called(ctx, tenantID, domain)
+	return args.Error(0)
+}
+func (m *MockMemoryStore) ListTenantsForMember(ctx context.Context, email string) ([]*models.Tenant, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Tenant), args.Error(1)
+}
+func (m *MockMemoryStore) ListTenantMembers(ctx context.Context, tenantID string) ([]*models.TenantMember, error) {
+	return nil, nil
+}
+func (m *MockMemoryStore) AddTenantMember(ctx context.Context, member *models.TenantMember) error {
+	args := m.Called(ctx, member)
+	return args.Error(0)
+}
+func (m *MockMemoryStore) RemoveTenantMember(ctx context.Context, tenantID, email string) error {
+	args := m.Called(ctx, tenantID, email)
+	return args.Error(0)
+}
+func (m *MockMemoryStore) CreateAPIKey(ctx context.Context, key *models.APIKey, hash string) error {
+	args := m.Called(ctx, key, hash)
+	return args.Error(0)
+}
+func (m *MockMemoryStore) GetAPIKeyByHash(ctx context.Context, hash string) (*models.APIKey, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+func (m *MockMemoryStore) ListAPIKeys(ctx context.Context, tenantID string) ([]*models.APIKey, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.APIKey), args.Error(1)
+}
+func (m *MockMemoryStore) RevokeAPIKey(ctx context.Context, tenantID, id string) error {
+	args := m.Called(ctx, tenantID, id)
+	return args.Error(0)
+}
 func (m *MockMemoryStore) CreateGroundingRule(ctx context.Context, rule *models.GroundingRule) error {
 	return nil
 }
 func (m *MockMemoryStore) GetGroundingRule(ctx context.Context, id string) (*models.GroundingRule, error) {
-	return nil, nil
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GroundingRule), args.Error(1)
 }
 func (m *MockMemoryStore) ListGroundingRules(ctx context.Context, tenantID string) ([]*models.GroundingRule, error) {
-	return nil, nil
-}
-func (m *MockMemoryStore) UpdateGroundingRule(ctx context.Context, rule *models.GroundingRule) error {
-	return nil
+	return m.groundingRules, nil
 }
-func (m *MockMemoryStore) DeleteGroundingRule(ctx context.Context, id string) error {
-	return nil
+func (m *MockMemoryStore) ArchiveGroundingRule(ctx context.Context, tenantID, id, actor string) error {
+	return m.Called(ctx, tenantID, id, actor).Error(0)
 }
 func (m *MockMemoryStore) SearchGroundingRules(ctx context.Context, tenantID string, embedding []float32) ([]*models.GroundingRule, error) {
-	return nil, nil
+	return m.groundingRules, nil
+}
+func (m *MockMemoryStore) CreateGroundingRuleVersion(ctx context.Context, version *models.GroundingRuleVersion) error {
+	return m.Called(ctx, version).Error(0)
+}
+func (m *MockMemoryStore) GetGroundingRuleVersion(ctx context.Context, ruleID string, version int) (*models.GroundingRuleVersion, error) {
+	args := m.Called(ctx, ruleID, version)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GroundingRuleVersion), args.Error(1)
+}
+func (m *MockMemoryStore) ListGroundingRuleVersions(ctx context.Context, ruleID string) ([]*models.GroundingRuleVersion, error) {
+	args := m.Called(ctx, ruleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.GroundingRuleVersion), args.Error(1)
+}
+func (m *MockMemoryStore) ApproveGroundingRuleVersion(ctx context.Context, ruleID string, version int, reviewer, comment string) (*models.GroundingRule, error) {
+	args := m.Called(ctx, ruleID, version, reviewer, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GroundingRule), args.Error(1)
+}
+func (m *MockMemoryStore) RejectGroundingRuleVersion(ctx context.Context, ruleID string, version int, reviewer, comment string) (*models.GroundingRuleVersion, error) {
+	args := m.Called(ctx, ruleID, version, reviewer, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.GroundingRuleVersion), args.Error(1)
 }
 
 func (m *MockMemoryStore) ListMemories(ctx context.Context, tenantID string) ([]*repository.Memory, error) {
 	return nil, nil
 }
 
+func (m *MockMemoryStore) CountMemories(ctx context.Context, tenantID string) (int, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockMemoryStore) RecordRecall(ctx context.Context, event *models.RecallEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+func (m *MockMemoryStore) RecordFeedback(ctx context.Context, event *models.FeedbackEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+func (m *MockMemoryStore) RecordFeedbackBatch(ctx context.Context, events []*models.FeedbackEvent) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+func (m *MockMemoryStore) ListFeedbackEvents(ctx context.Context, tenantID, memoryID string) ([]*models.FeedbackEvent, error) {
+	args := m.Called(ctx, tenantID, memoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.FeedbackEvent), args.Error(1)
+}
+func (m *MockMemoryStore) ListMemoryRecalls(ctx context.Context, tenantID, memoryID string, limit int) ([]*models.MemoryRecall, error) {
+	args := m.Called(ctx, tenantID, memoryID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.MemoryRecall), args.Error(1)
+}
+func (m *MockMemoryStore) GetTenantStats(ctx context.Context, tenantID string, since time.Time) (*models.TenantStats, error) {
+	args := m.Called(ctx, tenantID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TenantStats), args.Error(1)
+}
+
+func (m *MockMemoryStore) GetWorkflowVersionPerformance(ctx context.Context, tenantID, workflowID string, since time.Time) ([]models.VersionPerformance, error) {
+	args := m.Called(ctx, tenantID, workflowID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.VersionPerformance), args.Error(1)
+}
+
+// NoOpLogger for testing
+type NoOpLogger struct{}
+
+func (l *NoOpLogger) Error(msg string, args ...any) {}
+
 // MockMLClient satisfies MLClient interface
 type MockMLClient struct {
 	mock.Mock
@@ -100,10 +357,19 @@ func (m *MockMLClient) GetEmbedding(ctx context.Context, text string) ([]float32
 	return args.Get(0).([]float32), args.Error(1)
 }
 
+func (m *MockMLClient) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	args := m.Called(ctx, texts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([][]float32), args.Error(1)
+}
+
 func TestMemoryService_Remember(t *testing.T) {
 	mockStore := new(MockMemoryStore)
 	mockML := new(MockMLClient)
-	svc := NewMemoryService(mockStore, mockML)
+	publisher := &recordingPublisher{}
+	svc := NewMemoryService(mockStore, mockML).WithEvents(publisher)
 
 	tenantID := "test-tenant"
 	ctx := contextutil.WithTenant(context.Background(), tenantID)
@@ -123,10 +389,142 @@ func TestMemoryService_Remember(t *testing.T) {
 	assert.NotNil(t, memory)
 	assert.Equal(t, content, memory.Content)
 	assert.Equal(t, tenantID, memory.TenantID)
+	assert.Equal(t, []events.Event{{Kind: events.Created, URI: "memory://" + memory.ID, TenantID: tenantID}}, publisher.events)
 	mockML.AssertExpectations(t)
 	mockStore.AssertExpectations(t)
 }
 
+func TestMemoryService_Remember_MemoryQuotaExceeded(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	mockML := new(MockMLClient)
+	quotas := quota.NewEnforcer(quota.Limits{MaxMemories: 3}, mockStore, &NoOpLogger{})
+	svc := NewMemoryService(mockStore, mockML).WithQuotas(quotas)
+
+	tenantID := "test-tenant"
+	ctx := contextutil.WithTenant(context.Background(), tenantID)
+
+	mockStore.On("GetTenantByID", ctx, tenantID).Return(nil, repository.ErrNotFound)
+	mockStore.On("CountMemories", ctx, tenantID).Return(3, nil)
+
+	_, err := svc.Remember(ctx, "one too many")
+
+	assert.ErrorIs(t, err, quota.ErrExceeded)
+	mockML.AssertNotCalled(t, "GetEmbedding", mock.Anything, mock.Anything)
+	mockStore.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestMemoryService_ApplyFeedback_Signals(t *testing.T) {
+	tenantID := "test-tenant"
+	ctx := contextutil.WithTenant(context.Background(), tenantID)
+
+	cases := []struct {
+		signal models.FeedbackSignal
+		from   float64
+		want   float64
+	}{
+		{models.FeedbackSignalHelpful, 0.5, 0.6},
+		{models.FeedbackSignalHelpful, 0.95, 1},
+		{models.FeedbackSignalNotHelpful, 0.5, 0.4},
+		{models.FeedbackSignalOutdated, 0.5, 0.2},
+		{models.FeedbackSignalIncorrect, 0.3, 0},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.signal), func(t *testing.T) {
+			mockStore := new(MockMemoryStore)
+			svc := NewMemoryService(mockStore, new(MockMLClient))
+			mockStore.On("Get", ctx, "m1").Return(&repository.Memory{ID: "m1", TenantID: tenantID, Confidence: tc.from, Version: 1}, nil)
+			mockStore.On("Update", ctx, mock.Anything).Return(nil)
+			mockStore.On("RecordFeedback", ctx, mock.MatchedBy(func(e *models.FeedbackEvent) bool {
+				return e.Signal == tc.signal && e.Reason == "because" && e.Query == "what" && math.Abs(e.Confidence-tc.want) < 1e-9
+			})).Return(nil)
+
+			memory, err := svc.ApplyFeedback(ctx, "m1", Feedback{Signal: tc.signal, Reason: "because", Query: "what"})
+
+			require.NoError(t, err)
+			assert.InDelta(t, tc.want, memory.Confidence, 1e-9)
+			assert.Equal(t, 2, memory.Version)
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMemoryService_ApplyFeedback_Concurrency(t *testing.T) {
+	tenantID := "test-tenant"
+	ctx := contextutil.WithTenant(context.Background(), tenantID)
+	helpful := Feedback{Signal: models.FeedbackSignalHelpful}
+
+	t.Run("reapplies feedback that lost a race", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewMemoryService(mockStore, new(MockMLClient))
+		mockStore.On("Get", ctx, "m1").Return(&repository.Memory{ID: "m1", TenantID: tenantID, Confidence: 0.5, Version: 1}, nil).Once()
+		mockStore.On("Get", ctx, "m1").Return(&repository.Memory{ID: "m1", TenantID: tenantID, Confidence: 0.6, Version: 2}, nil).Once()
+		mockStore.On("Update", ctx, mock.MatchedBy(func(m *repository.Memory) bool { return m.Version == 2 })).Return(repository.ErrConflict).Once()
+		mockStore.On("Update", ctx, mock.MatchedBy(func(m *repository.Memory) bool { return m.Version == 3 })).Return(nil).Once()
+		mockStore.On("RecordFeedback", ctx, mock.Anything).Return(nil)
+
+		memory, err := svc.ApplyFeedback(ctx, "m1", helpful)
+
+		require.NoError(t, err)
+		assert.InDelta(t, 0.7, memory.Confidence, 1e-9)
+		assert.Equal(t, 3, memory.Version)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("gives up after repeated conflicts", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewMemoryService(mockStore, new(MockMLClient))
+		mockStore.On("Get", ctx, "m1").Return(&repository.Memory{ID: "m1", TenantID: tenantID, Confidence: 0.5, Version: 1}, nil)
+		mockStore.On("Update", ctx, mock.Anything).Return(repository.ErrConflict)
+
+		_, err := svc.ApplyFeedback(ctx, "m1", helpful)
+
+		assert.ErrorIs(t, err, repository.ErrConflict)
+		mockStore.AssertNumberOfCalls(t, "Update", maxFeedbackAttempts)
+		mockStore.AssertNotCalled(t, "RecordFeedback", mock.Anything, mock.Anything)
+	})
+
+	t.Run("feedback on an older version fails its precondition", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewMemoryService(mockStore, new(MockMLClient))
+		mockStore.On("Get", ctx, "m1").Return(&repository.Memory{ID: "m1", TenantID: tenantID, Confidence: 0.5, Version: 3}, nil)
+
+		_, err := svc.ApplyFeedback(ctx, "m1", Feedback{Signal: models.FeedbackSignalHelpful, Version: 2})
+
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+		mockStore.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("conditional feedback is not reapplied", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewMemoryService(mockStore, new(MockMLClient))
+		mockStore.On("Get", ctx, "m1").Return(&repository.Memory{ID: "m1", TenantID: tenantID, Confidence: 0.5, Version: 3}, nil)
+		mockStore.On("Update", ctx, mock.Anything).Return(repository.ErrConflict)
+
+		_, err := svc.ApplyFeedback(ctx, "m1", Feedback{Signal: models.FeedbackSignalHelpful, Version: 3})
+
+		assert.ErrorIs(t, err, repository.ErrConflict)
+		mockStore.AssertNumberOfCalls(t, "Update", 1)
+	})
+}
+
+func TestMemoryService_ApplyFeedback_Validation(t *testing.T) {
+	svc := NewMemoryService(new(MockMemoryStore), new(MockMLClient))
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+	half, tooHigh := 0.5, 1.5
+
+	for name, feedback := range map[string]Feedback{
+		"neither":        {},
+		"both":           {Confidence: &half, Signal: models.FeedbackSignalHelpful},
+		"out of range":   {Confidence: &tooHigh},
+		"unknown signal": {Signal: "meh"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := svc.ApplyFeedback(ctx, "m1", feedback)
+			assert.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+}
+
 func TestMemoryService_Recall(t *testing.T) {
 	mockStore := new(MockMemoryStore)
 	mockML := new(MockMLClient)
@@ -142,6 +540,9 @@ func TestMemoryService_Recall(t *testing.T) {
 
 	mockML.On("GetEmbedding", ctx, query).Return(fakeEmbedding, nil)
 	mockStore.On("Search", ctx, fakeEmbedding).Return(expectedResults, nil)
+	mockStore.On("RecordRecall", ctx, mock.MatchedBy(func(e *models.RecallEvent) bool {
+		return e.TenantID == tenantID && len(e.MemoryIDs) == 1 && e.MemoryIDs[0] == "1"
+	})).Return(nil)
 
 	results, err := svc.Recall(ctx, query)
 
@@ -20,31 +20,51 @@ func NewHTTPMLClient(url string) *HTTPMLClient {
 
 // GetEmbedding returns the embedding for a given text.
 func (c *HTTPMLClient) GetEmbedding(ctx context.Context, text string) ([]float32, error) {
-	requestBody, err := json.Marshal(map[string]string{"text": text})
+	var embedding []float32
+	if err := c.post(ctx, "/embedding", map[string]string{"text": text}, &embedding); err != nil {
+		return nil, fmt.Errorf("failed to get embedding: %w", err)
+	}
+	return embedding, nil
+}
+
+// GetEmbeddings returns the embeddings for several texts, in order, from one
+// call to the sidecar.
+func (c *HTTPMLClient) GetEmbeddings(ctx context.Context, texts []string) ([][]float32, error) {
+	var embeddings [][]float32
+	if err := c.post(ctx, "/embeddings", map[string][]string{"texts": texts}, &embeddings); err != nil {
+		return nil, fmt.Errorf("failed to get embeddings: %w", err)
+	}
+	if len(embeddings) != len(texts) {
+		return nil, fmt.Errorf("failed to get embeddings: got %d for %d texts", len(embeddings), len(texts))
+	}
+	return embeddings, nil
+}
+
+// post sends body as JSON to path and decodes the JSON response into out.
+func (c *HTTPMLClient) post(ctx context.Context, path string, body, out any) error {
+	requestBody, err := json.Marshal(body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		return fmt.Errorf("failed to marshal request body: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.url+"/embedding", bytes.NewBuffer(requestBody))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url+path, bytes.NewBuffer(requestBody))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get embedding: status code %d", resp.StatusCode)
+		return fmt.Errorf("status code %d", resp.StatusCode)
 	}
 
-	var embedding []float32
-	if err := json.NewDecoder(resp.Body).Decode(&embedding); err != nil {
-		return nil, fmt.Errorf("failed to decode response body: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response body: %w", err)
 	}
-
-	return embedding, nil
+	return nil
 }
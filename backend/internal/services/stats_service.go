@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultStatsWindowDays is the window used when the caller does not pick one.
+	DefaultStatsWindowDays = 30
+	// MaxStatsWindowDays bounds how far back statistics are computed.
+	MaxStatsWindowDays = 365
+
+	// statsCacheTTL is how long computed statistics are served from memory.
+	statsCacheTTL = time.Minute
+)
+
+type cachedStats struct {
+	stats     *models.TenantStats
+	expiresAt time.Time
+}
+
+// StatsService computes per-tenant usage analytics. Results are cached per
+// tenant and window because the underlying aggregations scan event tables.
+type StatsService struct {
+	store repository.Repository
+	now   func() time.Time
+
+	mu    sync.Mutex
+	cache map[string]cachedStats
+}
+
+// NewStatsService creates a new StatsService.
+func NewStatsService(store repository.Repository) *StatsService {
+	return &StatsService{
+		store: store,
+		now:   time.Now,
+		cache: make(map[string]cachedStats),
+	}
+}
+
+// TenantStats returns usage statistics for the current tenant over the last
+// days days.
+func (s *StatsService) TenantStats(ctx context.Context, days int) (*models.TenantStats, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	if days == 0 {
+		days = DefaultStatsWindowDays
+	}
+	if days < 1 || days > MaxStatsWindowDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", ErrInvalidInput, MaxStatsWindowDays)
+	}
+
+	key := fmt.Sprintf("%s/%d", tenantID, days)
+	now := s.now()
+
+	s.mu.Lock()
+	if entry, ok := s.cache[key]; ok && now.Before(entry.expiresAt) {
+		s.mu.Unlock()
+		return entry.stats, nil
+	}
+	s.mu.Unlock()
+
+	stats, err := s.store.GetTenantStats(ctx, tenantID, now.AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+	stats.WindowDays = days
+	stats.GeneratedAt = now
+
+	s.mu.Lock()
+	for k, entry := range s.cache {
+		if !now.Before(entry.expiresAt) {
+			delete(s.cache, k)
+		}
+	}
+	s.cache[key] = cachedStats{stats: stats, expiresAt: now.Add(statsCacheTTL)}
+	s.mu.Unlock()
+
+	return stats, nil
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsService_TenantStats_Cached(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewStatsService(mockStore)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := contextutil.WithTenant(context.Background(), "t1")
+
+	mockStore.On("GetTenantStats", ctx, "t1", now.AddDate(0, 0, -DefaultStatsWindowDays)).
+		Return(&models.TenantStats{TenantID: "t1", TotalMemories: 3}, nil).Once()
+
+	stats, err := svc.TenantStats(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, stats.TotalMemories)
+	assert.Equal(t, DefaultStatsWindowDays, stats.WindowDays)
+	assert.Equal(t, now, stats.GeneratedAt)
+
+	// Served from the cache until the TTL elapses.
+	now = now.Add(statsCacheTTL / 2)
+	_, err = svc.TenantStats(ctx, DefaultStatsWindowDays)
+	assert.NoError(t, err)
+	mockStore.AssertNumberOfCalls(t, "GetTenantStats", 1)
+
+	now = now.Add(statsCacheTTL)
+	mockStore.On("GetTenantStats", ctx, "t1", now.AddDate(0, 0, -DefaultStatsWindowDays)).
+		Return(&models.TenantStats{TenantID: "t1", TotalMemories: 4}, nil).Once()
+	stats, err = svc.TenantStats(ctx, DefaultStatsWindowDays)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, stats.TotalMemories)
+	mockStore.AssertExpectations(t)
+}
+
+func TestStatsService_TenantStats_InvalidDays(t *testing.T) {
+	svc := NewStatsService(new(MockMemoryStore))
+	ctx := contextutil.WithTenant(context.Background(), "t1")
+
+	_, err := svc.TenantStats(ctx, -1)
+	assert.True(t, errors.Is(err, ErrInvalidInput))
+
+	_, err = svc.TenantStats(ctx, MaxStatsWindowDays+1)
+	assert.True(t, errors.Is(err, ErrInvalidInput))
+}
@@ -0,0 +1,290 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"evolutionary-mcp/backend/internal/apikey"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidInput is returned when a request fails validation.
+var ErrInvalidInput = errors.New("invalid input")
+
+// ErrUnauthorized is returned when the caller has no tenant or the resource
+// belongs to another tenant.
+var ErrUnauthorized = repository.ErrUnauthorized
+
+// ErrForbidden is returned when the caller may see a record but not act on
+// it, e.g. to review a change it proposed itself.
+var ErrForbidden = errors.New("forbidden")
+
+// ErrPreconditionFailed is returned when an edit names the version it was
+// based on and the record has changed since.
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// DefaultAPIKeyScopes are granted to API keys created without explicit scopes.
+var DefaultAPIKeyScopes = []string{"evolve:read", "evolve:write"}
+
+// TenantUpdate holds the tenant fields an administrator may change. Nil fields
+// are left untouched; a non-nil Quotas replaces all of the tenant's overrides.
+type TenantUpdate struct {
+	Name       *string
+	BrandTitle *string
+	LogoSVG    *string
+	Quotas     *models.TenantQuotas
+}
+
+// TenantService is a service for onboarding and administering tenants.
+type TenantService struct {
+	store repository.Repository
+}
+
+// NewTenantService creates a new TenantService.
+func NewTenantService(store repository.Repository) *TenantService {
+	return &TenantService{store: store}
+}
+
+// ListTenants returns every tenant.
+func (s *TenantService) ListTenants(ctx context.Context) ([]*models.Tenant, error) {
+	return s.store.ListTenants(ctx)
+}
+
+// GetTenant returns a single tenant by ID.
+func (s *TenantService) GetTenant(ctx context.Context, id string) (*models.Tenant, error) {
+	return s.store.GetTenantByID(ctx, id)
+}
+
+// CreateTenant onboards a tenant. The first domain becomes the primary domain.
+func (s *TenantService) CreateTenant(ctx context.Context, name, brandTitle, logoSVG string, domains []string) (*models.Tenant, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if len(domains) == 0 {
+		return nil, fmt.Errorf("%w: at least one domain is required", ErrInvalidInput)
+	}
+
+	normalized := make([]string, 0, len(domains))
+	for _, d := range domains {
+		domain, err := normalizeDomain(d)
+		if err != nil {
+			return nil, err
+		}
+		normalized = append(normalized, domain)
+	}
+
+	tenant := &models.Tenant{
+		Name:       name,
+		Domain:     normalized[0],
+		Domains:    normalized,
+		BrandTitle: brandTitle,
+		LogoSVG:    logoSVG,
+		Status:     models.TenantStatusActive,
+	}
+	if err := s.store.CreateTenant(ctx, tenant); err != nil {
+		return nil, err
+	}
+	return tenant, nil
+}
+
+// UpdateTenant applies the non-nil fields of update to a tenant.
+func (s *TenantService) UpdateTenant(ctx context.Context, id string, update TenantUpdate) (*models.Tenant, error) {
+	tenant, err := s.store.GetTenantByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidInput)
+		}
+		tenant.Name = name
+	}
+	if update.BrandTitle != nil {
+		tenant.BrandTitle = *update.BrandTitle
+	}
+	if update.LogoSVG != nil {
+		tenant.LogoSVG = *update.LogoSVG
+	}
+	if update.Quotas != nil {
+		if err := validateQuotas(*update.Quotas); err != nil {
+			return nil, err
+		}
+		tenant.Quotas = *update.Quotas
+	}
+
+	if err := s.store.UpdateTenant(ctx, tenant); err != nil {
+		return nil, err
+	}
+	return tenant, nil
+}
+
+// SuspendTenant blocks every user of a tenant from authenticating.
+func (s *TenantService) SuspendTenant(ctx context.Context, id string) (*models.Tenant, error) {
+	return s.setStatus(ctx, id, models.TenantStatusSuspended)
+}
+
+// ActivateTenant lifts a suspension.
+func (s *TenantService) ActivateTenant(ctx context.Context, id string) (*models.Tenant, error) {
+	return s.setStatus(ctx, id, models.TenantStatusActive)
+}
+
+func (s *TenantService) setStatus(ctx context.Context, id, status string) (*models.Tenant, error) {
+	tenant, err := s.store.GetTenantByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	tenant.Status = status
+	if err := s.store.UpdateTenant(ctx, tenant); err != nil {
+		return nil, err
+	}
+	return tenant, nil
+}
+
+// DeleteTenant permanently removes a tenant and all of its data.
+func (s *TenantService) DeleteTenant(ctx context.Context, id string) error {
+	return s.store.DeleteTenant(ctx, id)
+}
+
+// AddDomain maps an additional email domain to a tenant.
+func (s *TenantService) AddDomain(ctx context.Context, id, domain string) (*models.Tenant, error) {
+	domain, err := normalizeDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.store.AddTenantDomain(ctx, id, domain); err != nil {
+		return nil, err
+	}
+	return s.store.GetTenantByID(ctx, id)
+}
+
+// RemoveDomain unmaps an email domain from a tenant. The primary domain cannot
+// be removed.
+func (s *TenantService) RemoveDomain(ctx context.Context, id, domain string) (*models.Tenant, error) {
+	domain, err := normalizeDomain(domain)
+	if err != nil {
+		return nil, err
+	}
+	tenant, err := s.store.GetTenantByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if tenant.Domain == domain {
+		return nil, fmt.Errorf("%w: %q is the primary domain of tenant %s", ErrInvalidInput, domain, id)
+	}
+	if err := s.store.RemoveTenantDomain(ctx, id, domain); err != nil {
+		return nil, err
+	}
+	return s.store.GetTenantByID(ctx, id)
+}
+
+// ListMembers returns the explicit members of a tenant.
+func (s *TenantService) ListMembers(ctx context.Context, id string) ([]*models.TenantMember, error) {
+	if _, err := s.store.GetTenantByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.store.ListTenantMembers(ctx, id)
+}
+
+// AddMember grants a user access to a tenant independently of their email
+// domain. Members resolve to the tenant before their domain is consulted.
+func (s *TenantService) AddMember(ctx context.Context, id, email string) (*models.TenantMember, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if at := strings.Index(email, "@"); at <= 0 || at != strings.LastIndex(email, "@") || at == len(email)-1 {
+		return nil, fmt.Errorf("%w: %q is not a valid email address", ErrInvalidInput, email)
+	}
+	member := &models.TenantMember{TenantID: id, Email: email}
+	if err := s.store.AddTenantMember(ctx, member); err != nil {
+		return nil, err
+	}
+	return member, nil
+}
+
+// RemoveMember revokes a user's explicit access to a tenant.
+func (s *TenantService) RemoveMember(ctx context.Context, id, email string) error {
+	return s.store.RemoveTenantMember(ctx, id, strings.ToLower(strings.TrimSpace(email)))
+}
+
+// ListUserTenants returns every tenant a user can switch to: their explicit
+// memberships followed by the tenant of their email domain, if any.
+func (s *TenantService) ListUserTenants(ctx context.Context, email string) ([]*models.Tenant, error) {
+	email = strings.ToLower(email)
+	tenants, err := s.store.ListTenantsForMember(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := email[strings.LastIndex(email, "@")+1:]
+	byDomain, err := s.store.GetTenantByDomain(ctx, domain)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return tenants, nil
+		}
+		return nil, err
+	}
+	for _, t := range tenants {
+		if t.ID == byDomain.ID {
+			return tenants, nil
+		}
+	}
+	return append(tenants, byDomain), nil
+}
+
+// CreateAPIKey issues an API key for a tenant. The returned secret is shown
+// once and cannot be recovered. Keys default to read and write access.
+func (s *TenantService) CreateAPIKey(ctx context.Context, id, name string, scopes []string) (*models.APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: name is required", ErrInvalidInput)
+	}
+	if len(scopes) == 0 {
+		scopes = DefaultAPIKeyScopes
+	}
+
+	secret, display, hash, err := apikey.Generate()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	key := &models.APIKey{TenantID: id, Name: name, Prefix: display, Scopes: scopes}
+	if err := s.store.CreateAPIKey(ctx, key, hash); err != nil {
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+// ListAPIKeys returns a tenant's API keys without their secrets.
+func (s *TenantService) ListAPIKeys(ctx context.Context, id string) ([]*models.APIKey, error) {
+	if _, err := s.store.GetTenantByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.store.ListAPIKeys(ctx, id)
+}
+
+// RevokeAPIKey permanently disables an API key.
+func (s *TenantService) RevokeAPIKey(ctx context.Context, id, keyID string) error {
+	return s.store.RevokeAPIKey(ctx, id, keyID)
+}
+
+// validateQuotas rejects negative quota overrides.
+func validateQuotas(q models.TenantQuotas) error {
+	if (q.MaxMemories != nil && *q.MaxMemories < 0) ||
+		(q.EmbeddingsPerMinute != nil && *q.EmbeddingsPerMinute < 0) ||
+		(q.RESTRequestsPerSecond != nil && *q.RESTRequestsPerSecond < 0) ||
+		(q.MCPRequestsPerSecond != nil && *q.MCPRequestsPerSecond < 0) {
+		return fmt.Errorf("%w: quotas cannot be negative", ErrInvalidInput)
+	}
+	return nil
+}
+
+// normalizeDomain lower-cases an email domain and rejects obviously invalid values.
+func normalizeDomain(domain string) (string, error) {
+	d := strings.ToLower(strings.TrimSpace(domain))
+	if d == "" || strings.ContainsAny(d, "@/ ") {
+		return "", fmt.Errorf("%w: %q is not a valid email domain", ErrInvalidInput, domain)
+	}
+	return d, nil
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/apikey"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTenantService_CreateTenant(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewTenantService(mockStore)
+	ctx := context.Background()
+
+	mockStore.On("CreateTenant", ctx, mock.MatchedBy(func(tenant *models.Tenant) bool {
+		return tenant.Name == "Acme" &&
+			tenant.Domain == "acme.com" &&
+			len(tenant.Domains) == 2 && tenant.Domains[1] == "acme.io" &&
+			tenant.Status == models.TenantStatusActive
+	})).Return(nil)
+
+	tenant, err := svc.CreateTenant(ctx, " Acme ", "", "", []string{"ACME.com", " acme.io"})
+	assert.NoError(t, err)
+	assert.Equal(t, "acme.com", tenant.Domain)
+	mockStore.AssertExpectations(t)
+}
+
+func TestTenantService_CreateTenant_InvalidInput(t *testing.T) {
+	svc := NewTenantService(new(MockMemoryStore))
+	ctx := context.Background()
+
+	_, err := svc.CreateTenant(ctx, "", "", "", []string{"acme.com"})
+	assert.True(t, errors.Is(err, ErrInvalidInput))
+
+	_, err = svc.CreateTenant(ctx, "Acme", "", "", nil)
+	assert.True(t, errors.Is(err, ErrInvalidInput))
+
+	_, err = svc.CreateTenant(ctx, "Acme", "", "", []string{"user@acme.com"})
+	assert.True(t, errors.Is(err, ErrInvalidInput))
+}
+
+func TestTenantService_SuspendTenant(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewTenantService(mockStore)
+	ctx := context.Background()
+
+	mockStore.On("GetTenantByID", ctx, "t1").Return(&models.Tenant{ID: "t1", Status: models.TenantStatusActive}, nil)
+	mockStore.On("UpdateTenant", ctx, mock.MatchedBy(func(tenant *models.Tenant) bool {
+		return tenant.Status == models.TenantStatusSuspended
+	})).Return(nil)
+
+	tenant, err := svc.SuspendTenant(ctx, "t1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.TenantStatusSuspended, tenant.Status)
+	mockStore.AssertExpectations(t)
+}
+
+func TestTenantService_RemoveDomain_RejectsPrimary(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewTenantService(mockStore)
+	ctx := context.Background()
+
+	mockStore.On("GetTenantByID", ctx, "t1").Return(&models.Tenant{ID: "t1", Domain: "acme.com"}, nil)
+
+	_, err := svc.RemoveDomain(ctx, "t1", "acme.com")
+	assert.True(t, errors.Is(err, ErrInvalidInput))
+	mockStore.AssertNotCalled(t, "RemoveTenantDomain", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTenantService_AddMember(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewTenantService(mockStore)
+	ctx := context.Background()
+
+	mockStore.On("AddTenantMember", ctx, mock.MatchedBy(func(member *models.TenantMember) bool {
+		return member.TenantID == "t1" && member.Email == "contractor@gmail.com"
+	})).Return(nil)
+
+	member, err := svc.AddMember(ctx, "t1", " Contractor@Gmail.com ")
+	assert.NoError(t, err)
+	assert.Equal(t, "contractor@gmail.com", member.Email)
+
+	_, err = svc.AddMember(ctx, "t1", "not-an-email")
+	assert.True(t, errors.Is(err, ErrInvalidInput))
+	mockStore.AssertExpectations(t)
+}
+
+func TestTenantService_ListUserTenants(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewTenantService(mockStore)
+	ctx := context.Background()
+
+	member := &models.Tenant{ID: "partner"}
+	home := &models.Tenant{ID: "home"}
+	mockStore.On("ListTenantsForMember", ctx, "user@acme.com").Return([]*models.Tenant{member}, nil)
+	mockStore.On("GetTenantByDomain", ctx, "acme.com").Return(home, nil)
+
+	tenants, err := svc.ListUserTenants(ctx, "user@acme.com")
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Tenant{member, home}, tenants)
+}
+
+func TestTenantService_CreateAPIKey(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewTenantService(mockStore)
+	ctx := context.Background()
+
+	var storedHash string
+	mockStore.On("CreateAPIKey", ctx, mock.MatchedBy(func(key *models.APIKey) bool {
+		return key.TenantID == "t1" && key.Name == "laptop" && len(key.Scopes) == 2
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		storedHash = args.String(2)
+	}).Return(nil)
+
+	key, secret, err := svc.CreateAPIKey(ctx, "t1", " laptop ", nil)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, key.Prefix))
+	assert.Equal(t, apikey.Hash(secret), storedHash)
+	assert.NotContains(t, storedHash, secret)
+	mockStore.AssertExpectations(t)
+
+	_, _, err = svc.CreateAPIKey(ctx, "t1", " ", nil)
+	assert.True(t, errors.Is(err, ErrInvalidInput))
+}
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// The encodings a workflow bundle can be written in.
+const (
+	BundleEncodingJSON = "json"
+	BundleEncodingYAML = "yaml"
+)
+
+// ExportWorkflow exports a workflow of the current tenant as a bundle: its
+// latest version, or every version with allVersions, each with the elements
+// nested under it and the grounding rules linked to any of them.
+func (s *WorkflowService) ExportWorkflow(ctx context.Context, workflowID string, allVersions bool) (*models.WorkflowBundle, error) {
+	var roots []*models.Workflow
+	if allVersions {
+		versions, err := s.ListVersions(ctx, workflowID)
+		if err != nil {
+			return nil, err
+		}
+		for i := len(versions) - 1; i >= 0; i-- {
+			roots = append(roots, versions[i])
+		}
+	} else {
+		latest, err := s.GetWorkflowVersion(ctx, workflowID, 0)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, latest)
+	}
+
+	bundle := &models.WorkflowBundle{
+		Format:         models.WorkflowBundleFormat,
+		ExportedAt:     time.Now().UTC(),
+		WorkflowID:     workflowID,
+		Workflows:      make([]models.BundledWorkflow, 0),
+		GroundingRules: make([]models.BundledGroundingRule, 0),
+	}
+	// Versions of elements can be nested under several versions of the
+	// workflow; they are exported once.
+	included := make(map[string]bool)
+	for _, root := range roots {
+		subtree, err := s.store.GetWorkflowSubtree(ctx, root.TenantID, root.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range subtree {
+			if included[w.ID] {
+				continue
+			}
+			included[w.ID] = true
+			bundled := models.BundledWorkflow{
+				ID:           w.ID,
+				WorkflowID:   w.WorkflowID,
+				Version:      w.Version,
+				Position:     w.Position,
+				Name:         w.Name,
+				Description:  w.Description,
+				Status:       w.Status,
+				ElementType:  w.ElementType,
+				InputSchema:  w.InputSchema,
+				OutputSchema: w.OutputSchema,
+			}
+			// Parents precede their children, so a parent in the bundle is
+			// already included.
+			if w.ParentID != nil && included[*w.ParentID] {
+				bundled.ParentID = *w.ParentID
+			}
+			bundle.Workflows = append(bundle.Workflows, bundled)
+		}
+	}
+
+	tenantID := contextutil.GetTenant(ctx)
+	rules, err := s.store.ListGroundingRules(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range rules {
+		if rule.TenantID != tenantID || rule.WorkflowID == nil || !included[*rule.WorkflowID] {
+			continue
+		}
+		bundle.GroundingRules = append(bundle.GroundingRules, models.BundledGroundingRule{
+			ID:         rule.ID,
+			WorkflowID: *rule.WorkflowID,
+			Name:       rule.Name,
+			Content:    rule.Content,
+			Status:     rule.Status,
+		})
+	}
+	return bundle, nil
+}
+
+// ImportWorkflow imports a bundle into the current tenant. Every workflow,
+// version and rule gets a new ID, so a bundle can even be imported next to
+// the workflow it was exported from. Versions keep their numbers and
+// nesting, and the newest version of each workflow becomes its latest. Every
+// version is imported as a draft, whatever its status in the bundle, so it
+// reaches review and publication only through Transition and its checks.
+// Likewise every grounding rule is imported as pending, proposed by the
+// caller, and takes effect only once a curator approves it. Grounding rules
+// are embedded in one call to the ML sidecar, like the
+// contents of RememberBatch. Either the whole bundle is imported or nothing.
+func (s *WorkflowService) ImportWorkflow(ctx context.Context, bundle *models.WorkflowBundle) (*models.WorkflowImport, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	order, err := checkBundle(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.WorkflowImport{IDs: make(map[string]string)}
+	newID := func(id string) string {
+		if _, ok := result.IDs[id]; !ok {
+			result.IDs[id] = uuid.New().String()
+		}
+		return result.IDs[id]
+	}
+	latest := make(map[string]int)
+	for _, w := range bundle.Workflows {
+		latest[w.WorkflowID] = max(latest[w.WorkflowID], w.Version)
+	}
+
+	actor := contextutil.GetUser(ctx)
+	for _, i := range order {
+		bundled := bundle.Workflows[i]
+		workflow := &models.Workflow{
+			ID:           newID(bundled.ID),
+			TenantID:     tenantID,
+			WorkflowID:   newID(bundled.WorkflowID),
+			Version:      bundled.Version,
+			IsLatest:     bundled.Version == latest[bundled.WorkflowID],
+			Name:         bundled.Name,
+			Description:  bundled.Description,
+			Status:       models.WorkflowStatusDraft,
+			Position:     bundled.Position,
+			ElementType:  bundled.ElementType,
+			InputSchema:  bundled.InputSchema,
+			OutputSchema: bundled.OutputSchema,
+			CreatedBy:    actor,
+		}
+		if workflow.ElementType == "" {
+			workflow.ElementType = "workflow"
+		}
+		if bundled.ParentID != "" {
+			parentID := newID(bundled.ParentID)
+			workflow.ParentID = &parentID
+		}
+		result.Workflows = append(result.Workflows, workflow)
+	}
+	result.GroundingRules = make([]*models.GroundingRule, 0, len(bundle.GroundingRules))
+	for _, bundled := range bundle.GroundingRules {
+		ruleID := uuid.New().String()
+		if bundled.ID != "" {
+			ruleID = newID(bundled.ID)
+		}
+		versionID := newID(bundled.WorkflowID)
+		rule := &models.GroundingRule{
+			ID:         ruleID,
+			TenantID:   tenantID,
+			WorkflowID: &versionID,
+			Name:       bundled.Name,
+			Content:    bundled.Content,
+			Status:     models.GroundingRuleStatusPending,
+			ProposedBy: actor,
+		}
+		result.GroundingRules = append(result.GroundingRules, rule)
+	}
+	if err := s.embedGroundingRules(ctx, tenantID, result.GroundingRules); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.ImportWorkflows(ctx, result.Workflows, result.GroundingRules, actor); err != nil {
+		return nil, fmt.Errorf("failed to import workflow bundle: %w", err)
+	}
+	for _, workflow := range result.Workflows {
+		s.publish(events.Created, workflow)
+	}
+	result.WorkflowID = result.IDs[bundle.WorkflowID]
+	return result, nil
+}
+
+// embedGroundingRules sets the embedding of every rule, so imported rules are
+// matched by relevance like any other.
+func (s *WorkflowService) embedGroundingRules(ctx context.Context, tenantID string, rules []*models.GroundingRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	if s.mlClient == nil {
+		return fmt.Errorf("no embedding model configured to embed %d grounding rules", len(rules))
+	}
+	if err := s.quotas.AllowEmbeddings(ctx, tenantID, len(rules)); err != nil {
+		return err
+	}
+	texts := make([]string, len(rules))
+	for i, rule := range rules {
+		texts[i] = rule.Content
+	}
+	embeddings, err := s.mlClient.GetEmbeddings(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("failed to generate embeddings: %w", err)
+	}
+	for i, rule := range rules {
+		rule.Embedding = embeddings[i]
+	}
+	return nil
+}
+
+// checkBundle rejects bundles that cannot be imported as a whole, and returns
+// the order in which to import their versions: parents before children.
+func checkBundle(bundle *models.WorkflowBundle) ([]int, error) {
+	if bundle.Format != models.WorkflowBundleFormat {
+		return nil, fmt.Errorf("%w: unsupported bundle format %q, expected %q", ErrInvalidInput, bundle.Format, models.WorkflowBundleFormat)
+	}
+	if len(bundle.Workflows) == 0 {
+		return nil, fmt.Errorf("%w: bundle holds no workflow versions", ErrInvalidInput)
+	}
+
+	index := make(map[string]int, len(bundle.Workflows))
+	versions := make(map[string]bool, len(bundle.Workflows))
+	exported := false
+	for i, w := range bundle.Workflows {
+		if w.ID == "" || w.WorkflowID == "" || w.Version < 1 {
+			return nil, fmt.Errorf("%w: workflows[%d] needs an id, a workflow_id and a version of at least 1", ErrInvalidInput, i)
+		}
+		if _, dup := index[w.ID]; dup {
+			return nil, fmt.Errorf("%w: workflow version %s appears more than once", ErrInvalidInput, w.ID)
+		}
+		index[w.ID] = i
+		key := fmt.Sprintf("%s/%d", w.WorkflowID, w.Version)
+		if versions[key] {
+			return nil, fmt.Errorf("%w: version %d of workflow %s appears more than once", ErrInvalidInput, w.Version, w.WorkflowID)
+		}
+		versions[key] = true
+		if _, known := workflowTransitions[w.Status]; w.Status != "" && !known {
+			return nil, fmt.Errorf("%w: version %d of workflow %s has unknown status %q", ErrInvalidInput, w.Version, w.WorkflowID, w.Status)
+		}
+		if err := validateWorkflowSchemas(&models.Workflow{InputSchema: w.InputSchema, OutputSchema: w.OutputSchema}); err != nil {
+			return nil, fmt.Errorf("version %d of workflow %s: %w", w.Version, w.WorkflowID, err)
+		}
+		exported = exported || w.WorkflowID == bundle.WorkflowID
+	}
+	if !exported {
+		return nil, fmt.Errorf("%w: bundle holds no version of its workflow %s", ErrInvalidInput, bundle.WorkflowID)
+	}
+	for _, rule := range bundle.GroundingRules {
+		if _, ok := index[rule.WorkflowID]; !ok {
+			return nil, fmt.Errorf("%w: grounding rule %q is linked to workflow version %s, which is not in the bundle", ErrInvalidInput, rule.Name, rule.WorkflowID)
+		}
+		if rule.Name == "" || rule.Content == "" {
+			return nil, fmt.Errorf("%w: grounding rules need a name and content", ErrInvalidInput)
+		}
+		switch rule.Status {
+		case "", models.GroundingRuleStatusPending, models.GroundingRuleStatusActive, models.GroundingRuleStatusRejected:
+		default:
+			return nil, fmt.Errorf("%w: grounding rule %q has unknown status %q", ErrInvalidInput, rule.Name, rule.Status)
+		}
+	}
+
+	// Order versions parents first, rejecting unknown parents and cycles.
+	order := make([]int, 0, len(bundle.Workflows))
+	const visiting, done = 1, 2
+	state := make([]int, len(bundle.Workflows))
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: workflow version %s is nested under itself", ErrInvalidInput, bundle.Workflows[i].ID)
+		}
+		state[i] = visiting
+		if parentID := bundle.Workflows[i].ParentID; parentID != "" {
+			parent, ok := index[parentID]
+			if !ok {
+				return fmt.Errorf("%w: parent %s of workflow version %s is not in the bundle", ErrInvalidInput, parentID, bundle.Workflows[i].ID)
+			}
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		state[i] = done
+		order = append(order, i)
+		return nil
+	}
+	for i := range bundle.Workflows {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// EncodeWorkflowBundle writes bundle as JSON or YAML.
+func EncodeWorkflowBundle(bundle *models.WorkflowBundle, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", BundleEncodingJSON:
+		return json.MarshalIndent(bundle, "", "  ")
+	case BundleEncodingYAML:
+		return yaml.Marshal(bundle)
+	}
+	return nil, fmt.Errorf("%w: unknown bundle encoding %q, use json or yaml", ErrInvalidInput, encoding)
+}
+
+// DecodeWorkflowBundle reads a bundle written as JSON or YAML.
+func DecodeWorkflowBundle(data []byte) (*models.WorkflowBundle, error) {
+	var bundle models.WorkflowBundle
+	var err error
+	if trimmed := bytes.TrimSpace(data); bytes.HasPrefix(trimmed, []byte("{")) {
+		err = json.Unmarshal(trimmed, &bundle)
+	} else {
+		err = yaml.Unmarshal(trimmed, &bundle)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: bundle is neither valid JSON nor valid YAML: %v", ErrInvalidInput, err)
+	}
+	return &bundle, nil
+}
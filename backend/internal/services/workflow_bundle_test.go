@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowService_ExportWorkflow(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewWorkflowService(mockStore)
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+
+	v1, v2 := "v1", "v2"
+	element := &models.Workflow{ID: "e1", WorkflowID: "el", Version: 1, TenantID: "test-tenant", ParentID: &v1, Name: "Step"}
+	mockStore.On("ListWorkflowVersions", ctx, "test-tenant", "wf").Return([]*models.Workflow{
+		{ID: v2, WorkflowID: "wf", Version: 2, TenantID: "test-tenant", IsLatest: true, Status: models.WorkflowStatusDraft},
+		{ID: v1, WorkflowID: "wf", Version: 1, TenantID: "test-tenant", Status: models.WorkflowStatusPublished},
+	}, nil)
+	mockStore.On("ListWorkflows", ctx).Return([]*models.Workflow{
+		{ID: v2, WorkflowID: "wf", Version: 2, TenantID: "test-tenant", IsLatest: true, Status: models.WorkflowStatusDraft},
+	}, nil)
+	mockStore.On("GetWorkflowSubtree", ctx, "test-tenant", v1).Return([]*models.Workflow{
+		{ID: v1, WorkflowID: "wf", Version: 1, TenantID: "test-tenant", Status: models.WorkflowStatusPublished},
+		element,
+	}, nil)
+	// The element is nested under both versions but exported once.
+	mockStore.On("GetWorkflowSubtree", ctx, "test-tenant", v2).Return([]*models.Workflow{
+		{ID: v2, WorkflowID: "wf", Version: 2, TenantID: "test-tenant", Status: models.WorkflowStatusDraft},
+	}, nil)
+	elementID, otherID := "e1", "other"
+	mockStore.groundingRules = []*models.GroundingRule{
+		{ID: "r1", TenantID: "test-tenant", WorkflowID: &elementID, Name: "Cite", Content: "Cite sources", Status: models.GroundingRuleStatusActive},
+		{ID: "r2", TenantID: "test-tenant", WorkflowID: &otherID, Name: "Other", Content: "Unrelated"},
+		{ID: "r3", TenantID: "test-tenant", Name: "Global", Content: "Unlinked"},
+	}
+
+	bundle, err := svc.ExportWorkflow(ctx, "wf", true)
+	require.NoError(t, err)
+	assert.Equal(t, models.WorkflowBundleFormat, bundle.Format)
+	require.Len(t, bundle.Workflows, 3)
+	assert.Equal(t, []string{v1, "e1", v2}, []string{bundle.Workflows[0].ID, bundle.Workflows[1].ID, bundle.Workflows[2].ID})
+	assert.Equal(t, v1, bundle.Workflows[1].ParentID)
+	assert.Empty(t, bundle.Workflows[0].ParentID)
+	require.Len(t, bundle.GroundingRules, 1)
+	assert.Equal(t, "r1", bundle.GroundingRules[0].ID)
+
+	latest, err := svc.ExportWorkflow(ctx, "wf", false)
+	require.NoError(t, err)
+	require.Len(t, latest.Workflows, 1)
+	assert.Equal(t, v2, latest.Workflows[0].ID)
+	assert.Empty(t, latest.GroundingRules)
+}
+
+func TestWorkflowService_ImportWorkflow(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	mockML := new(MockMLClient)
+	publisher := &recordingPublisher{}
+	svc := NewWorkflowService(mockStore).WithEmbeddings(mockML, nil).WithEvents(publisher)
+	ctx := contextutil.WithUser(contextutil.WithTenant(context.Background(), "prod"), "release@example.com")
+
+	// Children may precede their parents in a hand-written bundle.
+	bundle := &models.WorkflowBundle{
+		Format:     models.WorkflowBundleFormat,
+		WorkflowID: "wf",
+		Workflows: []models.BundledWorkflow{
+			{ID: "e1", WorkflowID: "el", Version: 1, ParentID: "v1", Name: "Step", Status: models.WorkflowStatusPublished},
+			{ID: "v1", WorkflowID: "wf", Version: 1, Name: "Flow", Status: models.WorkflowStatusPublished},
+			{ID: "v2", WorkflowID: "wf", Version: 2, Name: "Flow"},
+		},
+		GroundingRules: []models.BundledGroundingRule{
+			{ID: "r1", WorkflowID: "e1", Name: "Cite", Content: "Cite sources", Status: models.GroundingRuleStatusActive},
+		},
+	}
+	mockML.On("GetEmbeddings", ctx, []string{"Cite sources"}).Return([][]float32{{0.1, 0.2}}, nil)
+	var imported []*models.Workflow
+	var rules []*models.GroundingRule
+	mockStore.On("ImportWorkflows", ctx, mock.Anything, mock.Anything, "release@example.com").
+		Run(func(args mock.Arguments) {
+			imported = args.Get(1).([]*models.Workflow)
+			rules = args.Get(2).([]*models.GroundingRule)
+		}).Return(nil)
+
+	result, err := svc.ImportWorkflow(ctx, bundle)
+	require.NoError(t, err)
+	mockStore.AssertExpectations(t)
+
+	require.Len(t, imported, 3)
+	for _, id := range []string{"wf", "el", "v1", "v2", "e1", "r1"} {
+		assert.NotEmpty(t, result.IDs[id], id)
+		assert.NotEqual(t, id, result.IDs[id])
+	}
+	assert.Equal(t, result.IDs["wf"], result.WorkflowID)
+
+	// Parents are imported first, lineage is kept, every version is a draft
+	// and the newest version of each workflow is its latest.
+	byID := make(map[string]*models.Workflow)
+	for i, w := range imported {
+		byID[w.ID] = w
+		if w.ParentID != nil {
+			assert.Contains(t, byID, *w.ParentID, "parent imported after workflow %d", i)
+		}
+		assert.Equal(t, "prod", w.TenantID)
+	}
+	parent := byID[result.IDs["v1"]]
+	assert.Equal(t, models.WorkflowStatusDraft, parent.Status)
+	assert.False(t, parent.IsLatest)
+	latest := byID[result.IDs["v2"]]
+	assert.Equal(t, 2, latest.Version)
+	assert.True(t, latest.IsLatest)
+	assert.Equal(t, models.WorkflowStatusDraft, latest.Status)
+	assert.Equal(t, result.IDs["wf"], latest.WorkflowID)
+	element := byID[result.IDs["e1"]]
+	require.NotNil(t, element.ParentID)
+	assert.Equal(t, result.IDs["v1"], *element.ParentID)
+	assert.True(t, element.IsLatest)
+	assert.Equal(t, models.WorkflowStatusDraft, element.Status)
+
+	require.Len(t, rules, 1)
+	assert.Equal(t, result.IDs["r1"], rules[0].ID)
+	assert.Equal(t, result.IDs["e1"], *rules[0].WorkflowID)
+	assert.Equal(t, models.GroundingRuleStatusPending, rules[0].Status)
+	assert.Equal(t, "release@example.com", rules[0].ProposedBy)
+	assert.Equal(t, []float32{0.1, 0.2}, rules[0].Embedding)
+	mockML.AssertExpectations(t)
+	assert.Len(t, publisher.events, 3)
+	assert.Equal(t, events.Created, publisher.events[0].Kind)
+}
+
+func TestWorkflowService_ImportWorkflow_EmbeddingFailure(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	mockML := new(MockMLClient)
+	svc := NewWorkflowService(mockStore).WithEmbeddings(mockML, nil)
+	ctx := contextutil.WithTenant(context.Background(), "prod")
+
+	mockML.On("GetEmbeddings", ctx, []string{"Cite sources"}).Return(nil, errors.New("sidecar down"))
+
+	_, err := svc.ImportWorkflow(ctx, &models.WorkflowBundle{
+		Format:         models.WorkflowBundleFormat,
+		WorkflowID:     "wf",
+		Workflows:      []models.BundledWorkflow{{ID: "v1", WorkflowID: "wf", Version: 1, Name: "Flow"}},
+		GroundingRules: []models.BundledGroundingRule{{WorkflowID: "v1", Name: "Cite", Content: "Cite sources"}},
+	})
+
+	require.ErrorContains(t, err, "sidecar down")
+	mockStore.AssertNotCalled(t, "ImportWorkflows", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWorkflowService_ImportWorkflow_RejectsInconsistentBundles(t *testing.T) {
+	svc := NewWorkflowService(new(MockMemoryStore))
+	ctx := contextutil.WithTenant(context.Background(), "prod")
+
+	valid := func() *models.WorkflowBundle {
+		return &models.WorkflowBundle{
+			Format:     models.WorkflowBundleFormat,
+			WorkflowID: "wf",
+			Workflows: []models.BundledWorkflow{
+				{ID: "v1", WorkflowID: "wf", Version: 1, Name: "Flow"},
+				{ID: "e1", WorkflowID: "el", Version: 1, ParentID: "v1", Name: "Step"},
+			},
+		}
+	}
+	tests := map[string]func(b *models.WorkflowBundle){
+		"unknown format":      func(b *models.WorkflowBundle) { b.Format = "other/v9" },
+		"no versions":         func(b *models.WorkflowBundle) { b.Workflows = nil },
+		"missing own version": func(b *models.WorkflowBundle) { b.WorkflowID = "elsewhere" },
+		"duplicate id":        func(b *models.WorkflowBundle) { b.Workflows[1].ID = "v1" },
+		"duplicate version":   func(b *models.WorkflowBundle) { b.Workflows[1].WorkflowID = "wf" },
+		"missing version":     func(b *models.WorkflowBundle) { b.Workflows[1].Version = 0 },
+		"unknown status":      func(b *models.WorkflowBundle) { b.Workflows[0].Status = "live" },
+		"unknown parent":      func(b *models.WorkflowBundle) { b.Workflows[1].ParentID = "gone" },
+		"cycle":               func(b *models.WorkflowBundle) { b.Workflows[0].ParentID = "e1" },
+		"invalid schema": func(b *models.WorkflowBundle) {
+			b.Workflows[0].InputSchema = map[string]interface{}{"type": 7}
+		},
+		"rule outside bundle": func(b *models.WorkflowBundle) {
+			b.GroundingRules = []models.BundledGroundingRule{{WorkflowID: "gone", Name: "Cite", Content: "Cite sources"}}
+		},
+		"rule without content": func(b *models.WorkflowBundle) {
+			b.GroundingRules = []models.BundledGroundingRule{{WorkflowID: "e1", Name: "Cite"}}
+		},
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			bundle := valid()
+			mutate(bundle)
+			_, err := svc.ImportWorkflow(ctx, bundle)
+			assert.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+}
+
+func TestWorkflowBundle_Encoding(t *testing.T) {
+	bundle := &models.WorkflowBundle{
+		Format:     models.WorkflowBundleFormat,
+		WorkflowID: "wf",
+		Workflows: []models.BundledWorkflow{{
+			ID: "v1", WorkflowID: "wf", Version: 1, Name: "Flow", Status: models.WorkflowStatusPublished,
+			InputSchema: map[string]interface{}{"type": "object", "required": []interface{}{"q"}},
+		}},
+		GroundingRules: []models.BundledGroundingRule{{ID: "r1", WorkflowID: "v1", Name: "Cite", Content: "Cite sources"}},
+	}
+
+	for _, encoding := range []string{BundleEncodingJSON, BundleEncodingYAML} {
+		t.Run(encoding, func(t *testing.T) {
+			data, err := EncodeWorkflowBundle(bundle, encoding)
+			require.NoError(t, err)
+
+			decoded, err := DecodeWorkflowBundle(data)
+			require.NoError(t, err)
+			assert.Equal(t, bundle.Workflows[0].Name, decoded.Workflows[0].Name)
+			assert.Equal(t, "object", decoded.Workflows[0].InputSchema["type"])
+			assert.Equal(t, bundle.GroundingRules, decoded.GroundingRules)
+			_, err = checkBundle(decoded)
+			assert.NoError(t, err)
+		})
+	}
+
+	_, err := EncodeWorkflowBundle(bundle, "xml")
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	_, err = DecodeWorkflowBundle([]byte("{not json"))
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
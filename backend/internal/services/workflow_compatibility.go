@@ -0,0 +1,419 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"evolutionary-mcp/backend/pkg/models"
+)
+
+// CheckCompatibility classifies the changes to the input and output schemas
+// from one version of a workflow to another. A version of 0 selects the
+// latest version.
+func (s *WorkflowService) CheckCompatibility(ctx context.Context, workflowID string, from, to int) (*models.CompatibilityReport, error) {
+	before, err := s.GetWorkflowVersion(ctx, workflowID, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.GetWorkflowVersion(ctx, workflowID, to)
+	if err != nil {
+		return nil, err
+	}
+	return compareWorkflowSchemas(before, after), nil
+}
+
+// compareWorkflowSchemas classifies the schema changes from before to after.
+func compareWorkflowSchemas(before, after *models.Workflow) *models.CompatibilityReport {
+	report := &models.CompatibilityReport{
+		WorkflowID:    after.WorkflowID,
+		FromVersion:   before.Version,
+		ToVersion:     after.Version,
+		Compatibility: models.CompatibilityFull,
+		Changes:       make([]models.SchemaChange, 0),
+	}
+	for _, role := range []struct {
+		name          string
+		before, after map[string]interface{}
+		required      string
+	}{
+		// Callers send inputs written for the old schema; consumers read
+		// outputs expecting the old schema.
+		{"input_schema", before.InputSchema, after.InputSchema, models.CompatibilityBackward},
+		{"output_schema", before.OutputSchema, after.OutputSchema, models.CompatibilityForward},
+	} {
+		var changes []models.SchemaChange
+		changes = compareSchemas(changes, "/"+role.name, plainJSON(role.before), plainJSON(role.after))
+		for _, change := range changes {
+			change.Breaking = !satisfies(change.Compatibility, role.required)
+			report.Breaking = report.Breaking || change.Breaking
+			report.Compatibility = combineCompatibility(report.Compatibility, change.Compatibility)
+			report.Changes = append(report.Changes, change)
+		}
+	}
+	return report
+}
+
+// compareSchemas appends the changes from the schema before to the schema
+// after. A missing schema, or true, accepts anything; false accepts nothing.
+func compareSchemas(changes []models.SchemaChange, path string, before, after interface{}) []models.SchemaChange {
+	if reflect.DeepEqual(before, after) {
+		return changes
+	}
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	if beforeIsMap && afterIsMap {
+		return compareSchemaKeywords(changes, path, beforeMap, afterMap)
+	}
+
+	switch {
+	case acceptsAnything(before):
+		return append(changes, schemaChange(path, models.CompatibilityForward, "constraints added"))
+	case acceptsAnything(after):
+		return append(changes, schemaChange(path, models.CompatibilityBackward, "constraints removed"))
+	case after == false:
+		return append(changes, schemaChange(path, models.CompatibilityForward, "no longer accepts any value"))
+	case before == false:
+		return append(changes, schemaChange(path, models.CompatibilityBackward, "now accepts values"))
+	default:
+		return append(changes, schemaChange(path, models.CompatibilityNone, "schema changed"))
+	}
+}
+
+// schemaAnnotations never change which values a schema accepts.
+var schemaAnnotations = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true, "description": true,
+	"default": true, "examples": true, "deprecated": true, "readOnly": true, "writeOnly": true,
+}
+
+// Lower bounds narrow a schema as they grow; upper bounds as they shrink.
+var (
+	lowerBounds = map[string]bool{"minimum": true, "exclusiveMinimum": true, "minLength": true, "minItems": true, "minProperties": true}
+	upperBounds = map[string]bool{"maximum": true, "exclusiveMaximum": true, "maxLength": true, "maxItems": true, "maxProperties": true}
+)
+
+func compareSchemaKeywords(changes []models.SchemaChange, path string, before, after map[string]interface{}) []models.SchemaChange {
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	propertiesCompared := false
+	for _, k := range keys {
+		oldValue, newValue := before[k], after[k]
+		if reflect.DeepEqual(oldValue, newValue) || schemaAnnotations[k] {
+			continue
+		}
+		at := path + "/" + escapePointer(k)
+		switch {
+		case k == "properties" || k == "required":
+			// Properties and which of them are required are compared together.
+			if !propertiesCompared {
+				changes = compareProperties(changes, path, before, after)
+				propertiesCompared = true
+			}
+		case k == "additionalProperties" || k == "items":
+			changes = compareSchemas(changes, at, oldValue, newValue)
+		case k == "type":
+			changes = compareValueSets(changes, at, "type", typeSet(oldValue), typeSet(newValue))
+		case k == "enum" || k == "const":
+			changes = compareValueSets(changes, at, k, valueSet(k, oldValue), valueSet(k, newValue))
+		case lowerBounds[k] || upperBounds[k]:
+			changes = compareBound(changes, at, k, oldValue, newValue, lowerBounds[k])
+		case k == "pattern" || k == "format":
+			changes = compareConstraint(changes, at, k, oldValue, newValue)
+		default:
+			changes = append(changes, schemaChange(at, models.CompatibilityNone, fmt.Sprintf("%s changed; its compatibility cannot be determined", k)))
+		}
+	}
+	return changes
+}
+
+// compareProperties compares the properties of two object schemas and which
+// of them are required.
+func compareProperties(changes []models.SchemaChange, path string, before, after map[string]interface{}) []models.SchemaChange {
+	beforeProps := keysOf(before, "properties")
+	afterProps := keysOf(after, "properties")
+	beforeRequired := requiredSet(before)
+	afterRequired := requiredSet(after)
+	// Extra properties are allowed unless additionalProperties says otherwise.
+	beforeClosed := before["additionalProperties"] == false
+	afterClosed := after["additionalProperties"] == false
+
+	names := make([]string, 0, len(beforeProps)+len(afterProps)+len(beforeRequired)+len(afterRequired))
+	seen := make(map[string]bool)
+	for _, set := range []map[string]interface{}{beforeProps, afterProps} {
+		for name := range set {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	for _, set := range []map[string]bool{beforeRequired, afterRequired} {
+		for name := range set {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		at := path + "/properties/" + escapePointer(name)
+		oldSchema, hadProperty := beforeProps[name]
+		newSchema, hasProperty := afterProps[name]
+		switch {
+		case !hadProperty && hasProperty && afterRequired[name]:
+			compatibility := models.CompatibilityForward
+			if beforeClosed {
+				compatibility = models.CompatibilityNone
+			}
+			changes = append(changes, schemaChange(at, compatibility, fmt.Sprintf("required property %s added", name)))
+		case !hadProperty && hasProperty:
+			compatibility := models.CompatibilityFull
+			if beforeClosed {
+				compatibility = models.CompatibilityBackward
+			}
+			changes = append(changes, schemaChange(at, compatibility, fmt.Sprintf("optional property %s added", name)))
+		case hadProperty && !hasProperty:
+			compatibility := models.CompatibilityBackward
+			if afterClosed {
+				compatibility = models.CompatibilityNone
+			}
+			changes = append(changes, schemaChange(at, compatibility, fmt.Sprintf("property %s removed", name)))
+		default:
+			if hadProperty {
+				changes = compareSchemas(changes, at, oldSchema, newSchema)
+			}
+			switch {
+			case afterRequired[name] && !beforeRequired[name]:
+				changes = append(changes, schemaChange(path+"/required", models.CompatibilityForward, fmt.Sprintf("property %s is now required", name)))
+			case beforeRequired[name] && !afterRequired[name]:
+				changes = append(changes, schemaChange(path+"/required", models.CompatibilityBackward, fmt.Sprintf("property %s is no longer required", name)))
+			}
+		}
+	}
+	return changes
+}
+
+// compareValueSets compares keywords that restrict a value to a set, where a
+// nil set allows anything.
+func compareValueSets(changes []models.SchemaChange, path, keyword string, before, after map[string]bool) []models.SchemaChange {
+	widened := covers(after, before, keyword)
+	narrowed := covers(before, after, keyword)
+	description := fmt.Sprintf("%s changed from %s to %s", keyword, describeSet(before), describeSet(after))
+	switch {
+	case widened && narrowed:
+		return changes
+	case widened:
+		return append(changes, schemaChange(path, models.CompatibilityBackward, description))
+	case narrowed:
+		return append(changes, schemaChange(path, models.CompatibilityForward, description))
+	default:
+		return append(changes, schemaChange(path, models.CompatibilityNone, description))
+	}
+}
+
+// covers reports whether the set a allows every value set b allows. For
+// types, number allows integers.
+func covers(a, b map[string]bool, keyword string) bool {
+	if a == nil {
+		return true
+	}
+	if b == nil {
+		return false
+	}
+	for v := range b {
+		if !a[v] && !(keyword == "type" && v == "integer" && a["number"]) {
+			return false
+		}
+	}
+	return true
+}
+
+func compareBound(changes []models.SchemaChange, path, keyword string, before, after interface{}, lower bool) []models.SchemaChange {
+	oldBound, hadBound := before.(float64)
+	newBound, hasBound := after.(float64)
+	narrowed := false
+	switch {
+	case !hadBound && !hasBound:
+		return append(changes, schemaChange(path, models.CompatibilityNone, fmt.Sprintf("%s changed; its compatibility cannot be determined", keyword)))
+	case !hadBound:
+		narrowed = true
+	case !hasBound:
+		narrowed = false
+	case lower:
+		narrowed = newBound > oldBound
+	default:
+		narrowed = newBound < oldBound
+	}
+	description := fmt.Sprintf("%s changed from %s to %s", keyword, describeValue(before), describeValue(after))
+	if narrowed {
+		return append(changes, schemaChange(path, models.CompatibilityForward, description))
+	}
+	return append(changes, schemaChange(path, models.CompatibilityBackward, description))
+}
+
+// compareConstraint compares keywords whose values cannot be ordered, such
+// as patterns: adding one narrows the schema, removing one widens it, and
+// changing one may do either.
+func compareConstraint(changes []models.SchemaChange, path, keyword string, before, after interface{}) []models.SchemaChange {
+	description := fmt.Sprintf("%s changed from %s to %s", keyword, describeValue(before), describeValue(after))
+	switch {
+	case before == nil:
+		return append(changes, schemaChange(path, models.CompatibilityForward, description))
+	case after == nil:
+		return append(changes, schemaChange(path, models.CompatibilityBackward, description))
+	default:
+		return append(changes, schemaChange(path, models.CompatibilityNone, description))
+	}
+}
+
+func schemaChange(path, compatibility, description string) models.SchemaChange {
+	return models.SchemaChange{Path: path, Compatibility: compatibility, Description: description}
+}
+
+// satisfies reports whether a change of compatibility has the compatibility
+// required.
+func satisfies(compatibility, required string) bool {
+	return compatibility == models.CompatibilityFull || compatibility == required
+}
+
+// combineCompatibility returns the compatibility of two changes together.
+func combineCompatibility(a, b string) string {
+	switch {
+	case a == b || b == models.CompatibilityFull:
+		return a
+	case a == models.CompatibilityFull:
+		return b
+	default:
+		return models.CompatibilityNone
+	}
+}
+
+// acceptsAnything reports whether a schema places no constraint at all.
+func acceptsAnything(schema interface{}) bool {
+	if schema == nil || schema == true {
+		return true
+	}
+	m, ok := schema.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for k := range m {
+		if !schemaAnnotations[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// keysOf returns the object under key of schema, or an empty one.
+func keysOf(schema map[string]interface{}, key string) map[string]interface{} {
+	if m, ok := schema[key].(map[string]interface{}); ok {
+		return m
+	}
+	return map[string]interface{}{}
+}
+
+func requiredSet(schema map[string]interface{}) map[string]bool {
+	set := make(map[string]bool)
+	if names, ok := schema["required"].([]interface{}); ok {
+		for _, name := range names {
+			if s, ok := name.(string); ok {
+				set[s] = true
+			}
+		}
+	}
+	return set
+}
+
+// typeSet returns the types a type keyword allows, or nil for any type.
+func typeSet(v interface{}) map[string]bool {
+	switch t := v.(type) {
+	case string:
+		return map[string]bool{t: true}
+	case []interface{}:
+		set := make(map[string]bool, len(t))
+		for _, name := range t {
+			if s, ok := name.(string); ok {
+				set[s] = true
+			}
+		}
+		return set
+	default:
+		return nil
+	}
+}
+
+// valueSet returns the values an enum or const allows, encoded as JSON, or
+// nil for any value.
+func valueSet(keyword string, v interface{}) map[string]bool {
+	if v == nil {
+		return nil
+	}
+	values := []interface{}{v}
+	if keyword == "enum" {
+		list, ok := v.([]interface{})
+		if !ok {
+			return nil
+		}
+		values = list
+	}
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[describeValue(value)] = true
+	}
+	return set
+}
+
+func describeSet(set map[string]bool) string {
+	if set == nil {
+		return "any"
+	}
+	values := make([]string, 0, len(set))
+	for v := range set {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return strings.Join(values, "|")
+}
+
+func describeValue(v interface{}) string {
+	if v == nil {
+		return "none"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
+
+// plainJSON converts a schema into plain decoded JSON, so that schemas built
+// in code compare like schemas read from the database.
+func plainJSON(schema map[string]interface{}) interface{} {
+	if schema == nil {
+		return nil
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return schema
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return schema
+	}
+	return v
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func objectSchema(required []string, properties map[string]interface{}) map[string]interface{} {
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if required != nil {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func TestCompareWorkflowSchemas(t *testing.T) {
+	str := map[string]interface{}{"type": "string"}
+	base := objectSchema([]string{"email"}, map[string]interface{}{"email": str})
+
+	cases := []struct {
+		name          string
+		beforeInput   map[string]interface{} // defaults to base
+		input         map[string]interface{}
+		output        map[string]interface{}
+		compatibility string
+		breaking      bool
+		path          string
+	}{
+		{"unchanged", nil, base, base, models.CompatibilityFull, false, ""},
+		{
+			"description only", nil, objectSchema([]string{"email"}, map[string]interface{}{"email": map[string]interface{}{"type": "string", "description": "work email"}}), base,
+			models.CompatibilityFull, false, "",
+		},
+		{
+			"optional input added", nil, objectSchema([]string{"email"}, map[string]interface{}{"email": str, "name": str}), base,
+			models.CompatibilityFull, false, "/input_schema/properties/name",
+		},
+		{
+			"required input added", nil, objectSchema([]string{"email", "name"}, map[string]interface{}{"email": str, "name": str}), base,
+			models.CompatibilityForward, true, "/input_schema/properties/name",
+		},
+		{
+			"required output added", nil, base, objectSchema([]string{"email", "name"}, map[string]interface{}{"email": str, "name": str}),
+			models.CompatibilityForward, false, "/output_schema/properties/name",
+		},
+		{
+			"output property removed", nil, base, objectSchema(nil, map[string]interface{}{}),
+			models.CompatibilityBackward, true, "/output_schema/properties/email",
+		},
+		{
+			"input property made optional", nil, objectSchema(nil, map[string]interface{}{"email": str}), base,
+			models.CompatibilityBackward, false, "/input_schema/required",
+		},
+		{
+			"output type widened", nil, base, objectSchema([]string{"email"}, map[string]interface{}{"email": map[string]interface{}{"type": []interface{}{"string", "null"}}}),
+			models.CompatibilityBackward, true, "/output_schema/properties/email/type",
+		},
+		{
+			"input type changed", map[string]interface{}{"type": "string"}, map[string]interface{}{"type": "number"}, base,
+			models.CompatibilityNone, true, "/input_schema/type",
+		},
+		{
+			"input enum extended",
+			objectSchema([]string{"email"}, map[string]interface{}{"email": map[string]interface{}{"type": "string", "enum": []interface{}{"a", "b"}}}),
+			objectSchema([]string{"email"}, map[string]interface{}{"email": map[string]interface{}{"type": "string", "enum": []interface{}{"a", "b", "c"}}}), base,
+			models.CompatibilityBackward, false, "/input_schema/properties/email/enum",
+		},
+		{
+			"input maxLength lowered", nil, objectSchema([]string{"email"}, map[string]interface{}{"email": map[string]interface{}{"type": "string", "maxLength": 10}}), base,
+			models.CompatibilityForward, true, "/input_schema/properties/email/maxLength",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			before := &models.Workflow{WorkflowID: "wf", Version: 1, InputSchema: base, OutputSchema: base}
+			if tc.beforeInput != nil {
+				before.InputSchema = tc.beforeInput
+			}
+			after := &models.Workflow{WorkflowID: "wf", Version: 2, InputSchema: tc.input, OutputSchema: tc.output}
+
+			report := compareWorkflowSchemas(before, after)
+
+			assert.Equal(t, tc.compatibility, report.Compatibility)
+			assert.Equal(t, tc.breaking, report.Breaking)
+			if tc.path == "" {
+				assert.Empty(t, report.Changes)
+				return
+			}
+			require.Len(t, report.Changes, 1, report.Changes)
+			assert.Equal(t, tc.path, report.Changes[0].Path)
+			assert.NotEmpty(t, report.Changes[0].Description)
+		})
+	}
+}
+
+func TestWorkflowService_Transition_BreakingChanges(t *testing.T) {
+	ctx := contextutil.WithUser(contextutil.WithTenant(context.Background(), "test-tenant"), "curator@example.com")
+	published := &models.Workflow{
+		ID: "v1", WorkflowID: "wf", Version: 1, TenantID: "test-tenant", Status: models.WorkflowStatusPublished,
+		InputSchema: objectSchema(nil, map[string]interface{}{"email": map[string]interface{}{"type": "string"}}),
+	}
+	reviewed := &models.Workflow{
+		ID: "v2", WorkflowID: "wf", Version: 2, TenantID: "test-tenant", Status: models.WorkflowStatusReview,
+		InputSchema: objectSchema([]string{"email"}, map[string]interface{}{"email": map[string]interface{}{"type": "string"}}),
+	}
+
+	for _, allowBreaking := range []bool{false, true} {
+		mockStore := new(MockMemoryStore)
+		svc := NewWorkflowService(mockStore)
+		mockStore.On("GetWorkflowVersion", ctx, "test-tenant", "wf", 2).Return(reviewed, nil)
+		mockStore.On("ListWorkflowVersions", ctx, "test-tenant", "wf").Return([]*models.Workflow{reviewed, published}, nil)
+		mockStore.On("TransitionWorkflow", ctx, mock.MatchedBy(func(tr *models.WorkflowTransition) bool {
+			return tr.AllowBreaking
+		})).Return(nil)
+
+		_, err := svc.Transition(ctx, "wf", 2, models.WorkflowStatusPublished, "", allowBreaking)
+
+		if !allowBreaking {
+			require.ErrorIs(t, err, repository.ErrConflict)
+			assert.Contains(t, err.Error(), "/input_schema/required: property email is now required")
+			mockStore.AssertNotCalled(t, "TransitionWorkflow", mock.Anything, mock.Anything)
+			continue
+		}
+		require.NoError(t, err)
+		mockStore.AssertExpectations(t)
+	}
+}
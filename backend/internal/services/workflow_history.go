@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+)
+
+// ListVersions returns every version of a workflow of the current tenant,
+// newest first.
+func (s *WorkflowService) ListVersions(ctx context.Context, workflowID string) ([]*models.Workflow, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+
+	versions, err := s.store.ListWorkflowVersions(ctx, tenantID, workflowID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("workflow %s: %w", workflowID, repository.ErrNotFound)
+	}
+	for _, w := range versions {
+		if w.TenantID != tenantID {
+			return nil, fmt.Errorf("%w: workflow belongs to another tenant", ErrUnauthorized)
+		}
+	}
+	return versions, nil
+}
+
+// DiffVersions compares the name, description and schemas of two versions of
+// a workflow. A version of 0 selects the latest version.
+func (s *WorkflowService) DiffVersions(ctx context.Context, workflowID string, from, to int) (*models.WorkflowDiff, error) {
+	before, err := s.GetWorkflowVersion(ctx, workflowID, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.GetWorkflowVersion(ctx, workflowID, to)
+	if err != nil {
+		return nil, err
+	}
+	return diffWorkflows(before, after), nil
+}
+
+// Rollback copies an older version of a workflow into a new draft that
+// becomes its latest version. The history is kept: the copy gets the next
+// version number. Publishing the copy is left to Transition, so it passes the
+// same lifecycle and compatibility checks as any other version.
+func (s *WorkflowService) Rollback(ctx context.Context, workflowID string, version int) (*models.Workflow, error) {
+	if version < 1 {
+		return nil, fmt.Errorf("%w: version must be at least 1", ErrInvalidInput)
+	}
+	latest, err := s.GetWorkflowVersion(ctx, workflowID, 0)
+	if err != nil {
+		return nil, err
+	}
+	if latest.Version == version {
+		return nil, fmt.Errorf("%w: version %d is already the latest", ErrInvalidInput, version)
+	}
+	if _, err := s.GetWorkflowVersion(ctx, workflowID, version); err != nil {
+		return nil, err
+	}
+
+	restored, err := s.store.RollbackWorkflow(ctx, latest.TenantID, workflowID, version, contextutil.GetUser(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll back workflow: %w", err)
+	}
+	s.publish(events.Created, restored)
+	s.publish(events.Updated, latest)
+	return restored, nil
+}
+
+// diffWorkflows lists the changes from before to after.
+func diffWorkflows(before, after *models.Workflow) *models.WorkflowDiff {
+	diff := &models.WorkflowDiff{
+		WorkflowID:  after.WorkflowID,
+		FromVersion: before.Version,
+		ToVersion:   after.Version,
+		Changes:     make([]models.WorkflowChange, 0),
+	}
+	fields := []struct {
+		name          string
+		before, after interface{}
+	}{
+		{"name", before.Name, after.Name},
+		{"description", before.Description, after.Description},
+		{"element_type", before.ElementType, after.ElementType},
+		{"input_schema", schemaValue(before.InputSchema), schemaValue(after.InputSchema)},
+		{"output_schema", schemaValue(before.OutputSchema), schemaValue(after.OutputSchema)},
+	}
+	for _, f := range fields {
+		diff.Changes = appendChanges(diff.Changes, "/"+f.name, f.before, f.after)
+	}
+	return diff
+}
+
+// schemaValue treats a missing schema like an absent value rather than an
+// empty object.
+func schemaValue(schema map[string]interface{}) interface{} {
+	if schema == nil {
+		return nil
+	}
+	return schema
+}
+
+// appendChanges compares two JSON values, descending into objects so that a
+// change deep inside a schema is reported at its own path. Arrays are
+// compared as a whole.
+func appendChanges(changes []models.WorkflowChange, path string, before, after interface{}) []models.WorkflowChange {
+	beforeMap, beforeIsMap := before.(map[string]interface{})
+	afterMap, afterIsMap := after.(map[string]interface{})
+	switch {
+	case beforeIsMap && afterIsMap:
+		keys := make([]string, 0, len(beforeMap)+len(afterMap))
+		for k := range beforeMap {
+			keys = append(keys, k)
+		}
+		for k := range afterMap {
+			if _, ok := beforeMap[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			changes = appendChanges(changes, path+"/"+escapePointer(k), beforeMap[k], afterMap[k])
+		}
+		return changes
+	case before == nil && after == nil:
+		return changes
+	case before == nil:
+		return append(changes, models.WorkflowChange{Path: path, Kind: models.ChangeAdded, To: after})
+	case after == nil:
+		return append(changes, models.WorkflowChange{Path: path, Kind: models.ChangeRemoved, From: before})
+	case reflect.DeepEqual(before, after):
+		return changes
+	default:
+		return append(changes, models.WorkflowChange{Path: path, Kind: models.ChangeChanged, From: before, To: after})
+	}
+}
+
+// escapePointer escapes a key for use in a JSON Pointer (RFC 6901).
+func escapePointer(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
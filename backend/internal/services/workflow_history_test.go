@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowService_ListVersions(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewWorkflowService(mockStore)
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+
+	mockStore.On("ListWorkflowVersions", ctx, "test-tenant", "wf").Return([]*models.Workflow{
+		{WorkflowID: "wf", Version: 2, TenantID: "test-tenant"},
+		{WorkflowID: "wf", Version: 1, TenantID: "test-tenant"},
+	}, nil)
+	mockStore.On("ListWorkflowVersions", ctx, "test-tenant", "missing").Return([]*models.Workflow{}, nil)
+
+	versions, err := svc.ListVersions(ctx, "wf")
+	require.NoError(t, err)
+	assert.Len(t, versions, 2)
+
+	_, err = svc.ListVersions(ctx, "missing")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestWorkflowService_DiffVersions(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewWorkflowService(mockStore)
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+
+	mockStore.On("GetWorkflowVersion", ctx, "test-tenant", "wf", 1).Return(&models.Workflow{
+		WorkflowID:  "wf",
+		Version:     1,
+		TenantID:    "test-tenant",
+		Name:        "onboarding",
+		Description: "old",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name":  map[string]interface{}{"type": "string"},
+				"a/b":   map[string]interface{}{"type": "string"},
+				"email": map[string]interface{}{"type": "string"},
+			},
+		},
+	}, nil)
+	mockStore.On("GetWorkflowVersion", ctx, "test-tenant", "wf", 2).Return(&models.Workflow{
+		WorkflowID:  "wf",
+		Version:     2,
+		TenantID:    "test-tenant",
+		Name:        "onboarding",
+		Description: "new",
+		InputSchema: map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"name": map[string]interface{}{"type": "integer"},
+				"age":  map[string]interface{}{"type": "integer"},
+				"a/b":  map[string]interface{}{"type": "string"},
+			},
+		},
+		OutputSchema: map[string]interface{}{"type": "object"},
+	}, nil)
+
+	diff, err := svc.DiffVersions(ctx, "wf", 1, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, diff.FromVersion)
+	assert.Equal(t, 2, diff.ToVersion)
+	assert.Equal(t, []models.WorkflowChange{
+		{Path: "/description", Kind: models.ChangeChanged, From: "old", To: "new"},
+		{Path: "/input_schema/properties/age", Kind: models.ChangeAdded, To: map[string]interface{}{"type": "integer"}},
+		{Path: "/input_schema/properties/email", Kind: models.ChangeRemoved, From: map[string]interface{}{"type": "string"}},
+		{Path: "/input_schema/properties/name/type", Kind: models.ChangeChanged, From: "string", To: "integer"},
+		{Path: "/output_schema", Kind: models.ChangeAdded, To: map[string]interface{}{"type": "object"}},
+	}, diff.Changes)
+}
+
+func TestWorkflowService_Rollback(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	publisher := &recordingPublisher{}
+	svc := NewWorkflowService(mockStore).WithEvents(publisher)
+	ctx := contextutil.WithUser(contextutil.WithTenant(context.Background(), "test-tenant"), "curator@example.com")
+
+	mockStore.On("ListWorkflows", ctx).Return([]*models.Workflow{{WorkflowID: "wf", Version: 3, TenantID: "test-tenant"}}, nil)
+	mockStore.On("GetWorkflowVersion", ctx, "test-tenant", "wf", 1).Return(&models.Workflow{WorkflowID: "wf", Version: 1, TenantID: "test-tenant"}, nil)
+	mockStore.On("RollbackWorkflow", ctx, "test-tenant", "wf", 1, "curator@example.com").
+		Return(&models.Workflow{WorkflowID: "wf", Version: 4, TenantID: "test-tenant", IsLatest: true, Status: models.WorkflowStatusDraft}, nil)
+
+	restored, err := svc.Rollback(ctx, "wf", 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, restored.Version)
+	assert.Equal(t, models.WorkflowStatusDraft, restored.Status)
+	assert.Equal(t, []events.Event{
+		{Kind: events.Created, URI: "workflow://wf/v4", TenantID: "test-tenant"},
+		{Kind: events.Updated, URI: "workflow://wf/v3", TenantID: "test-tenant"},
+	}, publisher.events)
+
+	_, err = svc.Rollback(ctx, "wf", 3)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	mockStore.AssertNumberOfCalls(t, "RollbackWorkflow", 1)
+}
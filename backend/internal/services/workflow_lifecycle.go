@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/google/uuid"
+)
+
+// workflowTransitions lists the statuses a workflow version may move to from
+// each status. A version in review can be sent back to draft for changes;
+// archived versions are final.
+var workflowTransitions = map[string][]string{
+	models.WorkflowStatusDraft:      {models.WorkflowStatusReview, models.WorkflowStatusArchived},
+	models.WorkflowStatusReview:     {models.WorkflowStatusDraft, models.WorkflowStatusPublished, models.WorkflowStatusArchived},
+	models.WorkflowStatusPublished:  {models.WorkflowStatusDeprecated},
+	models.WorkflowStatusDeprecated: {models.WorkflowStatusArchived},
+	models.WorkflowStatusArchived:   {},
+}
+
+func canTransition(from, to string) bool {
+	for _, allowed := range workflowTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Save creates a workflow or a new version of one when workflow has no
+// WorkflowID or asks to SaveAsNewVersion; new versions always start as
+// drafts. Only an empty WorkflowID starts a new workflow: saving a new
+// version of a workflow this tenant does not have fails with
+// repository.ErrNotFound, and any other lookup error is returned as is.
+// Otherwise it edits the version workflow.ID in place, which is only
+// allowed while that version is a draft. Statuses are changed with
+// Transition, never by Save. Input and output schemas must be valid JSON
+// Schemas. An edit with a non-zero workflow.Revision fails with
+// ErrPreconditionFailed unless the version is still at that revision, and
+// any edit fails with repository.ErrConflict if another one lands first.
+func (s *WorkflowService) Save(ctx context.Context, workflow *models.Workflow) (*models.Workflow, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	if err := validateWorkflowSchemas(workflow); err != nil {
+		return nil, err
+	}
+	workflow.TenantID = tenantID
+	if user := contextutil.GetUser(ctx); user != "" {
+		workflow.CreatedBy = user
+	}
+
+	if workflow.SaveAsNewVersion || workflow.WorkflowID == "" {
+		var previous *models.Workflow
+		if workflow.WorkflowID == "" {
+			workflow.WorkflowID = uuid.New().String()
+		} else {
+			latest, err := s.GetWorkflowVersion(ctx, workflow.WorkflowID, 0)
+			if err != nil {
+				return nil, err
+			}
+			previous = latest
+		}
+		workflow.ID = ""
+		workflow.Status = models.WorkflowStatusDraft
+		if err := s.store.CreateWorkflow(ctx, workflow); err != nil {
+			return nil, fmt.Errorf("failed to create workflow version: %w", err)
+		}
+		s.publish(events.Created, workflow)
+		if previous != nil {
+			s.publish(events.Updated, previous)
+		}
+		return workflow, nil
+	}
+
+	if workflow.ID == "" {
+		return nil, fmt.Errorf("%w: id is required to edit a workflow version", ErrInvalidInput)
+	}
+	existing, err := s.store.GetWorkflow(ctx, workflow.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.TenantID != tenantID {
+		return nil, fmt.Errorf("%w: workflow belongs to another tenant", ErrUnauthorized)
+	}
+	if existing.Status != models.WorkflowStatusDraft {
+		return nil, fmt.Errorf("%w: version %d of workflow %s is %s and can no longer be edited; save it as a new version",
+			repository.ErrConflict, existing.Version, existing.WorkflowID, existing.Status)
+	}
+	if workflow.Revision != 0 && workflow.Revision != existing.Revision {
+		return nil, fmt.Errorf("%w: version %d of workflow %s is at revision %d, not %d",
+			ErrPreconditionFailed, existing.Version, existing.WorkflowID, existing.Revision, workflow.Revision)
+	}
+
+	workflow.Status = existing.Status
+	workflow.Revision = existing.Revision
+	if err := s.store.UpdateWorkflow(ctx, workflow); err != nil {
+		return nil, fmt.Errorf("failed to update workflow: %w", err)
+	}
+	updated, err := s.store.GetWorkflow(ctx, workflow.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(events.Updated, updated)
+	return updated, nil
+}
+
+// Transition moves a version of a workflow of the current tenant to status
+// and records who did it. Moves the lifecycle does not allow are conflicts,
+// and so is publishing a version with breaking schema changes from the
+// version published before it, unless allowBreaking overrides the check.
+func (s *WorkflowService) Transition(ctx context.Context, workflowID string, version int, status, comment string, allowBreaking bool) (*models.Workflow, error) {
+	if _, known := workflowTransitions[status]; !known {
+		return nil, fmt.Errorf("%w: unknown workflow status %q", ErrInvalidInput, status)
+	}
+	if version < 1 {
+		return nil, fmt.Errorf("%w: version must be at least 1", ErrInvalidInput)
+	}
+	workflow, err := s.GetWorkflowVersion(ctx, workflowID, version)
+	if err != nil {
+		return nil, err
+	}
+	if !canTransition(workflow.Status, status) {
+		return nil, fmt.Errorf("%w: version %d of workflow %s cannot move from %s to %s",
+			repository.ErrConflict, version, workflowID, workflow.Status, status)
+	}
+
+	transition := &models.WorkflowTransition{
+		TenantID:   workflow.TenantID,
+		VersionID:  workflow.ID,
+		WorkflowID: workflow.WorkflowID,
+		Version:    workflow.Version,
+		FromStatus: workflow.Status,
+		ToStatus:   status,
+		Actor:      contextutil.GetUser(ctx),
+		Comment:    comment,
+	}
+	if status == models.WorkflowStatusPublished {
+		report, err := s.compatibilityWithPublished(ctx, workflow)
+		if err != nil {
+			return nil, err
+		}
+		if report != nil && report.Breaking {
+			if !allowBreaking {
+				return nil, fmt.Errorf("%w: version %d of workflow %s has breaking schema changes from published version %d: %s; allow breaking changes to publish it anyway",
+					repository.ErrConflict, version, workflowID, report.FromVersion, describeBreakingChanges(report))
+			}
+			transition.AllowBreaking = true
+		}
+	}
+	if err := s.store.TransitionWorkflow(ctx, transition); err != nil {
+		return nil, err
+	}
+
+	workflow.Status = status
+	workflow.UpdatedAt = transition.CreatedAt
+	s.publish(events.Updated, workflow)
+	return workflow, nil
+}
+
+// compatibilityWithPublished compares the schemas of workflow with those of
+// the newest other published version of the workflow, if there is one.
+func (s *WorkflowService) compatibilityWithPublished(ctx context.Context, workflow *models.Workflow) (*models.CompatibilityReport, error) {
+	versions, err := s.store.ListWorkflowVersions(ctx, workflow.TenantID, workflow.WorkflowID)
+	if err != nil {
+		return nil, err
+	}
+	for _, published := range versions {
+		if published.Status == models.WorkflowStatusPublished && published.ID != workflow.ID {
+			return compareWorkflowSchemas(published, workflow), nil
+		}
+	}
+	return nil, nil
+}
+
+func describeBreakingChanges(report *models.CompatibilityReport) string {
+	var parts []string
+	for _, change := range report.Changes {
+		if change.Breaking {
+			parts = append(parts, change.Path+": "+change.Description)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// ListTransitions returns the status changes of every version of a workflow
+// of the current tenant, newest first.
+func (s *WorkflowService) ListTransitions(ctx context.Context, workflowID string) ([]*models.WorkflowTransition, error) {
+	if _, err := s.ListVersions(ctx, workflowID); err != nil {
+		return nil, err
+	}
+	return s.store.ListWorkflowTransitions(ctx, contextutil.GetTenant(ctx), workflowID)
+}
+
+// publish reports a changed workflow version, if anyone is listening.
+func (s *WorkflowService) publish(kind events.Kind, workflow *models.Workflow) {
+	if s.events != nil {
+		s.events.Publish(events.Event{Kind: kind, URI: models.WorkflowURI(workflow.WorkflowID, workflow.Version), TenantID: workflow.TenantID})
+	}
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowService_Transition(t *testing.T) {
+	ctx := contextutil.WithUser(contextutil.WithTenant(context.Background(), "test-tenant"), "curator@example.com")
+
+	cases := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{models.WorkflowStatusDraft, models.WorkflowStatusReview, true},
+		{models.WorkflowStatusReview, models.WorkflowStatusDraft, true},
+		{models.WorkflowStatusReview, models.WorkflowStatusPublished, true},
+		{models.WorkflowStatusPublished, models.WorkflowStatusDeprecated, true},
+		{models.WorkflowStatusDeprecated, models.WorkflowStatusArchived, true},
+		{models.WorkflowStatusDraft, models.WorkflowStatusPublished, false},
+		{models.WorkflowStatusPublished, models.WorkflowStatusDraft, false},
+		{models.WorkflowStatusArchived, models.WorkflowStatusDraft, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.from+" to "+tc.to, func(t *testing.T) {
+			mockStore := new(MockMemoryStore)
+			publisher := &recordingPublisher{}
+			svc := NewWorkflowService(mockStore).WithEvents(publisher)
+			mockStore.On("GetWorkflowVersion", ctx, "test-tenant", "wf", 2).
+				Return(&models.Workflow{ID: "v2", WorkflowID: "wf", Version: 2, TenantID: "test-tenant", Status: tc.from}, nil)
+			mockStore.On("ListWorkflowVersions", ctx, "test-tenant", "wf").
+				Return([]*models.Workflow{{ID: "v2", WorkflowID: "wf", Version: 2, TenantID: "test-tenant", Status: tc.from}}, nil).Maybe()
+			mockStore.On("TransitionWorkflow", ctx, mock.MatchedBy(func(tr *models.WorkflowTransition) bool {
+				return tr.VersionID == "v2" && tr.FromStatus == tc.from && tr.ToStatus == tc.to &&
+					tr.Actor == "curator@example.com" && tr.Comment == "looks good"
+			})).Run(func(args mock.Arguments) {
+				args.Get(1).(*models.WorkflowTransition).CreatedAt = time.Now()
+			}).Return(nil)
+
+			workflow, err := svc.Transition(ctx, "wf", 2, tc.to, "looks good", false)
+
+			if !tc.allowed {
+				assert.ErrorIs(t, err, repository.ErrConflict)
+				mockStore.AssertNotCalled(t, "TransitionWorkflow", mock.Anything, mock.Anything)
+				assert.Empty(t, publisher.events)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.to, workflow.Status)
+			assert.Equal(t, []events.Event{{Kind: events.Updated, URI: "workflow://wf/v2", TenantID: "test-tenant"}}, publisher.events)
+			mockStore.AssertExpectations(t)
+		})
+	}
+
+	svc := NewWorkflowService(new(MockMemoryStore))
+	_, err := svc.Transition(ctx, "wf", 2, "active", "", false)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestWorkflowService_Save_PublishedVersionsAreImmutable(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewWorkflowService(mockStore)
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+
+	mockStore.On("GetWorkflow", ctx, "v1").
+		Return(&models.Workflow{ID: "v1", WorkflowID: "wf", Version: 1, TenantID: "test-tenant", Status: models.WorkflowStatusPublished}, nil)
+
+	_, err := svc.Save(ctx, &models.Workflow{ID: "v1", WorkflowID: "wf", Name: "edited"})
+
+	assert.ErrorIs(t, err, repository.ErrConflict)
+	mockStore.AssertNotCalled(t, "UpdateWorkflow", mock.Anything, mock.Anything)
+}
+
+func TestWorkflowService_Save_EditsDraftInPlace(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewWorkflowService(mockStore)
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+
+	draft := &models.Workflow{ID: "v1", WorkflowID: "wf", Version: 1, TenantID: "test-tenant", Status: models.WorkflowStatusDraft}
+	mockStore.On("GetWorkflow", ctx, "v1").Return(draft, nil)
+	mockStore.On("UpdateWorkflow", ctx, mock.MatchedBy(func(w *models.Workflow) bool {
+		// The status in the request is ignored.
+		return w.Name == "edited" && w.Status == models.WorkflowStatusDraft && w.TenantID == "test-tenant"
+	})).Return(nil)
+
+	_, err := svc.Save(ctx, &models.Workflow{ID: "v1", WorkflowID: "wf", Name: "edited", Status: models.WorkflowStatusPublished})
+
+	require.NoError(t, err)
+	mockStore.AssertExpectations(t)
+}
+
+func TestWorkflowService_Save_ChecksRevision(t *testing.T) {
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+	draft := func() *models.Workflow {
+		return &models.Workflow{ID: "v1", WorkflowID: "wf", Version: 1, Revision: 4, TenantID: "test-tenant", Status: models.WorkflowStatusDraft}
+	}
+
+	t.Run("edits based on an older revision fail their precondition", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewWorkflowService(mockStore)
+		mockStore.On("GetWorkflow", ctx, "v1").Return(draft(), nil)
+
+		_, err := svc.Save(ctx, &models.Workflow{ID: "v1", WorkflowID: "wf", Name: "edited", Revision: 3})
+
+		assert.ErrorIs(t, err, ErrPreconditionFailed)
+		mockStore.AssertNotCalled(t, "UpdateWorkflow", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unconditional edits are checked against the revision they read", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewWorkflowService(mockStore)
+		mockStore.On("GetWorkflow", ctx, "v1").Return(draft(), nil)
+		mockStore.On("UpdateWorkflow", ctx, mock.MatchedBy(func(w *models.Workflow) bool { return w.Revision == 4 })).
+			Return(repository.ErrConflict)
+
+		_, err := svc.Save(ctx, &models.Workflow{ID: "v1", WorkflowID: "wf", Name: "edited"})
+
+		assert.ErrorIs(t, err, repository.ErrConflict)
+		mockStore.AssertExpectations(t)
+	})
+}
+
+func TestWorkflowService_Save_NewVersionsStartAsDrafts(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewWorkflowService(mockStore)
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+
+	mockStore.On("ListWorkflows", ctx).Return([]*models.Workflow{{ID: "v1", WorkflowID: "wf", TenantID: "test-tenant", Version: 1, Status: models.WorkflowStatusPublished}}, nil)
+	mockStore.On("CreateWorkflow", ctx, mock.MatchedBy(func(w *models.Workflow) bool {
+		return w.WorkflowID == "wf" && w.Status == models.WorkflowStatusDraft && w.ID == ""
+	})).Return(nil)
+
+	saved, err := svc.Save(ctx, &models.Workflow{ID: "v1", WorkflowID: "wf", Status: models.WorkflowStatusPublished, SaveAsNewVersion: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, models.WorkflowStatusDraft, saved.Status)
+	mockStore.AssertExpectations(t)
+}
+
+func TestWorkflowService_Save_NewVersionOfUnknownWorkflow(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewWorkflowService(mockStore)
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+
+	mockStore.On("ListWorkflows", ctx).Return([]*models.Workflow{}, nil).Once()
+
+	_, err := svc.Save(ctx, &models.Workflow{WorkflowID: "someone-elses", SaveAsNewVersion: true})
+	require.ErrorIs(t, err, repository.ErrNotFound)
+
+	mockStore.On("ListWorkflows", ctx).Return(nil, errors.New("connection reset")).Once()
+
+	_, err = svc.Save(ctx, &models.Workflow{WorkflowID: "wf", SaveAsNewVersion: true})
+	require.ErrorContains(t, err, "connection reset")
+	mockStore.AssertNotCalled(t, "CreateWorkflow", mock.Anything, mock.Anything)
+}
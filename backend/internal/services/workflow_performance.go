@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+)
+
+const (
+	// minComparisonSamples is how many samples each version needs before a
+	// change in a metric can count as significant.
+	minComparisonSamples = 5
+	// significanceZ is the two-sided critical value at the 95% level.
+	significanceZ = 1.96
+)
+
+// WorkflowPerformance reports how each version of a workflow of the current
+// tenant performed over the last days days, comparing every version with the
+// version before it.
+func (s *StatsService) WorkflowPerformance(ctx context.Context, workflowID string, days int) (*models.WorkflowPerformance, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	if days == 0 {
+		days = DefaultStatsWindowDays
+	}
+	if days < 1 || days > MaxStatsWindowDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", ErrInvalidInput, MaxStatsWindowDays)
+	}
+
+	now := s.now()
+	versions, err := s.store.GetWorkflowVersionPerformance(ctx, tenantID, workflowID, now.AddDate(0, 0, -days))
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("workflow %s: %w", workflowID, repository.ErrNotFound)
+	}
+	for i := 1; i < len(versions); i++ {
+		versions[i].Comparison = compareVersions(&versions[i-1], &versions[i])
+	}
+
+	return &models.WorkflowPerformance{
+		WorkflowID:  workflowID,
+		WindowDays:  days,
+		GeneratedAt: now,
+		Versions:    versions,
+	}, nil
+}
+
+// compareVersions compares the run success rate, the share of positive
+// feedback and the average memory confidence of current with baseline. The
+// verdict only counts changes that are significant.
+func compareVersions(baseline, current *models.VersionPerformance) *models.VersionComparison {
+	metrics := []models.MetricComparison{
+		compareProportions(models.MetricRunSuccessRate,
+			baseline.SucceededRuns, baseline.SucceededRuns+baseline.FailedRuns,
+			current.SucceededRuns, current.SucceededRuns+current.FailedRuns),
+		compareProportions(models.MetricPositiveFeedbackRate,
+			baseline.PositiveFeedback, baseline.PositiveFeedback+baseline.NegativeFeedback,
+			current.PositiveFeedback, current.PositiveFeedback+current.NegativeFeedback),
+		compareMeans(models.MetricAverageConfidence,
+			baseline.AverageConfidence, baseline.ConfidenceStdDev, baseline.Memories,
+			current.AverageConfidence, current.ConfidenceStdDev, current.Memories),
+	}
+
+	comparison := &models.VersionComparison{BaselineVersion: baseline.Version, Verdict: models.VerdictInsufficientData, Metrics: metrics}
+	var better, worse, comparable int
+	for _, m := range metrics {
+		if m.BaselineSamples < minComparisonSamples || m.CurrentSamples < minComparisonSamples {
+			continue
+		}
+		comparable++
+		switch {
+		case m.Significant && m.Delta > 0:
+			better++
+		case m.Significant && m.Delta < 0:
+			worse++
+		}
+	}
+	switch {
+	case better > 0 && worse > 0:
+		comparison.Verdict = models.VerdictMixed
+	case better > 0:
+		comparison.Verdict = models.VerdictBetter
+	case worse > 0:
+		comparison.Verdict = models.VerdictWorse
+	case comparable > 0:
+		comparison.Verdict = models.VerdictNoDifference
+	}
+	return comparison
+}
+
+// compareProportions compares the rate of successes in n trials with a
+// two-proportion z-test.
+func compareProportions(metric string, baseSuccesses, baseN, curSuccesses, curN int) models.MetricComparison {
+	m := models.MetricComparison{Metric: metric, BaselineSamples: baseN, CurrentSamples: curN}
+	if baseN > 0 {
+		m.Baseline = float64(baseSuccesses) / float64(baseN)
+	}
+	if curN > 0 {
+		m.Current = float64(curSuccesses) / float64(curN)
+	}
+	m.Delta = m.Current - m.Baseline
+	if baseN < minComparisonSamples || curN < minComparisonSamples {
+		return m
+	}
+	pooled := float64(baseSuccesses+curSuccesses) / float64(baseN+curN)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/float64(baseN) + 1/float64(curN)))
+	m.Significant = significant(m.Delta, se)
+	return m
+}
+
+// compareMeans compares two sample means with a z-test on their unpooled
+// standard error, which is adequate for the sample sizes required.
+func compareMeans(metric string, baseMean, baseStdDev float64, baseN int, curMean, curStdDev float64, curN int) models.MetricComparison {
+	m := models.MetricComparison{
+		Metric: metric, Baseline: baseMean, Current: curMean, Delta: curMean - baseMean,
+		BaselineSamples: baseN, CurrentSamples: curN,
+	}
+	if baseN < minComparisonSamples || curN < minComparisonSamples {
+		return m
+	}
+	se := math.Sqrt(baseStdDev*baseStdDev/float64(baseN) + curStdDev*curStdDev/float64(curN))
+	m.Significant = significant(m.Delta, se)
+	return m
+}
+
+// significant reports whether delta is significant given its standard error.
+// Without any variance every difference is.
+func significant(delta, se float64) bool {
+	if se == 0 {
+		return delta != 0
+	}
+	return math.Abs(delta/se) >= significanceZ
+}
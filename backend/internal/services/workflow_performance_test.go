@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsService_WorkflowPerformance(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewStatsService(mockStore)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+	ctx := contextutil.WithTenant(context.Background(), "t1")
+
+	mockStore.On("GetWorkflowVersionPerformance", ctx, "t1", "wf", now.AddDate(0, 0, -7)).Return([]models.VersionPerformance{
+		{Version: 1, SucceededRuns: 10, FailedRuns: 10, PositiveFeedback: 6, NegativeFeedback: 4, Memories: 20, AverageConfidence: 0.6, ConfidenceStdDev: 0.2},
+		{Version: 2, SucceededRuns: 19, FailedRuns: 1, PositiveFeedback: 6, NegativeFeedback: 4, Memories: 20, AverageConfidence: 0.62, ConfidenceStdDev: 0.2},
+		{Version: 3, SucceededRuns: 2},
+	}, nil)
+	mockStore.On("GetWorkflowVersionPerformance", ctx, "t1", "missing", now.AddDate(0, 0, -DefaultStatsWindowDays)).
+		Return([]models.VersionPerformance{}, nil)
+
+	performance, err := svc.WorkflowPerformance(ctx, "wf", 7)
+	require.NoError(t, err)
+	assert.Equal(t, 7, performance.WindowDays)
+	require.Len(t, performance.Versions, 3)
+	assert.Nil(t, performance.Versions[0].Comparison)
+
+	second := performance.Versions[1].Comparison
+	require.NotNil(t, second)
+	assert.Equal(t, 1, second.BaselineVersion)
+	assert.Equal(t, models.VerdictBetter, second.Verdict)
+	require.Len(t, second.Metrics, 3)
+	assert.Equal(t, models.MetricRunSuccessRate, second.Metrics[0].Metric)
+	assert.InDelta(t, 0.45, second.Metrics[0].Delta, 1e-9)
+	assert.True(t, second.Metrics[0].Significant)
+	assert.False(t, second.Metrics[1].Significant)
+	assert.False(t, second.Metrics[2].Significant)
+
+	// Two runs are not enough evidence either way.
+	assert.Equal(t, models.VerdictInsufficientData, performance.Versions[2].Comparison.Verdict)
+
+	_, err = svc.WorkflowPerformance(ctx, "missing", 0)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	_, err = svc.WorkflowPerformance(ctx, "wf", MaxStatsWindowDays+1)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestCompareVersions_Verdicts(t *testing.T) {
+	runs := func(succeeded, failed int) *models.VersionPerformance {
+		return &models.VersionPerformance{SucceededRuns: succeeded, FailedRuns: failed}
+	}
+
+	assert.Equal(t, models.VerdictWorse, compareVersions(runs(18, 2), runs(5, 15)).Verdict)
+	assert.Equal(t, models.VerdictNoDifference, compareVersions(runs(10, 10), runs(11, 9)).Verdict)
+
+	baseline := &models.VersionPerformance{SucceededRuns: 5, FailedRuns: 15, PositiveFeedback: 18, NegativeFeedback: 2}
+	current := &models.VersionPerformance{SucceededRuns: 18, FailedRuns: 2, PositiveFeedback: 4, NegativeFeedback: 16}
+	assert.Equal(t, models.VerdictMixed, compareVersions(baseline, current).Verdict)
+}
@@ -0,0 +1,218 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/google/uuid"
+)
+
+// maxRunErrorLength bounds the error message stored with a run.
+const maxRunErrorLength = 4000
+
+// StartRun records the current user starting a run of a version of a workflow
+// of the current tenant with inputs. A version of 0 selects the latest
+// version. Inputs must match the input schema of the version, and archived
+// versions can no longer be run.
+func (s *WorkflowService) StartRun(ctx context.Context, workflowID string, version int, inputs interface{}) (*models.WorkflowRun, error) {
+	workflow, err := s.GetWorkflowVersion(ctx, workflowID, version)
+	if err != nil {
+		return nil, err
+	}
+	if workflow.Status == models.WorkflowStatusArchived {
+		return nil, fmt.Errorf("%w: version %d of workflow %s is archived and can no longer be run",
+			repository.ErrConflict, workflow.Version, workflow.WorkflowID)
+	}
+	if err := checkPayload(workflow, models.WorkflowSchemaInput, inputs); err != nil {
+		return nil, err
+	}
+
+	run := &models.WorkflowRun{
+		TenantID:          workflow.TenantID,
+		VersionID:         workflow.ID,
+		WorkflowID:        workflow.WorkflowID,
+		Version:           workflow.Version,
+		Inputs:            inputs,
+		RecalledMemoryIDs: make([]string, 0),
+		CreatedMemoryIDs:  make([]string, 0),
+		StartedBy:         contextutil.GetUser(ctx),
+	}
+	if err := s.store.CreateWorkflowRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to start workflow run: %w", err)
+	}
+	return run, nil
+}
+
+// UpdateRun records the progress of a running run of the current tenant. A
+// terminal status completes the run; a succeeded run must have outputs that
+// match the output schema of its workflow version. Memories created during
+// the run are linked to it in their provenance.
+func (s *WorkflowService) UpdateRun(ctx context.Context, id string, update models.WorkflowRunUpdate) (*models.WorkflowRun, error) {
+	switch update.Status {
+	case "", models.WorkflowRunStatusRunning, models.WorkflowRunStatusSucceeded, models.WorkflowRunStatusFailed, models.WorkflowRunStatusCancelled:
+	default:
+		return nil, fmt.Errorf("%w: unknown workflow run status %q", ErrInvalidInput, update.Status)
+	}
+	if len(update.Error) > maxRunErrorLength {
+		return nil, fmt.Errorf("%w: error is limited to %d bytes", ErrInvalidInput, maxRunErrorLength)
+	}
+
+	run, err := s.GetRun(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status != models.WorkflowRunStatusRunning {
+		return nil, fmt.Errorf("%w: workflow run %s has already %s", repository.ErrConflict, run.ID, run.Status)
+	}
+	if err := s.checkRunMemories(ctx, update.RecalledMemoryIDs, update.CreatedMemoryIDs); err != nil {
+		return nil, err
+	}
+
+	if update.Status != "" {
+		run.Status = update.Status
+	}
+	if update.Outputs != nil {
+		run.Outputs = update.Outputs
+	}
+	if update.Error != "" {
+		run.Error = update.Error
+	}
+	run.RecalledMemoryIDs = appendUnique(run.RecalledMemoryIDs, update.RecalledMemoryIDs)
+	run.CreatedMemoryIDs = appendUnique(run.CreatedMemoryIDs, update.CreatedMemoryIDs)
+
+	if run.Status == models.WorkflowRunStatusSucceeded {
+		workflow, err := s.store.GetWorkflow(ctx, run.VersionID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPayload(workflow, models.WorkflowSchemaOutput, run.Outputs); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.store.UpdateWorkflowRun(ctx, run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// GetRun returns a workflow run of the current tenant.
+func (s *WorkflowService) GetRun(ctx context.Context, id string) (*models.WorkflowRun, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	run, err := s.store.GetWorkflowRun(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if run.TenantID != tenantID {
+		return nil, fmt.Errorf("%w: workflow run belongs to another tenant", ErrUnauthorized)
+	}
+	return run, nil
+}
+
+// ListRuns returns the runs of every version of a workflow of the current
+// tenant, newest first.
+func (s *WorkflowService) ListRuns(ctx context.Context, workflowID string) ([]*models.WorkflowRun, error) {
+	if _, err := s.ListVersions(ctx, workflowID); err != nil {
+		return nil, err
+	}
+	return s.store.ListWorkflowRuns(ctx, contextutil.GetTenant(ctx), workflowID)
+}
+
+// checkRunMemories rejects memory IDs that are not memories of the current
+// tenant.
+func (s *WorkflowService) checkRunMemories(ctx context.Context, idLists ...[]string) error {
+	var ids []string
+	for _, list := range idLists {
+		ids = appendUnique(ids, list)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := checkBatchSize(len(ids)); err != nil {
+		return err
+	}
+
+	// Malformed IDs cannot name a memory and would fail the uuid[] cast.
+	var valid, invalid []string
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err == nil {
+			valid = append(valid, id)
+		} else {
+			invalid = append(invalid, id)
+		}
+	}
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return fmt.Errorf("%w: invalid memory ids %s", ErrInvalidInput, strings.Join(invalid, ", "))
+	}
+
+	memories, err := s.store.GetBatch(ctx, valid)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(memories))
+	for _, m := range memories {
+		if m.TenantID == contextutil.GetTenant(ctx) {
+			known[m.ID] = true
+		}
+	}
+	var unknown []string
+	for _, id := range ids {
+		if !known[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: unknown memories %s", ErrInvalidInput, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// checkPayload rejects a payload that does not match the input or output
+// schema of workflow, listing every failure.
+func checkPayload(workflow *models.Workflow, schema string, payload interface{}) error {
+	result, err := validatePayload(workflow, schema, payload)
+	if err != nil {
+		return err
+	}
+	if result.Valid {
+		return nil
+	}
+	problems := make([]string, 0, len(result.Errors))
+	for _, e := range result.Errors {
+		problems = append(problems, fmt.Sprintf("at %q: %s", e.Path, e.Message))
+	}
+	return fmt.Errorf("%w: %s does not match the %s schema of version %d of workflow %s: %s",
+		ErrInvalidInput, payloadName(schema), schema, workflow.Version, workflow.WorkflowID, strings.Join(problems, "; "))
+}
+
+func payloadName(schema string) string {
+	if schema == models.WorkflowSchemaOutput {
+		return "outputs"
+	}
+	return "inputs"
+}
+
+// appendUnique appends the IDs in add that are not in ids yet.
+func appendUnique(ids []string, add []string) []string {
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	for _, id := range add {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
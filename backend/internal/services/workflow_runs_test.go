@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func runWorkflow() *models.Workflow {
+	return &models.Workflow{
+		ID: "v2", WorkflowID: "wf", Version: 2, TenantID: "test-tenant", Status: models.WorkflowStatusPublished,
+		InputSchema:  objectSchema([]string{"email"}, map[string]interface{}{"email": map[string]interface{}{"type": "string"}}),
+		OutputSchema: objectSchema([]string{"sent"}, map[string]interface{}{"sent": map[string]interface{}{"type": "boolean"}}),
+	}
+}
+
+func TestWorkflowService_StartRun(t *testing.T) {
+	ctx := contextutil.WithUser(contextutil.WithTenant(context.Background(), "test-tenant"), "agent@example.com")
+
+	t.Run("records the run", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewWorkflowService(mockStore)
+		mockStore.On("GetWorkflowVersion", ctx, "test-tenant", "wf", 2).Return(runWorkflow(), nil)
+		mockStore.On("CreateWorkflowRun", ctx, mock.MatchedBy(func(run *models.WorkflowRun) bool {
+			return run.VersionID == "v2" && run.WorkflowID == "wf" && run.Version == 2 && run.StartedBy == "agent@example.com"
+		})).Run(func(args mock.Arguments) {
+			run := args.Get(1).(*models.WorkflowRun)
+			run.ID = "run1"
+			run.Status = models.WorkflowRunStatusRunning
+		}).Return(nil)
+
+		run, err := svc.StartRun(ctx, "wf", 2, map[string]interface{}{"email": "ada@example.com"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "run1", run.ID)
+		assert.Equal(t, models.WorkflowRunStatusRunning, run.Status)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("rejects inputs that do not match the input schema", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewWorkflowService(mockStore)
+		mockStore.On("GetWorkflowVersion", ctx, "test-tenant", "wf", 2).Return(runWorkflow(), nil)
+
+		_, err := svc.StartRun(ctx, "wf", 2, map[string]interface{}{"name": "Ada"})
+
+		require.ErrorIs(t, err, ErrInvalidInput)
+		assert.Contains(t, err.Error(), "inputs does not match the input schema")
+		mockStore.AssertNotCalled(t, "CreateWorkflowRun", mock.Anything, mock.Anything)
+	})
+
+	t.Run("refuses archived versions", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewWorkflowService(mockStore)
+		archived := runWorkflow()
+		archived.Status = models.WorkflowStatusArchived
+		mockStore.On("GetWorkflowVersion", ctx, "test-tenant", "wf", 2).Return(archived, nil)
+
+		_, err := svc.StartRun(ctx, "wf", 2, map[string]interface{}{"email": "ada@example.com"})
+
+		assert.ErrorIs(t, err, repository.ErrConflict)
+	})
+}
+
+func TestWorkflowService_UpdateRun(t *testing.T) {
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+	const (
+		m1 = "6f1c2d3e-0000-4000-8000-000000000001"
+		m2 = "6f1c2d3e-0000-4000-8000-000000000002"
+		m3 = "6f1c2d3e-0000-4000-8000-000000000003"
+	)
+	running := func() *models.WorkflowRun {
+		return &models.WorkflowRun{
+			ID: "run1", TenantID: "test-tenant", VersionID: "v2", WorkflowID: "wf", Version: 2,
+			Status: models.WorkflowRunStatusRunning, RecalledMemoryIDs: []string{m1}, CreatedMemoryIDs: []string{},
+		}
+	}
+	memories := []*repository.Memory{{ID: m1, TenantID: "test-tenant"}, {ID: m2, TenantID: "test-tenant"}}
+
+	t.Run("adds memories and completes the run", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewWorkflowService(mockStore)
+		mockStore.On("GetWorkflowRun", ctx, "run1").Return(running(), nil)
+		mockStore.On("GetBatch", ctx, []string{m1, m2}).Return(memories, nil)
+		mockStore.On("GetWorkflow", ctx, "v2").Return(runWorkflow(), nil)
+		mockStore.On("UpdateWorkflowRun", ctx, mock.MatchedBy(func(run *models.WorkflowRun) bool {
+			return run.Status == models.WorkflowRunStatusSucceeded &&
+				assert.ObjectsAreEqual([]string{m1}, run.RecalledMemoryIDs) &&
+				assert.ObjectsAreEqual([]string{m2}, run.CreatedMemoryIDs)
+		})).Return(nil)
+
+		run, err := svc.UpdateRun(ctx, "run1", models.WorkflowRunUpdate{
+			Status:            models.WorkflowRunStatusSucceeded,
+			Outputs:           map[string]interface{}{"sent": true},
+			RecalledMemoryIDs: []string{m1},
+			CreatedMemoryIDs:  []string{m2},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"sent": true}, run.Outputs)
+		mockStore.AssertExpectations(t)
+	})
+
+	t.Run("rejects outputs of a succeeded run that do not match the output schema", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewWorkflowService(mockStore)
+		mockStore.On("GetWorkflowRun", ctx, "run1").Return(running(), nil)
+		mockStore.On("GetWorkflow", ctx, "v2").Return(runWorkflow(), nil)
+
+		_, err := svc.UpdateRun(ctx, "run1", models.WorkflowRunUpdate{Status: models.WorkflowRunStatusSucceeded})
+
+		require.ErrorIs(t, err, ErrInvalidInput)
+		mockStore.AssertNotCalled(t, "UpdateWorkflowRun", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed runs need no outputs", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewWorkflowService(mockStore)
+		mockStore.On("GetWorkflowRun", ctx, "run1").Return(running(), nil)
+		mockStore.On("UpdateWorkflowRun", ctx, mock.Anything).Return(nil)
+
+		run, err := svc.UpdateRun(ctx, "run1", models.WorkflowRunUpdate{Status: models.WorkflowRunStatusFailed, Error: "mail server down"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "mail server down", run.Error)
+	})
+
+	t.Run("rejects unknown memories", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewWorkflowService(mockStore)
+		mockStore.On("GetWorkflowRun", ctx, "run1").Return(running(), nil)
+		mockStore.On("GetBatch", ctx, []string{m3}).Return([]*repository.Memory{{ID: m3, TenantID: "other-tenant"}}, nil)
+
+		_, err := svc.UpdateRun(ctx, "run1", models.WorkflowRunUpdate{CreatedMemoryIDs: []string{m3}})
+
+		require.ErrorIs(t, err, ErrInvalidInput)
+		assert.Contains(t, err.Error(), m3)
+	})
+
+	t.Run("rejects malformed memory ids without querying them", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewWorkflowService(mockStore)
+		mockStore.On("GetWorkflowRun", ctx, "run1").Return(running(), nil)
+
+		_, err := svc.UpdateRun(ctx, "run1", models.WorkflowRunUpdate{CreatedMemoryIDs: []string{m2, "not-a-uuid"}})
+
+		require.ErrorIs(t, err, ErrInvalidInput)
+		assert.Contains(t, err.Error(), "not-a-uuid")
+		mockStore.AssertNotCalled(t, "GetBatch", mock.Anything, mock.Anything)
+	})
+
+	t.Run("completed runs cannot change", func(t *testing.T) {
+		mockStore := new(MockMemoryStore)
+		svc := NewWorkflowService(mockStore)
+		done := running()
+		done.Status = models.WorkflowRunStatusCancelled
+		mockStore.On("GetWorkflowRun", ctx, "run1").Return(done, nil)
+
+		_, err := svc.UpdateRun(ctx, "run1", models.WorkflowRunUpdate{Status: models.WorkflowRunStatusFailed})
+
+		assert.ErrorIs(t, err, repository.ErrConflict)
+	})
+
+	t.Run("rejects unknown statuses", func(t *testing.T) {
+		svc := NewWorkflowService(new(MockMemoryStore))
+
+		_, err := svc.UpdateRun(ctx, "run1", models.WorkflowRunUpdate{Status: "paused"})
+
+		assert.ErrorIs(t, err, ErrInvalidInput)
+	})
+}
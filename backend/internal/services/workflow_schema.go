@@ -0,0 +1,162 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// schemaURL names the schema being compiled in errors and $ids.
+const schemaURL = "urn:evolutionary-mcp:workflow-schema"
+
+// ValidatePayload checks payload against the input or output schema of a
+// version of a workflow of the current tenant. A version of 0 selects the
+// latest version. A workflow without that schema accepts any payload.
+func (s *WorkflowService) ValidatePayload(ctx context.Context, workflowID string, version int, schema string, payload interface{}) (*models.SchemaValidation, error) {
+	if schema != models.WorkflowSchemaInput && schema != models.WorkflowSchemaOutput {
+		return nil, fmt.Errorf("%w: schema must be %q or %q", ErrInvalidInput, models.WorkflowSchemaInput, models.WorkflowSchemaOutput)
+	}
+	workflow, err := s.GetWorkflowVersion(ctx, workflowID, version)
+	if err != nil {
+		return nil, err
+	}
+	return validatePayload(workflow, schema, payload)
+}
+
+// validatePayload checks payload against the input or output schema of
+// workflow.
+func validatePayload(workflow *models.Workflow, schema string, payload interface{}) (*models.SchemaValidation, error) {
+	definition := workflow.InputSchema
+	if schema == models.WorkflowSchemaOutput {
+		definition = workflow.OutputSchema
+	}
+	result := &models.SchemaValidation{
+		WorkflowID: workflow.WorkflowID,
+		Version:    workflow.Version,
+		Schema:     schema,
+		Valid:      true,
+		Errors:     make([]models.SchemaError, 0),
+	}
+	if definition == nil {
+		return result, nil
+	}
+
+	compiled, err := compileSchema(definition)
+	if err != nil {
+		return nil, fmt.Errorf("%s schema of version %d of workflow %s is not a valid JSON Schema: %w", schema, workflow.Version, workflow.WorkflowID, err)
+	}
+	value, err := normalizeJSON(payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: payload is not JSON: %v", ErrInvalidInput, err)
+	}
+	if err := compiled.Validate(value); err != nil {
+		result.Valid = false
+		result.Errors = schemaErrors(err)
+	}
+	return result, nil
+}
+
+// validateWorkflowSchemas rejects a workflow whose input or output schema is
+// not a valid JSON Schema, listing every problem found.
+func validateWorkflowSchemas(workflow *models.Workflow) error {
+	for _, field := range []struct {
+		name   string
+		schema map[string]interface{}
+	}{
+		{"input_schema", workflow.InputSchema},
+		{"output_schema", workflow.OutputSchema},
+	} {
+		if field.schema == nil {
+			continue
+		}
+		if _, err := compileSchema(field.schema); err != nil {
+			problems := make([]string, 0)
+			for _, e := range schemaErrors(err) {
+				problems = append(problems, fmt.Sprintf("at %q: %s", "/"+field.name+e.Path, e.Message))
+			}
+			if len(problems) == 0 {
+				problems = append(problems, err.Error())
+			}
+			return fmt.Errorf("%w: %s is not a valid JSON Schema: %s", ErrInvalidInput, field.name, strings.Join(problems, "; "))
+		}
+	}
+	return nil
+}
+
+// compileSchema compiles a JSON Schema, which is read as draft 2020-12 unless
+// it declares otherwise. References may only point into the schema itself:
+// nothing is loaded from files or the network.
+func compileSchema(schema map[string]interface{}) (*jsonschema.Schema, error) {
+	doc, err := normalizeJSON(schema)
+	if err != nil {
+		return nil, err
+	}
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(jsonschema.SchemeURLLoader{})
+	if err := compiler.AddResource(schemaURL, doc); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(schemaURL)
+}
+
+// normalizeJSON converts a decoded JSON value, or a Go value built in code,
+// into the representation the validator expects.
+func normalizeJSON(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(data))
+}
+
+// schemaPrinter renders validation messages.
+var schemaPrinter = message.NewPrinter(language.English)
+
+// schemaErrors lists the individual failures of a validation, or of a schema
+// that does not match its metaschema, in the order they were found. Only the
+// innermost failures are listed: "allOf failed" says less than the keyword
+// inside it that failed.
+func schemaErrors(err error) []models.SchemaError {
+	var invalidSchema *jsonschema.SchemaValidationError
+	if errors.As(err, &invalidSchema) {
+		err = invalidSchema.Err
+	}
+	var validation *jsonschema.ValidationError
+	if !errors.As(err, &validation) {
+		return nil
+	}
+	return appendSchemaErrors(make([]models.SchemaError, 0), validation)
+}
+
+func appendSchemaErrors(result []models.SchemaError, e *jsonschema.ValidationError) []models.SchemaError {
+	if len(e.Causes) > 0 {
+		for _, cause := range e.Causes {
+			result = appendSchemaErrors(result, cause)
+		}
+		return result
+	}
+	_, fragment, _ := strings.Cut(e.SchemaURL, "#")
+	return append(result, models.SchemaError{
+		Path:       jsonPointer(e.InstanceLocation),
+		SchemaPath: fragment + jsonPointer(e.ErrorKind.KeywordPath()),
+		Message:    e.ErrorKind.LocalizedString(schemaPrinter),
+	})
+}
+
+// jsonPointer joins tokens into a JSON Pointer (RFC 6901).
+func jsonPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteString("/")
+		sb.WriteString(escapePointer(token))
+	}
+	return sb.String()
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowService_ValidatePayload(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewWorkflowService(mockStore)
+
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+	mockStore.On("GetWorkflowVersion", ctx, "test-tenant", "wf", 2).Return(&models.Workflow{
+		ID: "v2", WorkflowID: "wf", Version: 2, TenantID: "test-tenant",
+		OutputSchema: map[string]interface{}{
+			"type":     "object",
+			"required": []string{"summary", "score"},
+			"properties": map[string]interface{}{
+				"summary": map[string]interface{}{"type": "string"},
+				"score":   map[string]interface{}{"type": "number", "minimum": 0, "maximum": 1},
+			},
+		},
+	}, nil)
+
+	result, err := svc.ValidatePayload(ctx, "wf", 2, models.WorkflowSchemaOutput, map[string]interface{}{"summary": "ok", "score": 0.5})
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Errors)
+
+	result, err = svc.ValidatePayload(ctx, "wf", 2, models.WorkflowSchemaOutput, map[string]interface{}{"summary": 3, "score": 1.5})
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	paths := make([]string, len(result.Errors))
+	for i, e := range result.Errors {
+		paths[i] = e.Path + " " + e.SchemaPath
+		assert.NotEmpty(t, e.Message)
+	}
+	assert.ElementsMatch(t, []string{"/summary /properties/summary/type", "/score /properties/score/maximum"}, paths)
+
+	// The workflow declares no input schema, so any input is accepted.
+	result, err = svc.ValidatePayload(ctx, "wf", 2, models.WorkflowSchemaInput, "anything")
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+
+	_, err = svc.ValidatePayload(ctx, "wf", 2, "context", nil)
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
+
+func TestWorkflowService_Save_InvalidSchema(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewWorkflowService(mockStore)
+
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+	_, err := svc.Save(ctx, &models.Workflow{
+		Name:        "Broken",
+		InputSchema: map[string]interface{}{"type": "objekt", "properties": map[string]interface{}{"n": map[string]interface{}{"minimum": "zero"}}},
+	})
+
+	require.ErrorIs(t, err, ErrInvalidInput)
+	assert.Contains(t, err.Error(), "input_schema is not a valid JSON Schema")
+	assert.Contains(t, err.Error(), `"/input_schema/type"`)
+	assert.Contains(t, err.Error(), `"/input_schema/properties/n/minimum"`)
+	mockStore.AssertNotCalled(t, "CreateWorkflow", mock.Anything, mock.Anything)
+}
+
+func TestValidateWorkflowSchemas_NoExternalReferences(t *testing.T) {
+	err := validateWorkflowSchemas(&models.Workflow{
+		OutputSchema: map[string]interface{}{"$ref": "file:///etc/passwd"},
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/quota"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"fmt"
+)
+
+// WorkflowService reads workflow definitions and records proposed versions
+// for the current tenant.
+type WorkflowService struct {
+	store    repository.Repository
+	mlClient MLClient
+	quotas   *quota.Enforcer
+	events   EventPublisher
+}
+
+// NewWorkflowService creates a new WorkflowService.
+func NewWorkflowService(store repository.Repository) *WorkflowService {
+	return &WorkflowService{store: store}
+}
+
+// WithEmbeddings makes the service embed the grounding rules of imported
+// bundles with mlClient, within the embedding quotas of the tenant.
+func (s *WorkflowService) WithEmbeddings(mlClient MLClient, quotas *quota.Enforcer) *WorkflowService {
+	s.mlClient = mlClient
+	s.quotas = quotas
+	return s
+}
+
+// WithEvents makes the service publish proposed workflow versions to
+// publisher.
+func (s *WorkflowService) WithEvents(publisher EventPublisher) *WorkflowService {
+	s.events = publisher
+	return s
+}
+
+// ListWorkflows returns the latest version of every workflow of the tenant.
+func (s *WorkflowService) ListWorkflows(ctx context.Context) ([]*models.Workflow, error) {
+	if contextutil.GetTenant(ctx) == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	return s.store.ListWorkflows(ctx)
+}
+
+// GetWorkflowVersion returns one version of a workflow of the current
+// tenant. A version of 0 selects the latest version.
+func (s *WorkflowService) GetWorkflowVersion(ctx context.Context, workflowID string, version int) (*models.Workflow, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+
+	if version == 0 {
+		latest, err := s.store.ListWorkflows(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range latest {
+			if w.WorkflowID == workflowID {
+				return w, nil
+			}
+		}
+		return nil, fmt.Errorf("workflow %s: %w", workflowID, repository.ErrNotFound)
+	}
+
+	workflow, err := s.store.GetWorkflowVersion(ctx, tenantID, workflowID, version)
+	if err != nil {
+		return nil, err
+	}
+	if workflow.TenantID != tenantID {
+		return nil, fmt.Errorf("%w: workflow belongs to another tenant", ErrUnauthorized)
+	}
+	return workflow, nil
+}
+
+// GetWorkflowTree returns a workflow version with the elements nested under
+// it, recursively. Under each parent only the newest version of an element is
+// included, and siblings are ordered by position.
+func (s *WorkflowService) GetWorkflowTree(ctx context.Context, workflowID string, version int) (*models.WorkflowNode, error) {
+	root, err := s.GetWorkflowVersion(ctx, workflowID, version)
+	if err != nil {
+		return nil, err
+	}
+	subtree, err := s.store.GetWorkflowSubtree(ctx, root.TenantID, root.ID)
+	if err != nil {
+		return nil, err
+	}
+	return buildWorkflowTree(subtree), nil
+}
+
+// ProposeVersion records proposal as a new draft version of an existing
+// workflow. Fields left empty are carried over from the latest version.
+func (s *WorkflowService) ProposeVersion(ctx context.Context, proposal *models.Workflow) (*models.Workflow, error) {
+	if proposal.WorkflowID == "" {
+		return nil, fmt.Errorf("%w: workflow_id is required", ErrInvalidInput)
+	}
+	latest, err := s.GetWorkflowVersion(ctx, proposal.WorkflowID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	draft := &models.Workflow{
+		TenantID:     latest.TenantID,
+		WorkflowID:   latest.WorkflowID,
+		Name:         latest.Name,
+		Description:  latest.Description,
+		Status:       models.WorkflowStatusDraft,
+		ParentID:     latest.ParentID,
+		ElementType:  latest.ElementType,
+		InputSchema:  latest.InputSchema,
+		OutputSchema: latest.OutputSchema,
+		CreatedBy:    contextutil.GetUser(ctx),
+	}
+	if proposal.Name != "" {
+		draft.Name = proposal.Name
+	}
+	if proposal.Description != "" {
+		draft.Description = proposal.Description
+	}
+	if proposal.InputSchema != nil {
+		draft.InputSchema = proposal.InputSchema
+	}
+	if proposal.OutputSchema != nil {
+		draft.OutputSchema = proposal.OutputSchema
+	}
+
+	if err := validateWorkflowSchemas(draft); err != nil {
+		return nil, err
+	}
+	if err := s.store.CreateWorkflow(ctx, draft); err != nil {
+		return nil, fmt.Errorf("failed to create workflow version: %w", err)
+	}
+	s.publish(events.Created, draft)
+	// Subscribers of the previous version learn it is no longer the latest.
+	s.publish(events.Updated, latest)
+	return draft, nil
+}
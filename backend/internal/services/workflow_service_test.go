@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingPublisher struct {
+	events []events.Event
+}
+
+func (r *recordingPublisher) Publish(event events.Event) {
+	r.events = append(r.events, event)
+}
+
+func TestWorkflowService_GetWorkflowVersion_OtherTenant(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewWorkflowService(mockStore)
+
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+	mockStore.On("GetWorkflowVersion", ctx, "test-tenant", "wf-1", 2).Return(&models.Workflow{WorkflowID: "wf-1", Version: 2, TenantID: "other"}, nil)
+
+	_, err := svc.GetWorkflowVersion(ctx, "wf-1", 2)
+
+	assert.Error(t, err)
+	mockStore.AssertExpectations(t)
+}
+
+func TestWorkflowService_GetWorkflowTree(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewWorkflowService(mockStore)
+
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+	root := "v-root"
+	element := "v-element"
+	rootVersion := &models.Workflow{ID: root, WorkflowID: "wf", Version: 3, TenantID: "test-tenant"}
+	mockStore.On("ListWorkflows", ctx).Return([]*models.Workflow{
+		rootVersion,
+		{ID: "v-other", WorkflowID: "other", Version: 1, TenantID: "test-tenant"},
+	}, nil)
+	mockStore.On("GetWorkflowSubtree", ctx, "test-tenant", root).Return([]*models.Workflow{
+		rootVersion,
+		{ID: element, WorkflowID: "el", Version: 1, TenantID: "test-tenant", ParentID: &root},
+		{ID: "v-detail", WorkflowID: "de", Version: 1, TenantID: "test-tenant", ParentID: &element},
+	}, nil)
+
+	tree, err := svc.GetWorkflowTree(ctx, "wf", 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, root, tree.ID)
+	require.Len(t, tree.Children, 1)
+	assert.Equal(t, "el", tree.Children[0].WorkflowID)
+	require.Len(t, tree.Children[0].Children, 1)
+	assert.Equal(t, "de", tree.Children[0].Children[0].WorkflowID)
+	assert.Empty(t, tree.Children[0].Children[0].Children)
+}
+
+func TestWorkflowService_ProposeVersion(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	publisher := &recordingPublisher{}
+	svc := NewWorkflowService(mockStore).WithEvents(publisher)
+
+	ctx := contextutil.WithUser(contextutil.WithTenant(context.Background(), "test-tenant"), "agent-7")
+	latest := &models.Workflow{
+		ID: "v2", WorkflowID: "wf", Version: 2, TenantID: "test-tenant", Name: "Onboarding",
+		Status: models.WorkflowStatusPublished, ElementType: "workflow",
+		InputSchema: map[string]interface{}{"type": "object"},
+	}
+	mockStore.On("ListWorkflows", ctx).Return([]*models.Workflow{latest}, nil)
+	mockStore.On("CreateWorkflow", ctx, mock.MatchedBy(func(w *models.Workflow) bool {
+		return w.WorkflowID == "wf" && w.Name == "Onboarding" && w.Description == "clearer steps" &&
+			w.Status == models.WorkflowStatusDraft && w.CreatedBy == "agent-7" && w.InputSchema["type"] == "object"
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Workflow).Version = 3
+	}).Return(nil)
+
+	draft, err := svc.ProposeVersion(ctx, &models.Workflow{WorkflowID: "wf", Description: "clearer steps"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, draft.Version)
+	assert.Equal(t, []events.Event{
+		{Kind: events.Created, URI: "workflow://wf/v3", TenantID: "test-tenant"},
+		{Kind: events.Updated, URI: "workflow://wf/v2", TenantID: "test-tenant"},
+	}, publisher.events)
+	mockStore.AssertExpectations(t)
+
+	_, err = svc.ProposeVersion(ctx, &models.Workflow{})
+	assert.ErrorIs(t, err, ErrInvalidInput)
+
+	_, err = svc.ProposeVersion(ctx, &models.Workflow{WorkflowID: "missing"})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
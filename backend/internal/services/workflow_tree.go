@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+)
+
+// MoveElements rearranges workflow versions of the current tenant in one
+// transaction, e.g. everything the editor changed since it loaded a tree. The
+// children of a version are part of it, so the parents that lose, gain or
+// reorder children must be drafts, and so must every moved version. Only
+// draft siblings are renumbered; other versions never change.
+func (s *WorkflowService) MoveElements(ctx context.Context, moves []models.WorkflowMove) ([]*models.Workflow, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	if err := checkBatchSize(len(moves)); err != nil {
+		return nil, err
+	}
+
+	for i, move := range moves {
+		if move.ID == "" {
+			return nil, fmt.Errorf("%w: moves[%d] needs an id", ErrInvalidInput, i)
+		}
+		if move.Position < 0 {
+			return nil, fmt.Errorf("%w: moves[%d] has a negative position", ErrInvalidInput, i)
+		}
+		workflow, err := s.tenantWorkflow(ctx, move.ID)
+		if err != nil {
+			return nil, err
+		}
+		if workflow.Status != models.WorkflowStatusDraft {
+			return nil, fmt.Errorf("%w: version %d of workflow %s is %s and cannot be moved; clone it into a new version",
+				repository.ErrConflict, workflow.Version, workflow.WorkflowID, workflow.Status)
+		}
+		reparented := !sameParent(workflow.ParentID, move.ParentID)
+		parents := []*string{move.ParentID}
+		if reparented {
+			parents = append(parents, workflow.ParentID)
+		}
+		for _, parentID := range parents {
+			if parentID == nil {
+				continue
+			}
+			parent, err := s.tenantWorkflow(ctx, *parentID)
+			if err != nil {
+				return nil, err
+			}
+			if parent.Status != models.WorkflowStatusDraft {
+				return nil, fmt.Errorf("%w: version %d of workflow %s is %s and its elements can no longer be rearranged; clone it into a new version",
+					repository.ErrConflict, parent.Version, parent.WorkflowID, parent.Status)
+			}
+		}
+	}
+
+	if err := s.store.MoveWorkflowElements(ctx, tenantID, moves); err != nil {
+		return nil, err
+	}
+
+	moved := make([]*models.Workflow, 0, len(moves))
+	for _, move := range moves {
+		workflow, err := s.store.GetWorkflow(ctx, move.ID)
+		if err != nil {
+			return nil, err
+		}
+		moved = append(moved, workflow)
+		s.publish(events.Updated, workflow)
+	}
+	return moved, nil
+}
+
+// CloneSubtree copies the workflow version id and everything nested under it
+// into new draft versions, so a published tree can be edited without
+// changing it. The copies become the latest versions of their workflows.
+func (s *WorkflowService) CloneSubtree(ctx context.Context, id string) (*models.WorkflowNode, error) {
+	root, err := s.tenantWorkflow(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	originals, err := s.store.GetWorkflowSubtree(ctx, root.TenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	clones, err := s.store.CloneWorkflowSubtree(ctx, root.TenantID, id, contextutil.GetUser(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone workflow subtree: %w", err)
+	}
+	for _, clone := range clones {
+		s.publish(events.Created, clone)
+	}
+	for _, original := range originals {
+		if original.IsLatest {
+			s.publish(events.Updated, original)
+		}
+	}
+	return buildWorkflowTree(clones), nil
+}
+
+// tenantWorkflow returns the workflow version id if it belongs to the
+// current tenant.
+func (s *WorkflowService) tenantWorkflow(ctx context.Context, id string) (*models.Workflow, error) {
+	tenantID := contextutil.GetTenant(ctx)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: tenant_id missing from context", ErrUnauthorized)
+	}
+	workflow, err := s.store.GetWorkflow(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if workflow.TenantID != tenantID {
+		return nil, fmt.Errorf("%w: workflow belongs to another tenant", ErrUnauthorized)
+	}
+	return workflow, nil
+}
+
+func sameParent(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// buildWorkflowTree nests a subtree as returned by GetWorkflowSubtree, whose
+// first version is the root and whose parents precede their children.
+func buildWorkflowTree(subtree []*models.Workflow) *models.WorkflowNode {
+	nodes := make(map[string]*models.WorkflowNode, len(subtree))
+	var root *models.WorkflowNode
+	for i, w := range subtree {
+		node := &models.WorkflowNode{Workflow: *w, Children: make([]*models.WorkflowNode, 0)}
+		nodes[w.ID] = node
+		if i == 0 {
+			root = node
+			continue
+		}
+		if parent, ok := nodes[*w.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+	return root
+}
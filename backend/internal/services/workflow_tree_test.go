@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"evolutionary-mcp/backend/internal/contextutil"
+	"evolutionary-mcp/backend/internal/events"
+	"evolutionary-mcp/backend/internal/repository"
+	"evolutionary-mcp/backend/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowService_MoveElements(t *testing.T) {
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+	root := "v-root"
+	other := "v-other"
+	workflows := map[string]*models.Workflow{
+		root:        {ID: root, WorkflowID: "wf", Version: 1, TenantID: "test-tenant", Status: models.WorkflowStatusDraft},
+		other:       {ID: other, WorkflowID: "other", Version: 1, TenantID: "test-tenant", Status: models.WorkflowStatusDraft},
+		"v-draft":   {ID: "v-draft", WorkflowID: "el", Version: 1, TenantID: "test-tenant", Status: models.WorkflowStatusDraft, ParentID: &root},
+		"v-publish": {ID: "v-publish", WorkflowID: "pub", Version: 1, TenantID: "test-tenant", Status: models.WorkflowStatusPublished, ParentID: &root},
+		"v-foreign": {ID: "v-foreign", WorkflowID: "foreign", Version: 1, TenantID: "other-tenant", Status: models.WorkflowStatusDraft},
+	}
+
+	cases := []struct {
+		name string
+		move models.WorkflowMove
+		err  error
+	}{
+		{"reparent a draft", models.WorkflowMove{ID: "v-draft", ParentID: &other}, nil},
+		{"reorder a published element", models.WorkflowMove{ID: "v-publish", ParentID: &root, Position: 3}, repository.ErrConflict},
+		{"reparent a published element", models.WorkflowMove{ID: "v-publish", ParentID: &other}, repository.ErrConflict},
+		{"move to the top level", models.WorkflowMove{ID: "v-draft"}, nil},
+		{"negative position", models.WorkflowMove{ID: "v-draft", ParentID: &root, Position: -1}, ErrInvalidInput},
+		{"another tenant", models.WorkflowMove{ID: "v-foreign"}, ErrUnauthorized},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockStore := new(MockMemoryStore)
+			publisher := &recordingPublisher{}
+			svc := NewWorkflowService(mockStore).WithEvents(publisher)
+			for id, w := range workflows {
+				mockStore.On("GetWorkflow", ctx, id).Return(w, nil).Maybe()
+			}
+			mockStore.On("MoveWorkflowElements", ctx, "test-tenant", []models.WorkflowMove{tc.move}).Return(nil).Maybe()
+
+			moved, err := svc.MoveElements(ctx, []models.WorkflowMove{tc.move})
+
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				mockStore.AssertNotCalled(t, "MoveWorkflowElements", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, moved, 1)
+			assert.Len(t, publisher.events, 1)
+			mockStore.AssertCalled(t, "MoveWorkflowElements", ctx, "test-tenant", []models.WorkflowMove{tc.move})
+		})
+	}
+}
+
+func TestWorkflowService_MoveElements_PublishedParent(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	svc := NewWorkflowService(mockStore)
+
+	ctx := contextutil.WithTenant(context.Background(), "test-tenant")
+	parent := "v-parent"
+	mockStore.On("GetWorkflow", ctx, "v-element").
+		Return(&models.Workflow{ID: "v-element", WorkflowID: "el", Version: 1, TenantID: "test-tenant", Status: models.WorkflowStatusDraft, ParentID: &parent}, nil)
+	mockStore.On("GetWorkflow", ctx, parent).
+		Return(&models.Workflow{ID: parent, WorkflowID: "wf", Version: 2, TenantID: "test-tenant", Status: models.WorkflowStatusPublished}, nil)
+
+	_, err := svc.MoveElements(ctx, []models.WorkflowMove{{ID: "v-element", ParentID: &parent, Position: 1}})
+
+	assert.ErrorIs(t, err, repository.ErrConflict)
+	mockStore.AssertNotCalled(t, "MoveWorkflowElements", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWorkflowService_CloneSubtree(t *testing.T) {
+	mockStore := new(MockMemoryStore)
+	publisher := &recordingPublisher{}
+	svc := NewWorkflowService(mockStore).WithEvents(publisher)
+
+	ctx := contextutil.WithUser(contextutil.WithTenant(context.Background(), "test-tenant"), "editor@example.com")
+	root := &models.Workflow{ID: "v1", WorkflowID: "wf", Version: 1, TenantID: "test-tenant", IsLatest: true, Status: models.WorkflowStatusPublished}
+	mockStore.On("GetWorkflow", ctx, "v1").Return(root, nil)
+	mockStore.On("GetWorkflowSubtree", ctx, "test-tenant", "v1").Return([]*models.Workflow{
+		root,
+		{ID: "e1", WorkflowID: "el", Version: 1, TenantID: "test-tenant", IsLatest: true, ParentID: &root.ID},
+	}, nil)
+	cloneRoot := "v2"
+	mockStore.On("CloneWorkflowSubtree", ctx, "test-tenant", "v1", "editor@example.com").Return([]*models.Workflow{
+		{ID: cloneRoot, WorkflowID: "wf", Version: 2, TenantID: "test-tenant", IsLatest: true, Status: models.WorkflowStatusDraft},
+		{ID: "e2", WorkflowID: "el", Version: 2, TenantID: "test-tenant", IsLatest: true, Status: models.WorkflowStatusDraft, ParentID: &cloneRoot},
+	}, nil)
+
+	tree, err := svc.CloneSubtree(ctx, "v1")
+
+	require.NoError(t, err)
+	assert.Equal(t, cloneRoot, tree.ID)
+	require.Len(t, tree.Children, 1)
+	assert.Equal(t, "e2", tree.Children[0].ID)
+	assert.Equal(t, []events.Event{
+		{Kind: events.Created, URI: "workflow://wf/v2", TenantID: "test-tenant"},
+		{Kind: events.Created, URI: "workflow://el/v2", TenantID: "test-tenant"},
+		{Kind: events.Updated, URI: "workflow://wf/v1", TenantID: "test-tenant"},
+		{Kind: events.Updated, URI: "workflow://el/v1", TenantID: "test-tenant"},
+	}, publisher.events)
+}
@@ -0,0 +1,37 @@
+-- Row-level security as defense-in-depth for multi-tenancy.
+-- The backend sets app.tenant_id per transaction (set_config(..., true)) before
+-- every query. When the setting is missing current_setting() returns NULL or '',
+-- so every policy below evaluates to false and a missed WHERE tenant_id filter
+-- fails closed instead of returning another tenant's rows.
+--
+-- FORCE makes the policies apply to the table owner as well. Superusers and
+-- roles with BYPASSRLS still skip them, so production must connect as an
+-- ordinary application role.
+
+-- Memories
+ALTER TABLE memories ENABLE ROW LEVEL SECURITY;
+ALTER TABLE memories FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON memories;
+CREATE POLICY tenant_isolation ON memories
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+-- Workflows
+ALTER TABLE workflows ENABLE ROW LEVEL SECURITY;
+ALTER TABLE workflows FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON workflows;
+CREATE POLICY tenant_isolation ON workflows
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+-- Grounding Rules
+-- Global rules are readable by every tenant but only writable by their owner.
+ALTER TABLE grounding_rules ENABLE ROW LEVEL SECURITY;
+ALTER TABLE grounding_rules FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON grounding_rules;
+CREATE POLICY tenant_isolation ON grounding_rules
+    USING (tenant_id::text = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id::text = current_setting('app.tenant_id', true));
+DROP POLICY IF EXISTS global_read ON grounding_rules;
+CREATE POLICY global_read ON grounding_rules FOR SELECT
+    USING (is_global = TRUE);
@@ -0,0 +1,16 @@
+-- Tenant administration: lifecycle status and multiple email domains per tenant.
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'suspended'));
+
+-- Email domains that resolve to a tenant. tenants.domain stays the primary
+-- domain and is mirrored here so that lookups only consult this table.
+-- No row-level security: domains are resolved before a tenant is known.
+CREATE TABLE IF NOT EXISTS tenant_domains (
+    domain TEXT PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_tenant_domains_tenant ON tenant_domains(tenant_id);
+
+INSERT INTO tenant_domains (domain, tenant_id)
+SELECT domain, id FROM tenants
+ON CONFLICT (domain) DO NOTHING;
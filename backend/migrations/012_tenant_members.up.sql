@@ -0,0 +1,11 @@
+-- Explicit tenant membership. Users listed here resolve to the tenant before
+-- their email domain is consulted, which covers contractors with external
+-- addresses and users that belong to more than one tenant.
+-- No row-level security: memberships are resolved before a tenant is known.
+CREATE TABLE IF NOT EXISTS tenant_members (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, email)
+);
+CREATE INDEX IF NOT EXISTS idx_tenant_members_email ON tenant_members(email);
@@ -0,0 +1,6 @@
+-- Per-tenant quota overrides. NULL falls back to the server-wide default from
+-- the quotas configuration section; 0 means unlimited.
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS quota_max_memories INT CHECK (quota_max_memories >= 0);
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS quota_embeddings_per_minute INT CHECK (quota_embeddings_per_minute >= 0);
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS quota_rest_requests_per_second DOUBLE PRECISION CHECK (quota_rest_requests_per_second >= 0);
+ALTER TABLE tenants ADD COLUMN IF NOT EXISTS quota_mcp_requests_per_second DOUBLE PRECISION CHECK (quota_mcp_requests_per_second >= 0);
@@ -0,0 +1,38 @@
+-- Usage events backing GET /api/v1/tenant/stats.
+
+-- One row per recall: how long it took, which memories were returned and
+-- which grounding rules matched the query.
+CREATE TABLE IF NOT EXISTS recall_events (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    latency_ms DOUBLE PRECISION NOT NULL,
+    memory_ids UUID[] NOT NULL DEFAULT '{}',
+    grounding_rule_ids UUID[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_recall_events_tenant_time ON recall_events(tenant_id, created_at);
+
+-- One row per piece of feedback given on a memory.
+CREATE TABLE IF NOT EXISTS feedback_events (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    memory_id UUID NOT NULL,
+    confidence DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_feedback_events_tenant_time ON feedback_events(tenant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_feedback_events_memory ON feedback_events(memory_id);
+
+ALTER TABLE recall_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE recall_events FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON recall_events;
+CREATE POLICY tenant_isolation ON recall_events
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
+
+ALTER TABLE feedback_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE feedback_events FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON feedback_events;
+CREATE POLICY tenant_isolation ON feedback_events
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
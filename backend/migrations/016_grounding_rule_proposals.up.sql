@@ -0,0 +1,8 @@
+-- Grounding rules proposed by agents wait for a human to approve them.
+-- Only active rules are used at recall time.
+ALTER TABLE grounding_rules
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'active'
+        CHECK (status IN ('pending', 'active', 'rejected')),
+    ADD COLUMN IF NOT EXISTS proposed_by TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_grounding_rules_tenant_status ON grounding_rules(tenant_id, status);
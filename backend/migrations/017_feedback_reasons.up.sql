@@ -0,0 +1,8 @@
+-- Feedback can be a relative signal instead of an absolute confidence, and
+-- can say why it was given and which query surfaced the memory, so curators
+-- can review downvotes.
+ALTER TABLE feedback_events
+    ADD COLUMN IF NOT EXISTS signal TEXT
+        CHECK (signal IN ('helpful', 'not_helpful', 'incorrect', 'outdated')),
+    ADD COLUMN IF NOT EXISTS reason TEXT,
+    ADD COLUMN IF NOT EXISTS query TEXT;
@@ -0,0 +1,33 @@
+-- Workflow versions follow an explicit lifecycle:
+-- draft -> review -> published -> deprecated -> archived.
+-- The service layer enforces which transitions are allowed; the table only
+-- guards against unknown statuses.
+UPDATE workflows SET status = 'published' WHERE status = 'active';
+
+ALTER TABLE workflows DROP CONSTRAINT IF EXISTS workflows_status_check;
+ALTER TABLE workflows ADD CONSTRAINT workflows_status_check
+    CHECK (status IN ('draft', 'review', 'published', 'deprecated', 'archived'));
+
+-- One row per status change of a workflow version, with who made it.
+-- from_status is NULL for versions created directly in a status, e.g. by a
+-- rollback.
+CREATE TABLE IF NOT EXISTS workflow_transitions (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    version_id UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+    workflow_id UUID NOT NULL,
+    version INT NOT NULL,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    comment TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_workflow_transitions_workflow ON workflow_transitions(tenant_id, workflow_id, created_at);
+
+ALTER TABLE workflow_transitions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE workflow_transitions FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON workflow_transitions;
+CREATE POLICY tenant_isolation ON workflow_transitions
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
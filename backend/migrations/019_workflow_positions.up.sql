@@ -0,0 +1,5 @@
+-- Elements of a workflow are ordered among their siblings, so the editor can
+-- show and save them in the order the user arranged them.
+ALTER TABLE workflows ADD COLUMN IF NOT EXISTS position INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_workflows_parent_position ON workflows(parent_id, position);
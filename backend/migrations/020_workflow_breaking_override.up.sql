@@ -0,0 +1,3 @@
+-- Publishing a version whose schemas break callers or consumers of the
+-- previously published version needs an explicit override, recorded here.
+ALTER TABLE workflow_transitions ADD COLUMN IF NOT EXISTS allow_breaking BOOLEAN NOT NULL DEFAULT FALSE;
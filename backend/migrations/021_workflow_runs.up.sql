@@ -0,0 +1,30 @@
+-- One row per execution of a workflow version by an agent. A run starts out
+-- running and ends succeeded, failed or cancelled; completed_at is set when
+-- it ends. The memories recalled and created during the run are listed so
+-- provenance can be traced back to it.
+CREATE TABLE IF NOT EXISTS workflow_runs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id TEXT NOT NULL,
+    version_id UUID NOT NULL REFERENCES workflows(id) ON DELETE CASCADE,
+    workflow_id UUID NOT NULL,
+    version INT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'running'
+        CHECK (status IN ('running', 'succeeded', 'failed', 'cancelled')),
+    inputs JSONB,
+    outputs JSONB,
+    error TEXT,
+    recalled_memory_ids UUID[] NOT NULL DEFAULT '{}',
+    created_memory_ids UUID[] NOT NULL DEFAULT '{}',
+    started_by TEXT NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ
+);
+CREATE INDEX IF NOT EXISTS idx_workflow_runs_workflow ON workflow_runs(tenant_id, workflow_id, started_at);
+
+ALTER TABLE workflow_runs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE workflow_runs FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON workflow_runs;
+CREATE POLICY tenant_isolation ON workflow_runs
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
@@ -0,0 +1,6 @@
+-- Edits check the revision they were based on, so concurrent edits of a
+-- grounding rule or a workflow version conflict instead of overwriting each
+-- other. Memories already carry a version. Workflows use revision because
+-- version numbers their versions.
+ALTER TABLE grounding_rules ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+ALTER TABLE workflows ADD COLUMN IF NOT EXISTS revision INT NOT NULL DEFAULT 1;
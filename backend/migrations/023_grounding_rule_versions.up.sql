@@ -0,0 +1,56 @@
+-- Grounding rules are versioned. Changes to a rule are proposed as new
+-- versions that wait for a curator to approve or reject them; approving a
+-- version copies it into grounding_rules, whose version column then names the
+-- version in effect. Rules are archived instead of deleted, so their history
+-- and the recalls that used them stay readable.
+ALTER TABLE grounding_rules DROP CONSTRAINT IF EXISTS grounding_rules_status_check;
+ALTER TABLE grounding_rules ADD CONSTRAINT grounding_rules_status_check
+    CHECK (status IN ('pending', 'active', 'rejected', 'archived'));
+
+-- One row per version of a rule. base_version is the version in effect when
+-- the change was proposed, and NULL for the first version.
+CREATE TABLE IF NOT EXISTS grounding_rule_versions (
+    id BIGSERIAL PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id),
+    rule_id UUID NOT NULL REFERENCES grounding_rules(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    base_version INT,
+    workflow_id UUID REFERENCES workflows(id),
+    name TEXT NOT NULL,
+    content TEXT NOT NULL,
+    embedding VECTOR(384),
+    is_global BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'approved', 'rejected')),
+    proposed_by TEXT,
+    reviewed_by TEXT,
+    review_comment TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMPTZ,
+    UNIQUE (rule_id, version)
+);
+CREATE INDEX IF NOT EXISTS idx_grounding_rule_versions_pending ON grounding_rule_versions(tenant_id, status);
+
+-- Existing rules start their history at the version they are at.
+INSERT INTO grounding_rule_versions (tenant_id, rule_id, version, workflow_id, name, content, embedding, is_global, status, proposed_by, created_at, reviewed_at)
+SELECT tenant_id, id, version, workflow_id, name, content, embedding, is_global,
+       CASE status WHEN 'active' THEN 'approved' ELSE status END,
+       proposed_by, updated_at, CASE WHEN status = 'pending' THEN NULL ELSE updated_at END
+FROM grounding_rules
+ON CONFLICT (rule_id, version) DO NOTHING;
+
+-- Versions of global rules are readable by every tenant, like the rules.
+ALTER TABLE grounding_rule_versions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE grounding_rule_versions FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON grounding_rule_versions;
+CREATE POLICY tenant_isolation ON grounding_rule_versions
+    USING (tenant_id::text = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id::text = current_setting('app.tenant_id', true));
+DROP POLICY IF EXISTS global_read ON grounding_rule_versions;
+CREATE POLICY global_read ON grounding_rule_versions FOR SELECT
+    USING (is_global = TRUE);
+
+-- Recalls record the version in effect of each matching rule, at the same
+-- position as its ID in grounding_rule_ids. Earlier recalls have none.
+ALTER TABLE recall_events ADD COLUMN IF NOT EXISTS grounding_rule_versions INT[] NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS idx_recall_events_memories ON recall_events USING GIN (memory_ids);
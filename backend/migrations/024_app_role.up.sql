@@ -0,0 +1,27 @@
+-- The application role the server connects as. Row-level security (010) does
+-- not apply to superusers or roles with BYPASSRLS, so the server must not use
+-- the role that owns the schema and runs these migrations.
+--
+-- The role is created without a password. Set one out of band, e.g.
+--   ALTER ROLE evolve_app PASSWORD '...';
+-- `make migrate` does this for local development.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'evolve_app') THEN
+        CREATE ROLE evolve_app LOGIN NOSUPERUSER NOCREATEDB NOCREATEROLE NOBYPASSRLS;
+    END IF;
+END
+$$;
+
+GRANT USAGE ON SCHEMA public TO evolve_app;
+GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO evolve_app;
+GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO evolve_app;
+
+-- Tables and sequences added by later migrations are granted as well.
+ALTER DEFAULT PRIVILEGES IN SCHEMA public
+    GRANT SELECT, INSERT, UPDATE, DELETE ON TABLES TO evolve_app;
+ALTER DEFAULT PRIVILEGES IN SCHEMA public
+    GRANT USAGE, SELECT ON SEQUENCES TO evolve_app;
+
+-- The migration bookkeeping is not the application's to change.
+REVOKE ALL ON TABLE schema_migrations FROM evolve_app;
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// GroundingRuleVersion is one version of a grounding rule. Changes to a rule
+// are proposed as pending versions; approving one puts it into effect.
+type GroundingRuleVersion struct {
+	ID            int64      `json:"id"`
+	TenantID      string     `json:"tenant_id"`
+	RuleID        string     `json:"rule_id"`
+	Version       int        `json:"version"`
+	BaseVersion   int        `json:"base_version,omitempty"` // version in effect when proposed, 0 for the first
+	WorkflowID    *string    `json:"workflow_id,omitempty"`
+	Name          string     `json:"name"`
+	Content       string     `json:"content"`
+	Embedding     []float32  `json:"-"`
+	IsGlobal      bool       `json:"is_global"`
+	Status        string     `json:"status"`
+	ProposedBy    string     `json:"proposed_by,omitempty"`
+	ReviewedBy    string     `json:"reviewed_by,omitempty"`
+	ReviewComment string     `json:"review_comment,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// Grounding rule version statuses.
+const (
+	GroundingRuleVersionPending  = "pending"
+	GroundingRuleVersionApproved = "approved"
+	GroundingRuleVersionRejected = "rejected"
+)
+
+// RecalledGroundingRule is a grounding rule that matched a recall, as it read
+// in the version then in effect. Version is 0 for recalls recorded before
+// rules were versioned.
+type RecalledGroundingRule struct {
+	RuleID  string `json:"rule_id"`
+	Version int    `json:"version,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// MemoryRecall is a recall that returned a memory, with the grounding rules
+// that matched it.
+type MemoryRecall struct {
+	RecalledAt     time.Time               `json:"recalled_at"`
+	LatencyMs      float64                 `json:"latency_ms"`
+	GroundingRules []RecalledGroundingRule `json:"grounding_rules"`
+}
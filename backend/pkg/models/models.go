@@ -15,10 +15,24 @@ type GroundingRule struct {
 	Content    string    `json:"content"`
 	Embedding  []float32 `json:"-"` // Not exposed in JSON, used for vector search
 	IsGlobal   bool      `json:"is_global"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	Status     string    `json:"status"`
+	ProposedBy string    `json:"proposed_by,omitempty"` // Set when an agent proposed the rule
+	Version    int       `json:"version"`               // The version in effect
+	// PendingVersions counts the proposed changes awaiting review.
+	PendingVersions int       `json:"pending_versions"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
+// Grounding rule statuses. Rules proposed by agents start out pending, and
+// deleted rules are archived.
+const (
+	GroundingRuleStatusPending  = "pending"
+	GroundingRuleStatusActive   = "active"
+	GroundingRuleStatusRejected = "rejected"
+	GroundingRuleStatusArchived = "archived"
+)
+
 // HealthStatus represents service health
 type HealthStatus struct {
 	Status    string            `json:"status"`
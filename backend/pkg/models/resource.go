@@ -0,0 +1,26 @@
+package models
+
+import "fmt"
+
+// URIs under which memories, grounding rules and workflow versions are
+// exposed as MCP resources.
+const (
+	MemoryURIScheme        = "memory"
+	GroundingRuleURIScheme = "grounding"
+	WorkflowURIScheme      = "workflow"
+)
+
+// MemoryURI returns the resource URI of a memory.
+func MemoryURI(id string) string {
+	return fmt.Sprintf("%s://%s", MemoryURIScheme, id)
+}
+
+// GroundingRuleURI returns the resource URI of a grounding rule.
+func GroundingRuleURI(id string) string {
+	return fmt.Sprintf("%s://%s", GroundingRuleURIScheme, id)
+}
+
+// WorkflowURI returns the resource URI of one version of a workflow.
+func WorkflowURI(workflowID string, version int) string {
+	return fmt.Sprintf("%s://%s/v%d", WorkflowURIScheme, workflowID, version)
+}
@@ -0,0 +1,177 @@
+package models
+
+import (
+	"time"
+)
+
+// RecallEvent records a single recall for usage analytics.
+// GroundingRuleVersions holds the version in effect of each rule in
+// GroundingRuleIDs, at the same index.
+type RecallEvent struct {
+	TenantID              string    `json:"tenant_id"`
+	LatencyMs             float64   `json:"latency_ms"`
+	MemoryIDs             []string  `json:"memory_ids"`
+	GroundingRuleIDs      []string  `json:"grounding_rule_ids"`
+	GroundingRuleVersions []int     `json:"grounding_rule_versions"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+// FeedbackSignal is a relative judgement of a memory, for agents that cannot
+// tell what its confidence should be.
+type FeedbackSignal string
+
+const (
+	FeedbackSignalHelpful    FeedbackSignal = "helpful"
+	FeedbackSignalNotHelpful FeedbackSignal = "not_helpful"
+	FeedbackSignalIncorrect  FeedbackSignal = "incorrect"
+	FeedbackSignalOutdated   FeedbackSignal = "outdated"
+)
+
+// FeedbackEvent records a single piece of feedback given on a memory.
+// Confidence is the confidence the memory was left with.
+type FeedbackEvent struct {
+	TenantID   string         `json:"tenant_id"`
+	MemoryID   string         `json:"memory_id"`
+	Confidence float64        `json:"confidence"`
+	Signal     FeedbackSignal `json:"signal,omitempty"`
+	Reason     string         `json:"reason,omitempty"`
+	Query      string         `json:"query,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// TenantStats summarises how a tenant has used the service over a window of days.
+type TenantStats struct {
+	TenantID            string             `json:"tenant_id"`
+	WindowDays          int                `json:"window_days"`
+	GeneratedAt         time.Time          `json:"generated_at"`
+	TotalMemories       int                `json:"total_memories"`
+	ConfidenceBands     []ConfidenceBand   `json:"confidence_bands"`
+	FeedbackOverTime    []DailyCount       `json:"feedback_over_time"`
+	TopRecalledMemories []RecalledMemory   `json:"top_recalled_memories"`
+	RecallLatency       LatencyPercentiles `json:"recall_latency"`
+	Grounding           GroundingStats     `json:"grounding"`
+	WorkflowUsage       []WorkflowUsage    `json:"workflow_usage"`
+}
+
+// ConfidenceBand counts memories whose confidence lies in [Min, Max).
+// The top band includes Max.
+type ConfidenceBand struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int     `json:"count"`
+}
+
+// DailyCount is the number of events on a UTC day.
+type DailyCount struct {
+	Day   time.Time `json:"day"`
+	Count int       `json:"count"`
+}
+
+// RecalledMemory is a memory together with how often it was recalled.
+type RecalledMemory struct {
+	MemoryID   string  `json:"memory_id"`
+	Content    string  `json:"content"`
+	Confidence float64 `json:"confidence"`
+	Recalls    int     `json:"recalls"`
+}
+
+// LatencyPercentiles summarises recall latency in milliseconds.
+type LatencyPercentiles struct {
+	Count int     `json:"count"`
+	P50   float64 `json:"p50_ms"`
+	P90   float64 `json:"p90_ms"`
+	P99   float64 `json:"p99_ms"`
+	Max   float64 `json:"max_ms"`
+}
+
+// GroundingStats reports how often recalls matched grounding rules.
+type GroundingStats struct {
+	Recalls          int                 `json:"recalls"`
+	RecallsWithRules int                 `json:"recalls_with_rules"`
+	HitRate          float64             `json:"hit_rate"`
+	Rules            []GroundingRuleHits `json:"rules"`
+}
+
+// GroundingRuleHits is the number of recalls a grounding rule matched.
+type GroundingRuleHits struct {
+	RuleID  string  `json:"rule_id"`
+	Name    string  `json:"name"`
+	Hits    int     `json:"hits"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// WorkflowUsage counts the memories linked to a workflow and how often they
+// were recalled.
+type WorkflowUsage struct {
+	WorkflowID string `json:"workflow_id"`
+	Name       string `json:"name"`
+	Memories   int    `json:"memories"`
+	Recalls    int    `json:"recalls"`
+}
+
+// Verdicts of a VersionComparison.
+const (
+	VerdictBetter           = "better"
+	VerdictWorse            = "worse"
+	VerdictMixed            = "mixed"             // some metrics improved, others regressed
+	VerdictNoDifference     = "no_difference"     // no metric changed significantly
+	VerdictInsufficientData = "insufficient_data" // no metric has enough samples on both sides
+)
+
+// Metrics compared between workflow versions. Higher is better for each.
+const (
+	MetricRunSuccessRate       = "run_success_rate"       // succeeded runs of those that succeeded or failed
+	MetricPositiveFeedbackRate = "positive_feedback_rate" // helpful signals of all signals on the version's memories
+	MetricAverageConfidence    = "average_confidence"     // of the memories linked to the version
+)
+
+// WorkflowPerformance reports how each version of a workflow performed over a
+// window of days, and how each compares with the version before it.
+type WorkflowPerformance struct {
+	WorkflowID  string               `json:"workflow_id"`
+	WindowDays  int                  `json:"window_days"`
+	GeneratedAt time.Time            `json:"generated_at"`
+	Versions    []VersionPerformance `json:"versions"` // oldest first
+}
+
+// VersionPerformance aggregates the memories, feedback and runs of one
+// workflow version. Memory confidence covers every memory linked to the
+// version; feedback and runs only those in the window.
+type VersionPerformance struct {
+	VersionID           string             `json:"version_id"`
+	Version             int                `json:"version"`
+	Status              string             `json:"status"`
+	Memories            int                `json:"memories"`
+	AverageConfidence   float64            `json:"average_confidence"`
+	ConfidenceStdDev    float64            `json:"confidence_stddev"`
+	Feedback            int                `json:"feedback"`
+	PositiveFeedback    int                `json:"positive_feedback"` // helpful signals
+	NegativeFeedback    int                `json:"negative_feedback"` // not helpful, incorrect and outdated signals
+	Runs                int                `json:"runs"`
+	SucceededRuns       int                `json:"succeeded_runs"`
+	FailedRuns          int                `json:"failed_runs"`
+	CancelledRuns       int                `json:"cancelled_runs"`
+	MedianRunDurationMs float64            `json:"median_run_duration_ms"` // of succeeded runs
+	Comparison          *VersionComparison `json:"comparison,omitempty"`   // absent for the first version
+}
+
+// VersionComparison compares a workflow version with the version before it.
+type VersionComparison struct {
+	BaselineVersion int                `json:"baseline_version"`
+	Verdict         string             `json:"verdict"`
+	Metrics         []MetricComparison `json:"metrics"`
+}
+
+// MetricComparison is the change of one metric from the baseline version.
+// Significant is set when both versions have enough samples and the change
+// is significant at the 95% level; only significant changes count towards
+// the verdict.
+type MetricComparison struct {
+	Metric          string  `json:"metric"`
+	Baseline        float64 `json:"baseline"`
+	Current         float64 `json:"current"`
+	Delta           float64 `json:"delta"`
+	BaselineSamples int     `json:"baseline_samples"`
+	CurrentSamples  int     `json:"current_samples"`
+	Significant     bool    `json:"significant"`
+}
@@ -4,12 +4,50 @@ import (
 	"time"
 )
 
+// Tenant lifecycle states.
+const (
+	TenantStatusActive    = "active"
+	TenantStatusSuspended = "suspended"
+)
+
 type Tenant struct {
-	ID         string    `json:"id"`
-	Name       string    `json:"name"`
-	Domain     string    `json:"domain"`
-	LogoSVG    string    `json:"logo_svg,omitempty"`
-	BrandTitle string    `json:"brand_title,omitempty"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
-}
\ No newline at end of file
+	ID         string       `json:"id"`
+	Name       string       `json:"name"`
+	Domain     string       `json:"domain"`            // Primary email domain
+	Domains    []string     `json:"domains,omitempty"` // All email domains resolving to this tenant, including Domain
+	LogoSVG    string       `json:"logo_svg,omitempty"`
+	BrandTitle string       `json:"brand_title,omitempty"`
+	Status     string       `json:"status"`
+	Quotas     TenantQuotas `json:"quotas"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
+}
+
+// TenantQuotas overrides the server-wide quota defaults for a tenant. A nil
+// field falls back to the default; zero means unlimited.
+type TenantQuotas struct {
+	MaxMemories           *int     `json:"max_memories,omitempty"`
+	EmbeddingsPerMinute   *int     `json:"embeddings_per_minute,omitempty"`
+	RESTRequestsPerSecond *float64 `json:"rest_requests_per_second,omitempty"`
+	MCPRequestsPerSecond  *float64 `json:"mcp_requests_per_second,omitempty"`
+}
+
+// TenantMember explicitly grants a user access to a tenant regardless of their
+// email domain.
+type TenantMember struct {
+	TenantID  string    `json:"tenant_id"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// APIKey authenticates a non-interactive client to a tenant. The secret is
+// only returned once, when the key is created.
+type APIKey struct {
+	ID        string     `json:"id"`
+	TenantID  string     `json:"tenant_id"`
+	Name      string     `json:"name"`
+	Prefix    string     `json:"prefix"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
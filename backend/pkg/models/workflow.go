@@ -4,18 +4,30 @@ import (
 	"time"
 )
 
+// Workflow statuses, in lifecycle order. Only drafts can be edited; every
+// later status is immutable and changes need a new version.
+const (
+	WorkflowStatusDraft      = "draft"
+	WorkflowStatusReview     = "review"
+	WorkflowStatusPublished  = "published"
+	WorkflowStatusDeprecated = "deprecated"
+	WorkflowStatusArchived   = "archived"
+)
+
 // Workflow represents the evolutionary definition of a logic pipeline.
 type Workflow struct {
 	ID           string                 `json:"id"`          // Unique Version ID
 	TenantID     string                 `json:"tenant_id"`   // Multi-tenancy isolation
 	WorkflowID   string                 `json:"workflow_id"` // Stable Concept ID
 	Version      int                    `json:"version"`
+	Revision     int                    `json:"revision"` // Incremented by every edit of this version
 	IsLatest     bool                   `json:"is_latest"`
 	Name         string                 `json:"name"`
 	Description  string                 `json:"description"`
 	Status       string                 `json:"status"`
-	ParentID     *string                `json:"parent_id,omitempty"`   // Hierarchy: points to ID (version) of parent
-	ElementType  string                 `json:"element_type"`          // workflow, element, detail
+	ParentID     *string                `json:"parent_id,omitempty"` // Hierarchy: points to ID (version) of parent
+	Position     int                    `json:"position"`            // Order among the siblings under ParentID
+	ElementType  string                 `json:"element_type"`        // workflow, element, detail
 	InputSchema  map[string]interface{} `json:"input_schema"`
 	OutputSchema map[string]interface{} `json:"output_schema"`
 	CreatedBy    string                 `json:"created_by"`
@@ -24,4 +36,156 @@ type Workflow struct {
 
 	// UI/API Control fields (not in DB table)
 	SaveAsNewVersion bool `json:"save_as_new_version,omitempty"`
-}
\ No newline at end of file
+}
+
+// WorkflowTransition records a status change of a workflow version and who
+// made it.
+type WorkflowTransition struct {
+	ID            int64     `json:"id"`
+	TenantID      string    `json:"tenant_id"`
+	VersionID     string    `json:"version_id"` // ID of the workflow version
+	WorkflowID    string    `json:"workflow_id"`
+	Version       int       `json:"version"`
+	FromStatus    string    `json:"from_status,omitempty"` // empty for versions created in ToStatus
+	ToStatus      string    `json:"to_status"`
+	Actor         string    `json:"actor"`
+	Comment       string    `json:"comment,omitempty"`
+	AllowBreaking bool      `json:"allow_breaking,omitempty"` // published despite breaking schema changes
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// WorkflowNode is a workflow version together with the elements nested under it.
+type WorkflowNode struct {
+	Workflow
+	Children []*WorkflowNode `json:"children"`
+}
+
+// The schemas of a workflow a payload can be validated against.
+const (
+	WorkflowSchemaInput  = "input"
+	WorkflowSchemaOutput = "output"
+)
+
+// SchemaError is one reason a value does not match a JSON Schema.
+type SchemaError struct {
+	Path       string `json:"path"`        // JSON Pointer into the value
+	SchemaPath string `json:"schema_path"` // JSON Pointer to the failing keyword of the schema
+	Message    string `json:"message"`
+}
+
+// SchemaValidation is the result of validating a payload against the input or
+// output schema of a workflow version.
+type SchemaValidation struct {
+	WorkflowID string        `json:"workflow_id"`
+	Version    int           `json:"version"`
+	Schema     string        `json:"schema"` // input or output
+	Valid      bool          `json:"valid"`
+	Errors     []SchemaError `json:"errors"`
+}
+
+// WorkflowMove places a workflow version under ParentID at Position among
+// its siblings. A nil ParentID makes it a top-level workflow.
+type WorkflowMove struct {
+	ID       string  `json:"id"`
+	ParentID *string `json:"parent_id"`
+	Position int     `json:"position"`
+}
+
+// Kinds of change in a WorkflowDiff.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// WorkflowChange is one difference between two workflow versions. Path is a
+// JSON Pointer into the workflow, e.g. /input_schema/properties/name/type.
+type WorkflowChange struct {
+	Path string      `json:"path"`
+	Kind string      `json:"kind"`
+	From interface{} `json:"from,omitempty"`
+	To   interface{} `json:"to,omitempty"`
+}
+
+// WorkflowDiff lists what changed from one version of a workflow to another.
+type WorkflowDiff struct {
+	WorkflowID  string           `json:"workflow_id"`
+	FromVersion int              `json:"from_version"`
+	ToVersion   int              `json:"to_version"`
+	Changes     []WorkflowChange `json:"changes"`
+}
+
+// Compatibility of a schema change between two workflow versions. A
+// backward compatible schema still accepts everything the old one accepted;
+// a forward compatible schema accepts nothing the old one rejected. Full
+// compatibility is both, none is neither.
+const (
+	CompatibilityFull     = "full"
+	CompatibilityBackward = "backward"
+	CompatibilityForward  = "forward"
+	CompatibilityNone     = "none"
+)
+
+// SchemaChange is one change to the input or output schema of a workflow.
+// Input schemas must stay backward compatible, so callers keep working;
+// output schemas must stay forward compatible, so consumers keep working.
+// Changes that do not are breaking.
+type SchemaChange struct {
+	Path          string `json:"path"` // JSON Pointer into the workflow, e.g. /input_schema/properties/email
+	Description   string `json:"description"`
+	Compatibility string `json:"compatibility"`
+	Breaking      bool   `json:"breaking"`
+}
+
+// CompatibilityReport classifies the schema changes from one version of a
+// workflow to another.
+type CompatibilityReport struct {
+	WorkflowID    string         `json:"workflow_id"`
+	FromVersion   int            `json:"from_version"`
+	ToVersion     int            `json:"to_version"`
+	Compatibility string         `json:"compatibility"` // of all changes together
+	Breaking      bool           `json:"breaking"`
+	Changes       []SchemaChange `json:"changes"`
+}
+
+// Workflow run statuses. A run starts out running and ends in one of the
+// other statuses, after which it can no longer change.
+const (
+	WorkflowRunStatusRunning   = "running"
+	WorkflowRunStatusSucceeded = "succeeded"
+	WorkflowRunStatusFailed    = "failed"
+	WorkflowRunStatusCancelled = "cancelled"
+)
+
+// WorkflowRun records an agent executing a workflow version: what it was
+// given, what it produced, how long it took and which memories it recalled
+// and created on the way.
+type WorkflowRun struct {
+	ID                string      `json:"id"`
+	TenantID          string      `json:"tenant_id"`
+	VersionID         string      `json:"version_id"` // ID of the workflow version
+	WorkflowID        string      `json:"workflow_id"`
+	Version           int         `json:"version"`
+	Status            string      `json:"status"`
+	Inputs            interface{} `json:"inputs,omitempty"`
+	Outputs           interface{} `json:"outputs,omitempty"`
+	Error             string      `json:"error,omitempty"` // why a run failed or was cancelled
+	RecalledMemoryIDs []string    `json:"recalled_memory_ids"`
+	CreatedMemoryIDs  []string    `json:"created_memory_ids"`
+	StartedBy         string      `json:"started_by"`
+	StartedAt         time.Time   `json:"started_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
+	CompletedAt       *time.Time  `json:"completed_at,omitempty"`
+	DurationMs        *int64      `json:"duration_ms,omitempty"` // set once the run has completed
+}
+
+// WorkflowRunUpdate reports the progress of a running workflow run. Memory
+// IDs are added to those already recorded; Outputs, when set, replaces the
+// outputs recorded so far. A terminal Status completes the run.
+type WorkflowRunUpdate struct {
+	Status            string
+	Outputs           interface{}
+	Error             string
+	RecalledMemoryIDs []string
+	CreatedMemoryIDs  []string
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// WorkflowBundleFormat identifies the format of a workflow bundle, so that
+// imports can reject files they do not understand.
+const WorkflowBundleFormat = "evolutionary-mcp/workflow-bundle/v1"
+
+// WorkflowBundle is a portable definition of a workflow that moves it between
+// environments: its versions, the elements nested under them and the
+// grounding rules linked to them. IDs are those of the exporting environment
+// and only serve to link the entries of the bundle to each other.
+type WorkflowBundle struct {
+	Format         string                 `json:"format" yaml:"format"`
+	ExportedAt     time.Time              `json:"exported_at" yaml:"exported_at"`
+	WorkflowID     string                 `json:"workflow_id" yaml:"workflow_id"` // the exported workflow
+	Workflows      []BundledWorkflow      `json:"workflows" yaml:"workflows"`
+	GroundingRules []BundledGroundingRule `json:"grounding_rules" yaml:"grounding_rules"`
+}
+
+// BundledWorkflow is one workflow version in a WorkflowBundle.
+type BundledWorkflow struct {
+	ID           string                 `json:"id" yaml:"id"`
+	WorkflowID   string                 `json:"workflow_id" yaml:"workflow_id"`
+	Version      int                    `json:"version" yaml:"version"`
+	ParentID     string                 `json:"parent_id,omitempty" yaml:"parent_id,omitempty"` // only set when the parent is in the bundle
+	Position     int                    `json:"position" yaml:"position"`
+	Name         string                 `json:"name" yaml:"name"`
+	Description  string                 `json:"description,omitempty" yaml:"description,omitempty"`
+	Status       string                 `json:"status" yaml:"status"`
+	ElementType  string                 `json:"element_type" yaml:"element_type"`
+	InputSchema  map[string]interface{} `json:"input_schema,omitempty" yaml:"input_schema,omitempty"`
+	OutputSchema map[string]interface{} `json:"output_schema,omitempty" yaml:"output_schema,omitempty"`
+}
+
+// BundledGroundingRule is a grounding rule in a WorkflowBundle, linked to one
+// of its workflow versions.
+type BundledGroundingRule struct {
+	ID         string `json:"id" yaml:"id"`
+	WorkflowID string `json:"workflow_id" yaml:"workflow_id"` // ID of a version in the bundle
+	Name       string `json:"name" yaml:"name"`
+	Content    string `json:"content" yaml:"content"`
+	Status     string `json:"status" yaml:"status"`
+}
+
+// WorkflowImport is the outcome of importing a WorkflowBundle. Every entry
+// gets a new ID; IDs maps the workflow, version and rule IDs of the bundle
+// to them.
+type WorkflowImport struct {
+	WorkflowID     string            `json:"workflow_id"` // new ID of the bundle's workflow
+	IDs            map[string]string `json:"ids"`
+	Workflows      []*Workflow       `json:"workflows"`
+	GroundingRules []*GroundingRule  `json:"grounding_rules"`
+}
@@ -6,7 +6,7 @@ export interface Workflow {
   tenant_id: string;
   name: string;
   description: string;
-  status: "draft" | "active" | "archived";
+  status: "draft" | "review" | "published" | "deprecated" | "archived";
   version: number;
   is_latest: boolean;
   input_schema: Record<string, any>;
@@ -1,5 +1,5 @@
 import apiClient from './client';
-import { GroundingRule } from '../types';
+import { GroundingRule, GroundingRuleVersion } from '../types';
 
 export const getGroundingRules = async (): Promise<GroundingRule[]> => {
   const response = await apiClient.get<GroundingRule[]>('/grounding');
@@ -11,11 +11,36 @@ export const createGroundingRule = async (rule: Partial<GroundingRule>): Promise
   return response.data;
 };
 
-export const updateGroundingRule = async (id: string, rule: Partial<GroundingRule>): Promise<GroundingRule> => {
-  const response = await apiClient.put<GroundingRule>(`/grounding/${id}`, rule);
+/**
+ * Proposes a change to a rule. The change becomes a pending version that
+ * takes effect once a curator approves it. When the rule carries the version
+ * it was loaded at, the proposal fails with 412 if someone else changed the
+ * rule since.
+ */
+export const updateGroundingRule = async (id: string, rule: Partial<GroundingRule>): Promise<GroundingRuleVersion> => {
+  const response = await apiClient.put<GroundingRuleVersion>(`/grounding/${id}`, rule, {
+    headers: rule.version ? { 'If-Match': `"${rule.version}"` } : undefined,
+  });
   return response.data;
 };
 
+export const getGroundingRuleVersions = async (id: string): Promise<GroundingRuleVersion[]> => {
+  const response = await apiClient.get<GroundingRuleVersion[]>(`/grounding/${id}/versions`);
+  return response.data || [];
+};
+
+export const approveGroundingRuleVersion = async (id: string, version: number, comment?: string): Promise<GroundingRule> => {
+  const response = await apiClient.post<GroundingRule>(`/grounding/${id}/versions/${version}/approve`, { comment });
+  return response.data;
+};
+
+export const rejectGroundingRuleVersion = async (id: string, version: number, comment?: string): Promise<GroundingRuleVersion> => {
+  const response = await apiClient.post<GroundingRuleVersion>(`/grounding/${id}/versions/${version}/reject`, { comment });
+  return response.data;
+};
+
+// Archives a rule. Archived rules no longer ground recalls but keep their
+// version history.
 export const deleteGroundingRule = async (id: string): Promise<void> => {
   await apiClient.delete(`/grounding/${id}`);
 };
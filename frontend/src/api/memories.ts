@@ -1,5 +1,5 @@
 import apiClient from './client';
-import { Memory, MemoryFeedback } from '../types';
+import { Memory, MemoryFeedback, MemoryRecall } from '../types';
 
 export const getMemories = async (): Promise<Memory[]> => {
   const response = await apiClient.get<Memory[]>('/memories');
@@ -14,3 +14,8 @@ export const searchMemories = async (query: string): Promise<Memory[]> => {
 export const giveMemoryFeedback = async (id: string, feedback: MemoryFeedback): Promise<void> => {
   await apiClient.post(`/memories/${id}/feedback`, feedback);
 };
+
+export const getMemoryRecalls = async (id: string): Promise<MemoryRecall[]> => {
+  const response = await apiClient.get<MemoryRecall[]>(`/memories/${id}/recalls`);
+  return response.data || [];
+};
@@ -0,0 +1,7 @@
+import apiClient from './client';
+import { TenantStats } from '../types';
+
+export const getTenantStats = async (days?: number): Promise<TenantStats> => {
+  const response = await apiClient.get<TenantStats>('/tenant/stats', { params: { days } });
+  return response.data;
+};
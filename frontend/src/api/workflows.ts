@@ -1,5 +1,5 @@
 import apiClient from './client';
-import { Workflow, HealthStatus, Tenant, WorkflowUpdatePayload } from '../types';
+import { Workflow, HealthStatus, Tenant, WorkflowUpdatePayload, WorkflowNode, WorkflowMove, WorkflowRun, WorkflowPerformance, WorkflowBundle, WorkflowImport } from '../types';
 
 /**
  * Retrieves the current tenant's branding configuration.
@@ -25,11 +25,81 @@ export const getWorkflow = async (id: string): Promise<Workflow> => {
   return response.data;
 };
 
+/**
+ * Retrieves a workflow version with all of its elements nested under it.
+ * Omitting the version loads the latest one.
+ */
+export const getWorkflowTree = async (workflowId: string, version?: number): Promise<WorkflowNode> => {
+  const response = await apiClient.get<WorkflowNode>(`/workflows/${workflowId}/tree`, {
+    params: version ? { version } : undefined,
+  });
+  return response.data;
+};
+
+/**
+ * Moves and reorders workflow elements in one transaction.
+ */
+export const moveWorkflowElements = async (moves: WorkflowMove[]): Promise<Workflow[]> => {
+  const response = await apiClient.post<Workflow[]>('/workflows/moves', { moves });
+  return response.data;
+};
+
+/**
+ * Clones a workflow version and everything nested under it into new drafts.
+ */
+export const cloneWorkflowSubtree = async (id: string): Promise<WorkflowNode> => {
+  const response = await apiClient.post<WorkflowNode>(`/workflows/${id}/clone`);
+  return response.data;
+};
+
+/**
+ * Retrieves the runs of every version of a workflow, newest first.
+ */
+export const getWorkflowRuns = async (workflowId: string): Promise<WorkflowRun[]> => {
+  const response = await apiClient.get<WorkflowRun[]>(`/workflows/${workflowId}/runs`);
+  return response.data || [];
+};
+
+/**
+ * Compares the versions of a workflow over the last `days` days.
+ */
+export const getWorkflowPerformance = async (workflowId: string, days?: number): Promise<WorkflowPerformance> => {
+  const response = await apiClient.get<WorkflowPerformance>(`/workflows/${workflowId}/performance`, {
+    params: days ? { days } : undefined,
+  });
+  return response.data;
+};
+
+/**
+ * Exports the latest version of a workflow, or all of them, as a bundle.
+ */
+export const exportWorkflow = async (workflowId: string, versions: 'latest' | 'all' = 'latest'): Promise<WorkflowBundle> => {
+  const response = await apiClient.get<WorkflowBundle>(`/workflows/${workflowId}/export`, {
+    params: { versions, format: 'json' },
+  });
+  return response.data;
+};
+
+/**
+ * Imports a bundle exported from another environment under new IDs.
+ */
+export const importWorkflow = async (bundle: WorkflowBundle | string): Promise<WorkflowImport> => {
+  const response = await apiClient.post<WorkflowImport>('/workflows/import', bundle, {
+    headers: typeof bundle === 'string' ? { 'Content-Type': 'application/yaml' } : undefined,
+  });
+  return response.data;
+};
+
 /**
  * Creates or updates a workflow (supports versioning via save_as_new_version flag).
+ * Edits of a version loaded at a known revision fail with 412 if someone else
+ * changed it since.
  */
 export const putWorkflow = async (workflow: WorkflowUpdatePayload): Promise<Workflow> => {
-  const response = await apiClient.put<Workflow>('/workflows', workflow);
+  const conditional = !workflow.save_as_new_version && workflow.revision;
+  const response = await apiClient.put<Workflow>('/workflows', workflow, {
+    headers: conditional ? { 'If-Match': `"${workflow.revision}"` } : undefined,
+  });
   return response.data;
 };
 
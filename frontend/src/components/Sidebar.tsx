@@ -56,7 +56,7 @@ const TreeNode: React.FC<TreeNodeProps> = ({ node, selectedId, onSelect, level }
                 
                 <span className="truncate flex-1 text-left">{node.name}</span>
                 
-                {node.status === 'active' && (
+                {node.status === 'published' && (
                     <span className="w-1.5 h-1.5 rounded-full bg-green-500"></span>
                 )}
             </button>
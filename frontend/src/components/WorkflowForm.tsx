@@ -56,7 +56,7 @@ const WorkflowForm: React.FC<WorkflowFormProps> = ({
   const handlePublishNewVersion = (e: React.FormEvent) => {
     e.preventDefault();
     if (validate()) {
-      onSave({ ...formData, save_as_new_version: true, status: 'active' });
+      onSave({ ...formData, save_as_new_version: true, status: 'draft' });
     }
   };
 
@@ -132,7 +132,9 @@ const WorkflowForm: React.FC<WorkflowFormProps> = ({
               className="w-full bg-bg-base border border-border-base rounded-lg px-4 py-2 focus:ring-2 focus:ring-primary outline-none transition-all"
             >
               <option value="draft">Draft</option>
-              <option value="active">Active</option>
+              <option value="review">In review</option>
+              <option value="published">Published</option>
+              <option value="deprecated">Deprecated</option>
               <option value="archived">Archived</option>
             </select>
           </div>
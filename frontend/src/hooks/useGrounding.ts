@@ -3,7 +3,10 @@ import {
   getGroundingRules, 
   createGroundingRule, 
   updateGroundingRule, 
-  deleteGroundingRule 
+  deleteGroundingRule,
+  getGroundingRuleVersions,
+  approveGroundingRuleVersion,
+  rejectGroundingRuleVersion
 } from '../api/grounding';
 import { GroundingRule } from '../types';
 
@@ -11,6 +14,7 @@ export const groundingKeys = {
   all: ['grounding'] as const,
   list: () => [...groundingKeys.all, 'list'] as const,
   detail: (id: string) => [...groundingKeys.all, 'detail', id] as const,
+  versions: (id: string) => [...groundingKeys.all, 'versions', id] as const,
 };
 
 export function useGroundingRules() {
@@ -38,6 +42,39 @@ export function useUpdateGroundingRule() {
     onSuccess: (_, variables) => {
       queryClient.invalidateQueries({ queryKey: groundingKeys.list() });
       queryClient.invalidateQueries({ queryKey: groundingKeys.detail(variables.id) });
+      queryClient.invalidateQueries({ queryKey: groundingKeys.versions(variables.id) });
+    },
+  });
+}
+
+export function useGroundingRuleVersions(id: string | undefined) {
+  return useQuery({
+    queryKey: groundingKeys.versions(id ?? ''),
+    queryFn: () => getGroundingRuleVersions(id!),
+    enabled: !!id,
+  });
+}
+
+type VersionReview = { id: string; version: number; comment?: string };
+
+export function useApproveGroundingRuleVersion() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: ({ id, version, comment }: VersionReview) => approveGroundingRuleVersion(id, version, comment),
+    onSuccess: (_, variables) => {
+      queryClient.invalidateQueries({ queryKey: groundingKeys.list() });
+      queryClient.invalidateQueries({ queryKey: groundingKeys.versions(variables.id) });
+    },
+  });
+}
+
+export function useRejectGroundingRuleVersion() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: ({ id, version, comment }: VersionReview) => rejectGroundingRuleVersion(id, version, comment),
+    onSuccess: (_, variables) => {
+      queryClient.invalidateQueries({ queryKey: groundingKeys.list() });
+      queryClient.invalidateQueries({ queryKey: groundingKeys.versions(variables.id) });
     },
   });
 }
@@ -1,11 +1,12 @@
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
-import { getMemories, searchMemories, giveMemoryFeedback } from '../api/memories';
+import { getMemories, searchMemories, giveMemoryFeedback, getMemoryRecalls } from '../api/memories';
 import { MemoryFeedback } from '../types';
 
 export const memoryKeys = {
   all: ['memories'] as const,
   list: () => [...memoryKeys.all, 'list'] as const,
   search: (query: string) => [...memoryKeys.all, 'search', query] as const,
+  recalls: (id: string) => [...memoryKeys.all, 'recalls', id] as const,
 };
 
 export function useMemories() {
@@ -23,6 +24,14 @@ export function useSearchMemories(query: string) {
   });
 }
 
+export function useMemoryRecalls(id: string | undefined) {
+  return useQuery({
+    queryKey: memoryKeys.recalls(id ?? ''),
+    queryFn: () => getMemoryRecalls(id!),
+    enabled: !!id,
+  });
+}
+
 export function useGiveMemoryFeedback() {
   const queryClient = useQueryClient();
   return useMutation({
@@ -0,0 +1,16 @@
+import { useQuery } from '@tanstack/react-query';
+import { getTenantStats } from '../api/stats';
+
+export const statsKeys = {
+  all: ['tenant-stats'] as const,
+  window: (days?: number) => [...statsKeys.all, days ?? 'default'] as const,
+};
+
+export function useTenantStats(days?: number) {
+  return useQuery({
+    queryKey: statsKeys.window(days),
+    queryFn: () => getTenantStats(days),
+    // The backend caches stats for a minute; polling faster gains nothing.
+    staleTime: 60_000,
+  });
+}
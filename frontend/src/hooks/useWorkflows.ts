@@ -1,6 +1,17 @@
 import { useQuery, useMutation, useQueryClient } from '@tanstack/react-query';
-import { getWorkflows, putWorkflow, deleteWorkflow, getTenant, getWorkflow } from '../api/workflows';
-import { WorkflowUpdatePayload } from '../types';
+import {
+  getWorkflows,
+  putWorkflow,
+  deleteWorkflow,
+  getTenant,
+  getWorkflow,
+  getWorkflowTree,
+  moveWorkflowElements,
+  cloneWorkflowSubtree,
+  getWorkflowRuns,
+  getWorkflowPerformance,
+} from '../api/workflows';
+import { WorkflowMove, WorkflowUpdatePayload } from '../types';
 
 /**
  * Key for caching workflow and tenant data
@@ -9,6 +20,10 @@ export const workflowKeys = {
   all: ['workflows'] as const,
   list: () => [...workflowKeys.all, 'list'] as const,
   details: (id: string) => [...workflowKeys.all, 'detail', id] as const,
+  trees: () => [...workflowKeys.all, 'tree'] as const,
+  tree: (workflowId: string, version?: number) => [...workflowKeys.trees(), workflowId, version ?? 'latest'] as const,
+  runs: (workflowId: string) => [...workflowKeys.all, 'runs', workflowId] as const,
+  performance: (workflowId: string, days?: number) => [...workflowKeys.all, 'performance', workflowId, days ?? 'default'] as const,
 };
 
 export const tenantKeys = {
@@ -48,6 +63,69 @@ export function useWorkflow(id: string | null) {
   });
 }
 
+/**
+ * Hook for fetching a whole workflow tree, e.g. to load it into the editor.
+ */
+export function useWorkflowTree(workflowId: string | null, version?: number) {
+  return useQuery({
+    queryKey: workflowKeys.tree(workflowId || '', version),
+    queryFn: () => getWorkflowTree(workflowId!, version),
+    enabled: !!workflowId,
+  });
+}
+
+/**
+ * Hook for fetching the runs of a workflow, e.g. to compare its versions.
+ */
+export function useWorkflowRuns(workflowId: string | null) {
+  return useQuery({
+    queryKey: workflowKeys.runs(workflowId || ''),
+    queryFn: () => getWorkflowRuns(workflowId!),
+    enabled: !!workflowId,
+  });
+}
+
+/**
+ * Hook for comparing the performance of the versions of a workflow.
+ */
+export function useWorkflowPerformance(workflowId: string | null, days?: number) {
+  return useQuery({
+    queryKey: workflowKeys.performance(workflowId || '', days),
+    queryFn: () => getWorkflowPerformance(workflowId!, days),
+    enabled: !!workflowId,
+  });
+}
+
+/**
+ * Hook for saving the arrangement of workflow elements in one request.
+ */
+export function useMoveWorkflowElements() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (moves: WorkflowMove[]) => moveWorkflowElements(moves),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: workflowKeys.list() });
+      queryClient.invalidateQueries({ queryKey: workflowKeys.trees() });
+    },
+  });
+}
+
+/**
+ * Hook for cloning a workflow subtree into new draft versions.
+ */
+export function useCloneWorkflowSubtree() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (id: string) => cloneWorkflowSubtree(id),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: workflowKeys.list() });
+      queryClient.invalidateQueries({ queryKey: workflowKeys.trees() });
+    },
+  });
+}
+
 /**
  * Hook for creating or updating a workflow.
  */
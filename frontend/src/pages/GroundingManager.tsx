@@ -3,15 +3,56 @@ import {
   useGroundingRules, 
   useCreateGroundingRule, 
   useUpdateGroundingRule, 
-  useDeleteGroundingRule 
+  useDeleteGroundingRule,
+  useGroundingRuleVersions,
+  useApproveGroundingRuleVersion,
+  useRejectGroundingRuleVersion
 } from '../hooks/useGrounding';
 import { GroundingRule } from '../types';
 
+// Lists the proposed changes to an active rule for a curator to review.
+const PendingVersions: React.FC<{ ruleId: string }> = ({ ruleId }) => {
+  const { data: versions } = useGroundingRuleVersions(ruleId);
+  const approveMutation = useApproveGroundingRuleVersion();
+  const rejectMutation = useRejectGroundingRuleVersion();
+
+  return (
+    <div className="space-y-2 mb-4">
+      {versions?.filter(v => v.status === 'pending').map(v => (
+        <div key={v.version} className="p-2 rounded-lg bg-amber-50 dark:bg-amber-900/20">
+          <div className="flex items-center justify-between">
+            <span className="text-xs text-amber-700 dark:text-amber-400">
+              Version {v.version}{v.proposed_by ? ` by ${v.proposed_by}` : ''}, awaiting approval
+            </span>
+            <div className="flex space-x-2">
+              <button
+                onClick={() => approveMutation.mutate({ id: ruleId, version: v.version })}
+                className="text-xs font-medium text-green-700 hover:underline dark:text-green-400"
+              >
+                Approve
+              </button>
+              <button
+                onClick={() => rejectMutation.mutate({ id: ruleId, version: v.version })}
+                className="text-xs font-medium text-red-600 hover:underline dark:text-red-400"
+              >
+                Reject
+              </button>
+            </div>
+          </div>
+          <p className="text-xs text-text-muted line-clamp-3 mt-1">{v.content}</p>
+        </div>
+      ))}
+    </div>
+  );
+};
+
 const GroundingManager: React.FC = () => {
   const { data: rules, isLoading } = useGroundingRules();
   const createMutation = useCreateGroundingRule();
   const updateMutation = useUpdateGroundingRule();
   const deleteMutation = useDeleteGroundingRule();
+  const approveMutation = useApproveGroundingRuleVersion();
+  const rejectMutation = useRejectGroundingRuleVersion();
 
   const [isModalOpen, setIsModalOpen] = useState(false);
   const [editingRule, setEditingRule] = useState<Partial<GroundingRule> | null>(null);
@@ -68,7 +109,7 @@ const GroundingManager: React.FC = () => {
                       <svg className="w-4 h-4" fill="none" viewBox="0 0 24 24" stroke="currentColor"><path strokeLinecap="round" strokeLinejoin="round" strokeWidth={2} d="M11 5H6a2 2 0 00-2 2v11a2 2 0 002 2h11a2 2 0 002-2v-5m-1.414-9.414a2 2 0 112.828 2.828L11.828 15H9v-2.828l8.586-8.586z" /></svg>
                     </button>
                     <button 
-                      onClick={() => { if(confirm("Delete rule?")) deleteMutation.mutate(rule.id); }}
+                      onClick={() => { if(confirm("Archive rule?")) deleteMutation.mutate(rule.id); }}
                       className="p-1 hover:text-red-500 transition-colors"
                     >
                       <svg className="w-4 h-4" fill="none" viewBox="0 0 24 24" stroke="currentColor"><path strokeLinecap="round" strokeLinejoin="round" strokeWidth={2} d="M19 7l-.867 12.142A2 2 0 0116.138 21H7.862a2 2 0 01-1.995-1.858L5 7m5 4v6m4-6v6m1-10V4a1 1 0 00-1-1h-4a1 1 0 00-1 1v3M4 7h16" /></svg>
@@ -78,12 +119,41 @@ const GroundingManager: React.FC = () => {
                 <p className="text-sm text-text-muted line-clamp-4 mb-4 leading-relaxed">
                   {rule.content}
                 </p>
+                {rule.status === 'pending' && (
+                  <div className="flex items-center justify-between mb-4 p-2 rounded-lg bg-amber-50 dark:bg-amber-900/20">
+                    <span className="text-xs text-amber-700 dark:text-amber-400">
+                      Proposed{rule.proposed_by ? ` by ${rule.proposed_by}` : ''}, awaiting approval
+                    </span>
+                    <div className="flex space-x-2">
+                      <button
+                        onClick={() => approveMutation.mutate({ id: rule.id, version: rule.version })}
+                        className="text-xs font-medium text-green-700 hover:underline dark:text-green-400"
+                      >
+                        Approve
+                      </button>
+                      <button
+                        onClick={() => rejectMutation.mutate({ id: rule.id, version: rule.version })}
+                        className="text-xs font-medium text-red-600 hover:underline dark:text-red-400"
+                      >
+                        Reject
+                      </button>
+                    </div>
+                  </div>
+                )}
+                {rule.status === 'active' && rule.pending_versions > 0 && (
+                  <PendingVersions ruleId={rule.id} />
+                )}
                 <div className="flex items-center justify-between mt-auto pt-4 border-t border-border-base/50">
                   <span className={`text-[10px] uppercase tracking-wider font-bold px-2 py-0.5 rounded-full ${rule.is_global ? 'bg-purple-100 text-purple-700 dark:bg-purple-900/30 dark:text-purple-400' : 'bg-blue-100 text-blue-700 dark:bg-blue-900/30 dark:text-blue-400'}`}>
                     {rule.is_global ? 'Global' : 'Tenant'}
                   </span>
+                  {rule.status === 'rejected' && (
+                    <span className="text-[10px] uppercase tracking-wider font-bold px-2 py-0.5 rounded-full bg-gray-100 text-gray-600 dark:bg-gray-800 dark:text-gray-400">
+                      Rejected
+                    </span>
+                  )}
                   <span className="text-[10px] text-text-muted">
-                    {new Date(rule.updated_at).toLocaleDateString()}
+                    v{rule.version} · {new Date(rule.updated_at).toLocaleDateString()}
                   </span>
                 </div>
               </div>
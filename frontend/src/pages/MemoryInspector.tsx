@@ -1,5 +1,5 @@
 import { useState } from 'react';
-import { useMemories, useSearchMemories, useGiveMemoryFeedback } from '../hooks/useMemories';
+import { useMemories, useSearchMemories, useGiveMemoryFeedback, useMemoryRecalls } from '../hooks/useMemories';
 import { Memory } from '../types';
 
 const MemoryInspector: React.FC = () => {
@@ -9,6 +9,7 @@ const MemoryInspector: React.FC = () => {
   const { data: allMemories, isLoading: listLoading } = useMemories();
   const { data: searchResults, isFetching: searchLoading } = useSearchMemories(searchQuery);
   const feedbackMutation = useGiveMemoryFeedback();
+  const { data: recalls } = useMemoryRecalls(selectedMemory?.id);
 
   const memories = searchQuery.length > 2 ? searchResults : allMemories;
   const isLoading = listLoading || (searchQuery.length > 2 && searchLoading);
@@ -161,6 +162,27 @@ const MemoryInspector: React.FC = () => {
                 ))}
               </div>
             </section>
+
+            <section className="space-y-3">
+              <h3 className="text-xs font-semibold text-text-muted uppercase tracking-wider">Recalls</h3>
+              {recalls?.length ? (
+                <div className="space-y-2">
+                  {recalls.map(recall => (
+                    <div key={recall.recalled_at} className="bg-bg-base p-3 rounded-xl border border-border-base text-[10px] space-y-1">
+                      <div className="text-text-muted">{new Date(recall.recalled_at).toLocaleString()}</div>
+                      {recall.grounding_rules.map(rule => (
+                        <div key={rule.rule_id} className="flex justify-between">
+                          <span className="text-text-base truncate pr-2">{rule.name ?? rule.rule_id}</span>
+                          <span className="text-primary font-mono">{rule.version ? `v${rule.version}` : '—'}</span>
+                        </div>
+                      ))}
+                    </div>
+                  ))}
+                </div>
+              ) : (
+                <p className="text-[10px] text-text-muted italic">Not recalled yet.</p>
+              )}
+            </section>
           </div>
         </div>
       )}
@@ -0,0 +1,59 @@
+export interface ConfidenceBand {
+  min: number;
+  max: number;
+  count: number;
+}
+
+export interface DailyCount {
+  day: string;
+  count: number;
+}
+
+export interface RecalledMemory {
+  memory_id: string;
+  content: string;
+  confidence: number;
+  recalls: number;
+}
+
+export interface LatencyPercentiles {
+  count: number;
+  p50_ms: number;
+  p90_ms: number;
+  p99_ms: number;
+  max_ms: number;
+}
+
+export interface GroundingRuleHits {
+  rule_id: string;
+  name: string;
+  hits: number;
+  hit_rate: number;
+}
+
+export interface GroundingStats {
+  recalls: number;
+  recalls_with_rules: number;
+  hit_rate: number;
+  rules: GroundingRuleHits[];
+}
+
+export interface WorkflowUsage {
+  workflow_id: string;
+  name: string;
+  memories: number;
+  recalls: number;
+}
+
+export interface TenantStats {
+  tenant_id: string;
+  window_days: number;
+  generated_at: string;
+  total_memories: number;
+  confidence_bands: ConfidenceBand[];
+  feedback_over_time: DailyCount[];
+  top_recalled_memories: RecalledMemory[];
+  recall_latency: LatencyPercentiles;
+  grounding: GroundingStats;
+  workflow_usage: WorkflowUsage[];
+}
@@ -1,4 +1,4 @@
-export type WorkflowStatus = 'draft' | 'active' | 'archived';
+export type WorkflowStatus = 'draft' | 'review' | 'published' | 'deprecated' | 'archived';
 export type ElementType = 'workflow' | 'element' | 'detail';
 
 export interface Workflow {
@@ -9,8 +9,10 @@ export interface Workflow {
   description: string;
   status: WorkflowStatus;
   version: number;
+  revision: number;
   is_latest: boolean;
   parent_id?: string;
+  position: number;
   element_type: ElementType;
   input_schema: Record<string, any>;
   output_schema?: Record<string, any>;
@@ -19,6 +21,117 @@ export interface Workflow {
   updated_at: string;
 }
 
+export interface WorkflowNode extends Workflow {
+  children: WorkflowNode[];
+}
+
+export interface WorkflowMove {
+  id: string;
+  parent_id?: string | null;
+  position: number;
+}
+
+export type WorkflowRunStatus = 'running' | 'succeeded' | 'failed' | 'cancelled';
+
+export interface WorkflowRun {
+  id: string;
+  tenant_id: string;
+  version_id: string;
+  workflow_id: string;
+  version: number;
+  status: WorkflowRunStatus;
+  inputs?: any;
+  outputs?: any;
+  error?: string;
+  recalled_memory_ids: string[];
+  created_memory_ids: string[];
+  started_by: string;
+  started_at: string;
+  updated_at: string;
+  completed_at?: string;
+  duration_ms?: number;
+}
+
+export type PerformanceVerdict = 'better' | 'worse' | 'mixed' | 'no_difference' | 'insufficient_data';
+
+export interface MetricComparison {
+  metric: 'run_success_rate' | 'positive_feedback_rate' | 'average_confidence';
+  baseline: number;
+  current: number;
+  delta: number;
+  baseline_samples: number;
+  current_samples: number;
+  significant: boolean;
+}
+
+export interface VersionPerformance {
+  version_id: string;
+  version: number;
+  status: WorkflowStatus;
+  memories: number;
+  average_confidence: number;
+  confidence_stddev: number;
+  feedback: number;
+  positive_feedback: number;
+  negative_feedback: number;
+  runs: number;
+  succeeded_runs: number;
+  failed_runs: number;
+  cancelled_runs: number;
+  median_run_duration_ms: number;
+  comparison?: {
+    baseline_version: number;
+    verdict: PerformanceVerdict;
+    metrics: MetricComparison[];
+  };
+}
+
+export interface WorkflowPerformance {
+  workflow_id: string;
+  window_days: number;
+  generated_at: string;
+  versions: VersionPerformance[];
+}
+
+export interface BundledWorkflow {
+  id: string;
+  workflow_id: string;
+  version: number;
+  parent_id?: string;
+  position: number;
+  name: string;
+  description?: string;
+  status: WorkflowStatus;
+  element_type: ElementType;
+  input_schema?: Record<string, unknown>;
+  output_schema?: Record<string, unknown>;
+}
+
+export interface BundledGroundingRule {
+  id?: string;
+  workflow_id: string;
+  name: string;
+  content: string;
+  status?: GroundingRuleStatus;
+}
+
+// A portable definition of a workflow, moved between environments by export
+// and import. IDs only link the entries of the bundle to each other.
+export interface WorkflowBundle {
+  format: 'evolutionary-mcp/workflow-bundle/v1';
+  exported_at: string;
+  workflow_id: string;
+  workflows: BundledWorkflow[];
+  grounding_rules: BundledGroundingRule[];
+}
+
+export interface WorkflowImport {
+  workflow_id: string;
+  ids: Record<string, string>;
+  workflows: Workflow[];
+  grounding_rules: GroundingRule[];
+}
+
 export interface WorkflowUpdatePayload extends Partial<Workflow> {
   save_as_new_version?: boolean;
 }
@@ -40,10 +153,52 @@ export interface GroundingRule {
   name: string;
   content: string;
   is_global: boolean;
+  status: GroundingRuleStatus;
+  proposed_by?: string;
+  // The version in effect.
+  version: number;
+  pending_versions: number;
   created_at: string;
   updated_at: string;
 }
 
+export type GroundingRuleStatus = 'pending' | 'active' | 'rejected' | 'archived';
+
+// A proposed or reviewed revision of a grounding rule. Only approved
+// versions take effect.
+export interface GroundingRuleVersion {
+  id: number;
+  tenant_id: string;
+  rule_id: string;
+  version: number;
+  base_version?: number;
+  workflow_id?: string;
+  name: string;
+  content: string;
+  is_global: boolean;
+  status: 'pending' | 'approved' | 'rejected';
+  proposed_by?: string;
+  reviewed_by?: string;
+  review_comment?: string;
+  created_at: string;
+  reviewed_at?: string;
+}
+
+export interface RecalledGroundingRule {
+  rule_id: string;
+  version?: number;
+  name?: string;
+  content?: string;
+}
+
+// A recall that returned a memory, with the grounding rule versions in
+// effect at the time.
+export interface MemoryRecall {
+  recalled_at: string;
+  latency_ms: number;
+  grounding_rules: RecalledGroundingRule[];
+}
+
 export interface Memory {
   id: string;
   content: string;
@@ -8,3 +8,10 @@ def get_embedding(text: str) -> list[float]:
     """
     embedding = model.encode(text)
     return embedding.tolist()
+
+def get_embeddings(texts: list[str]) -> list[list[float]]:
+    """
+    Generates embeddings for several texts in one forward pass.
+    """
+    embeddings = model.encode(texts)
+    return embeddings.tolist()
@@ -1,10 +1,13 @@
 from fastapi import FastAPI
 from pydantic import BaseModel
-from .embeddings import get_embedding
+from .embeddings import get_embedding, get_embeddings
 
 class Text(BaseModel):
     text: str
 
+class Texts(BaseModel):
+    texts: list[str]
+
 app = FastAPI()
 
 @app.get("/")
@@ -14,3 +17,7 @@ def read_root():
 @app.post("/embedding")
 def get_embedding_endpoint(text: Text):
     return get_embedding(text.text)
+
+@app.post("/embeddings")
+def get_embeddings_endpoint(texts: Texts):
+    return get_embeddings(texts.texts)